@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/request"
+	"xsis-code-test/utils"
+)
+
+func (ah *AppHandler) CreateGenre(w http.ResponseWriter, r *http.Request) {
+	var requestCreateGenre request.CreateGenre
+	if err := utils.ReadJson(w, r, &requestCreateGenre); err != nil {
+		utils.WriteError(w, r, requestBodyError(err))
+		return
+	}
+
+	data, err := ah.AppUsecase.CreateGenre(requestCreateGenre)
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	jsonResponse := utils.JSONResponse{
+		Error:   false,
+		Message: "Success Created Genre",
+		Data:    data,
+	}
+	utils.WriteJson(w, http.StatusCreated, jsonResponse)
+	return
+}
+
+func (ah *AppHandler) ListGenre(w http.ResponseWriter, r *http.Request) {
+	data, err := ah.AppUsecase.ListGenre()
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	jsonResponse := utils.JSONResponse{
+		Error:   false,
+		Message: "Success Listing Genres",
+		Data:    data,
+	}
+	utils.WriteJson(w, http.StatusOK, jsonResponse)
+	return
+}
+
+func (ah *AppHandler) GetGenre(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		utils.WriteError(w, r, apperror.BadRequest("Id is not a numeric"))
+		return
+	}
+
+	data, err := ah.AppUsecase.GetGenre(int64(idInt))
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	jsonResponse := utils.JSONResponse{
+		Error:   false,
+		Message: "Success Getting Genre",
+		Data:    data,
+	}
+	utils.WriteJson(w, http.StatusOK, jsonResponse)
+	return
+}
+
+func (ah *AppHandler) UpdateGenre(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		utils.WriteError(w, r, apperror.BadRequest("Id is not a numeric"))
+		return
+	}
+
+	var requestUpdateGenre request.UpdateGenre
+	if err := utils.ReadJson(w, r, &requestUpdateGenre); err != nil {
+		utils.WriteError(w, r, requestBodyError(err))
+		return
+	}
+
+	if err := ah.AppUsecase.UpdateGenre(int64(idInt), requestUpdateGenre); err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	jsonResponse := utils.JSONResponse{
+		Error:   false,
+		Message: "Genre Successfully Updated",
+	}
+	utils.WriteJson(w, http.StatusOK, jsonResponse)
+	return
+}
+
+func (ah *AppHandler) DeleteGenre(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		utils.WriteError(w, r, apperror.BadRequest("Id is not a numeric"))
+		return
+	}
+
+	if err := ah.AppUsecase.DeleteGenre(int64(idInt)); err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	jsonResponse := utils.JSONResponse{
+		Error:   false,
+		Message: "Genre Successfully Deleted",
+	}
+	utils.WriteJson(w, http.StatusOK, jsonResponse)
+	return
+}
+
+func (ah *AppHandler) ListGenreMovie(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		utils.WriteError(w, r, apperror.BadRequest("Id is not a numeric"))
+		return
+	}
+
+	req, err := parseListMovie(r)
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	data, pagination, err := ah.AppUsecase.ListGenreMovie(int64(idInt), req)
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+	pagination.Next, pagination.Prev = paginationLinks(r, pagination)
+
+	jsonResponse := utils.JSONResponse{
+		Error:   false,
+		Message: "Success Listing Genre Movies",
+		Data:    data,
+		Meta:    pagination,
+	}
+	utils.WriteJson(w, http.StatusOK, jsonResponse)
+	return
+}
@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/go-chi/chi/v5/middleware"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"time"
+	"xsis-code-test/app"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+	"xsis-code-test/utils"
+)
+
+const (
+	idempotencyKeyHeader      = "Idempotency-Key"
+	idempotencyReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	idempotencyMemoryBody     = 1 << 20
+)
+
+type idempotencyRecorder struct {
+	http.ResponseWriter
+	status int
+	header http.Header
+	body   bytes.Buffer
+}
+
+func (ir *idempotencyRecorder) WriteHeader(status int) {
+	if ir.header == nil {
+		ir.status = status
+		ir.header = ir.ResponseWriter.Header().Clone()
+	}
+	ir.ResponseWriter.WriteHeader(status)
+}
+
+func (ir *idempotencyRecorder) Write(data []byte) (int, error) {
+	if ir.header == nil {
+		ir.WriteHeader(http.StatusOK)
+	}
+	ir.body.Write(data)
+	return ir.ResponseWriter.Write(data)
+}
+
+type spooledBody struct {
+	*os.File
+}
+
+func (sb *spooledBody) Close() error {
+	err := sb.File.Close()
+	os.Remove(sb.File.Name())
+	return err
+}
+
+func Idempotency(store app.IAppIdempotencyRepository, ttl time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(idempotencyKeyHeader)
+			if key == "" || r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				utils.WriteError(w, r, apperror.BadRequest(fmt.Sprintf("Idempotency-Key Cannot Be Longer Than %d Characters", maxIdempotencyKeyLength)))
+				return
+			}
+
+			fingerprint, body, err := fingerprintRequest(w, r)
+			if err != nil {
+				utils.WriteError(w, r, apperror.BadRequest("Cannot Read Request Body"))
+				return
+			}
+			defer body.Close()
+			r.Body = body
+
+			now := time.Now()
+			existing, err := store.ReserveIdempotencyKey(model.IdempotencyRecord{
+				Key:         key,
+				Fingerprint: fingerprint,
+				CreatedAt:   now,
+				ExpiresAt:   now.Add(ttl),
+			})
+			if err != nil {
+				utils.WriteError(w, r, err)
+				return
+			}
+			if existing != nil {
+				replayIdempotentResponse(w, r, *existing, fingerprint)
+				return
+			}
+
+			recorder := &idempotencyRecorder{ResponseWriter: w}
+			completed := false
+			defer func() {
+				if !completed {
+					if err := store.ReleaseIdempotencyKey(key); err != nil {
+						log.Println("Cannot Release Idempotency Key", err)
+					}
+				}
+			}()
+			next.ServeHTTP(recorder, r)
+			if recorder.header == nil {
+				recorder.WriteHeader(http.StatusOK)
+			}
+			if recorder.status >= http.StatusInternalServerError {
+				return
+			}
+
+			recorder.header.Del(middleware.RequestIDHeader)
+			header, err := json.Marshal(recorder.header)
+			if err != nil {
+				log.Println("Cannot Encode Idempotent Response", err)
+				return
+			}
+			err = store.SaveIdempotencyKey(model.IdempotencyRecord{
+				Key:    key,
+				Status: recorder.status,
+				Header: string(header),
+				Body:   recorder.body.Bytes(),
+			})
+			if err != nil {
+				log.Println("Cannot Save Idempotent Response", err)
+				return
+			}
+			completed = true
+		})
+	}
+}
+
+func fingerprintRequest(w http.ResponseWriter, r *http.Request) (string, io.ReadCloser, error) {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s %s\n", r.Method, r.URL.RequestURI())
+	source := io.TeeReader(http.MaxBytesReader(w, r.Body, importMaxBytes), hash)
+
+	var buffer bytes.Buffer
+	n, err := io.CopyN(&buffer, source, idempotencyMemoryBody+1)
+	if err != nil && err != io.EOF {
+		return "", nil, err
+	}
+	if n <= idempotencyMemoryBody {
+		return hex.EncodeToString(hash.Sum(nil)), io.NopCloser(&buffer), nil
+	}
+
+	file, err := os.CreateTemp("", "idempotent-body-*")
+	if err != nil {
+		return "", nil, err
+	}
+	body := &spooledBody{File: file}
+	if _, err := file.Write(buffer.Bytes()); err != nil {
+		body.Close()
+		return "", nil, err
+	}
+	if _, err := io.Copy(file, source); err != nil {
+		body.Close()
+		return "", nil, err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		body.Close()
+		return "", nil, err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), body, nil
+}
+
+func replayIdempotentResponse(w http.ResponseWriter, r *http.Request, record model.IdempotencyRecord, fingerprint string) {
+	if record.Fingerprint != fingerprint {
+		utils.WriteError(w, r, apperror.Validation("Idempotency-Key Was Already Used With A Different Request"))
+		return
+	}
+	if !record.Completed {
+		utils.WriteError(w, r, apperror.Conflict("A Request With This Idempotency-Key Is Still In Progress"))
+		return
+	}
+
+	header := http.Header{}
+	if err := json.Unmarshal([]byte(record.Header), &header); err != nil {
+		utils.WriteError(w, r, apperror.Internal("Cannot Decode Idempotent Response", err))
+		return
+	}
+	for name, values := range header {
+		w.Header()[name] = values
+	}
+	w.Header().Set(idempotencyReplayedHeader, "true")
+	w.WriteHeader(record.Status)
+	w.Write(record.Body)
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"xsis-code-test/app/repository"
+)
+
+func TestIdempotency(t *testing.T) {
+	calls := 0
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		if string(body) == `{"fail":true}` {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Location", "/Movie/1")
+		w.WriteHeader(http.StatusCreated)
+		w.Write(body)
+	})
+	handler := Idempotency(repository.NewMemoryIdempotencyRepository(), time.Hour)(next)
+
+	send := func(key, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/Movie", strings.NewReader(body))
+		if key != "" {
+			r.Header.Set("Idempotency-Key", key)
+		}
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	first := send("create-1", `{"title":"Dans 1"}`)
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Equal(t, 1, calls)
+
+	retry := send("create-1", `{"title":"Dans 1"}`)
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, `{"title":"Dans 1"}`, retry.Body.String())
+	assert.Equal(t, "/Movie/1", retry.Header().Get("Location"))
+	assert.Equal(t, "true", retry.Header().Get("Idempotent-Replayed"))
+	assert.Equal(t, 1, calls)
+
+	mismatch := send("create-1", `{"title":"Dans 2"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, mismatch.Code)
+	assert.Equal(t, 1, calls)
+
+	send("create-2", `{"fail":true}`)
+	send("create-2", `{"fail":true}`)
+	assert.Equal(t, 3, calls)
+
+	send("", `{"title":"Dans 1"}`)
+	send("", `{"title":"Dans 1"}`)
+	assert.Equal(t, 5, calls)
+
+	tooLong := send(strings.Repeat("k", 256), `{}`)
+	assert.Equal(t, http.StatusBadRequest, tooLong.Code)
+}
+
+func TestIdempotency_LargeBody(t *testing.T) {
+	var received int
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = len(body)
+		w.WriteHeader(http.StatusAccepted)
+	})
+	handler := Idempotency(repository.NewMemoryIdempotencyRepository(), time.Hour)(next)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/Movie/import", strings.NewReader(strings.Repeat("x", idempotencyMemoryBody+10)))
+	r.Header.Set("Idempotency-Key", "import-1")
+	handler.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Equal(t, idempotencyMemoryBody+10, received)
+}
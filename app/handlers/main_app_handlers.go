@@ -3,7 +3,8 @@ package handlers
 import "xsis-code-test/app"
 
 type AppHandler struct {
-	AppUsecase app.IAppUsecase
+	AppUsecase   app.IAppUsecase
+	CacheControl string
 }
 
 func NewAppHandler(appUsecase app.IAppUsecase) *AppHandler {
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/request"
+	"xsis-code-test/utils"
+)
+
+func (ah *AppHandler) BulkMovie(w http.ResponseWriter, r *http.Request) {
+	var requestBulkMovie request.BulkMovie
+	if err := utils.ReadJson(w, r, &requestBulkMovie); err != nil {
+		utils.WriteError(w, r, requestBodyError(err))
+		return
+	}
+	requestBulkMovie.Actor = r.Header.Get("X-Actor")
+
+	data, err := ah.AppUsecase.BulkMovie(requestBulkMovie)
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	for i := range data.Results {
+		result := &data.Results[i]
+		if result.Err == nil {
+			result.Status = http.StatusOK
+			if result.Operation == "create" {
+				result.Status = http.StatusCreated
+			}
+			continue
+		}
+		result.Status = utils.ErrorStatus(result.Err)
+		var validationErrors utils.ValidationErrors
+		if errors.As(result.Err, &validationErrors) {
+			result.Error = "Validation Failed"
+			result.Errors = validationErrors
+			continue
+		}
+		var appErr *apperror.Error
+		if result.Status == http.StatusInternalServerError && !errors.As(result.Err, &appErr) {
+			result.Error = "Internal Server Error"
+			continue
+		}
+		result.Error = result.Err.Error()
+	}
+
+	status := http.StatusOK
+	message := "Bulk Operations Completed"
+	if data.Failed > 0 {
+		status = http.StatusMultiStatus
+		message = "Bulk Operations Completed With Failures"
+	}
+	jsonResponse := utils.JSONResponse{
+		Error:   false,
+		Message: message,
+		Data:    data,
+	}
+	utils.WriteJson(w, status, jsonResponse)
+	return
+}
@@ -0,0 +1,22 @@
+package handlers
+
+import (
+	"net/http"
+	"xsis-code-test/utils"
+)
+
+func (ah *AppHandler) ListDuplicates(w http.ResponseWriter, r *http.Request) {
+	data, err := ah.AppUsecase.ListDuplicates()
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	jsonResponse := utils.JSONResponse{
+		Error:   false,
+		Message: "Success Listing Suspected Duplicate Movies",
+		Data:    data,
+	}
+	utils.WriteJson(w, http.StatusOK, jsonResponse)
+	return
+}
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/response"
+	"xsis-code-test/utils"
+)
+
+var exportColumns = []string{"id", "title", "description", "rating", "image", "created_at", "updated_at", "version"}
+
+type movieExporter interface {
+	Write(response.ListMovie) error
+	Close() error
+}
+
+type exportFormat struct {
+	contentType string
+	extension   string
+	open        func(io.Writer) (movieExporter, error)
+}
+
+var exportFormats = map[string]exportFormat{
+	"csv":    {contentType: "text/csv; charset=utf-8", extension: "csv", open: newCSVExporter},
+	"ndjson": {contentType: "application/x-ndjson", extension: "ndjson", open: newNDJSONExporter},
+	"xlsx":   {contentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", extension: "xlsx", open: newXLSXExporter},
+}
+
+func (ah *AppHandler) ExportMovie(w http.ResponseWriter, r *http.Request) {
+	formatName := strings.ToLower(r.URL.Query().Get("format"))
+	if formatName == "" {
+		formatName = "csv"
+	}
+	format, ok := exportFormats[formatName]
+	if !ok {
+		utils.WriteError(w, r, apperror.BadRequest("format Must Be csv, ndjson or xlsx"))
+		return
+	}
+
+	req, err := parseListMovie(r)
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	var (
+		exporter movieExporter
+		started  bool
+	)
+	start := func() (err error) {
+		started = true
+		w.Header().Set("Content-Type", format.contentType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="movies-%s.%s"`, time.Now().Format("20060102-150405"), format.extension))
+		w.WriteHeader(http.StatusOK)
+		exporter, err = format.open(w)
+		return err
+	}
+
+	err = ah.AppUsecase.ExportMovie(req, func(movie response.ListMovie) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		return exporter.Write(movie)
+	})
+	if err == nil && !started {
+		err = start()
+	}
+	if err == nil {
+		err = exporter.Close()
+	}
+	if err != nil {
+		if !started {
+			utils.WriteError(w, r, err)
+			return
+		}
+		log.Println("Cannot Finish Movie Export", err)
+		panic(http.ErrAbortHandler)
+	}
+	return
+}
+
+type csvExporter struct {
+	writer *csv.Writer
+}
+
+func newCSVExporter(w io.Writer) (movieExporter, error) {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(exportColumns); err != nil {
+		return nil, err
+	}
+	return &csvExporter{writer: writer}, nil
+}
+
+func (ce *csvExporter) Write(movie response.ListMovie) error {
+	return ce.writer.Write([]string{
+		strconv.FormatInt(movie.ID, 10),
+		movie.Title,
+		movie.Description,
+		strconv.FormatFloat(float64(movie.Rating), 'f', -1, 32),
+		movie.Image,
+		movie.CreatedAt,
+		movie.UpdatedAt,
+		strconv.FormatInt(movie.Version, 10),
+	})
+}
+
+func (ce *csvExporter) Close() error {
+	ce.writer.Flush()
+	return ce.writer.Error()
+}
+
+type ndjsonExporter struct {
+	buffer  *bufio.Writer
+	encoder *json.Encoder
+}
+
+func newNDJSONExporter(w io.Writer) (movieExporter, error) {
+	buffer := bufio.NewWriter(w)
+	return &ndjsonExporter{buffer: buffer, encoder: json.NewEncoder(buffer)}, nil
+}
+
+func (ne *ndjsonExporter) Write(movie response.ListMovie) error {
+	return ne.encoder.Encode(movie)
+}
+
+func (ne *ndjsonExporter) Close() error {
+	return ne.buffer.Flush()
+}
+
+type xlsxExporter struct {
+	writer *utils.XLSXWriter
+}
+
+func newXLSXExporter(w io.Writer) (movieExporter, error) {
+	writer, err := utils.NewXLSXWriter(w, "Movies")
+	if err != nil {
+		return nil, err
+	}
+	header := make([]any, 0, len(exportColumns))
+	for _, column := range exportColumns {
+		header = append(header, column)
+	}
+	if err := writer.WriteRow(header...); err != nil {
+		return nil, err
+	}
+	return &xlsxExporter{writer: writer}, nil
+}
+
+func (xe *xlsxExporter) Write(movie response.ListMovie) error {
+	return xe.writer.WriteRow(movie.ID, movie.Title, movie.Description, movie.Rating, movie.Image, movie.CreatedAt, movie.UpdatedAt, movie.Version)
+}
+
+func (xe *xlsxExporter) Close() error {
+	return xe.writer.Close()
+}
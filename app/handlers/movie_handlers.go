@@ -1,24 +1,33 @@
 package handlers
 
 import (
-	"errors"
+	"fmt"
 	"github.com/go-chi/chi/v5"
 	"net/http"
 	"strconv"
+	"xsis-code-test/apperror"
 	"xsis-code-test/models/request"
 	"xsis-code-test/utils"
 )
 
 func (ah *AppHandler) CreateMovie(w http.ResponseWriter, r *http.Request) {
 	var requestCreateMovie request.CreateMovie
-	if err := utils.ReadJson(w, r, &requestCreateMovie); err != nil {
-		utils.ErrorJson(w, err, http.StatusBadRequest)
+	err := utils.ReadJson(w, r, &requestCreateMovie)
+	if err != nil {
+		utils.WriteError(w, r, requestBodyError(err))
 		return
 	}
+	requestCreateMovie.Actor = r.Header.Get("X-Actor")
+	if value := r.URL.Query().Get("force"); value != "" {
+		if requestCreateMovie.Force, err = strconv.ParseBool(value); err != nil {
+			utils.WriteError(w, r, apperror.BadRequest("force is not a valid boolean"))
+			return
+		}
+	}
 
-	err := ah.AppUsecase.CreateMovie(requestCreateMovie)
+	err = ah.AppUsecase.CreateMovie(requestCreateMovie)
 	if err != nil {
-		utils.ErrorJson(w, err, http.StatusNotAcceptable)
+		utils.WriteError(w, r, err)
 		return
 	}
 	jsonResponse := utils.JSONResponse{
@@ -30,18 +39,26 @@ func (ah *AppHandler) CreateMovie(w http.ResponseWriter, r *http.Request) {
 }
 
 func (ah *AppHandler) ListMovie(w http.ResponseWriter, r *http.Request) {
-	data, err := ah.AppUsecase.ListMovie()
+	req, err := parseListMovie(r)
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	data, pagination, err := ah.AppUsecase.ListMovie(req)
 	if err != nil {
-		utils.ErrorJson(w, err, http.StatusNotAcceptable)
+		utils.WriteError(w, r, err)
 		return
 	}
+	pagination.Next, pagination.Prev = paginationLinks(r, pagination)
 
 	jsonResponse := utils.JSONResponse{
 		Error:   false,
 		Message: "Success Listing Movies",
 		Data:    data,
+		Meta:    pagination,
 	}
-	utils.WriteJson(w, http.StatusAccepted, jsonResponse)
+	utils.WriteCachedJson(w, r, http.StatusAccepted, jsonResponse, utils.Cache{CacheControl: ah.CacheControl})
 	return
 }
 
@@ -49,21 +66,46 @@ func (ah *AppHandler) GetMovie(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	idInt, err := strconv.Atoi(id)
 	if err != nil {
-		utils.ErrorJson(w, errors.New("Id is not a numeric"), http.StatusNotAcceptable)
+		utils.WriteError(w, r, apperror.BadRequest("Id is not a numeric"))
 		return
 	}
+	includeCredits := false
+	if include := r.URL.Query().Get("include"); include != "" {
+		if include != "credits" {
+			utils.WriteError(w, r, apperror.BadRequest(fmt.Sprintf("include %s is not supported", include)))
+			return
+		}
+		includeCredits = true
+	}
 	data, err := ah.AppUsecase.GetMovie(int64(idInt))
+	if apperror.Is(err, apperror.KindNotFound) {
+		if survivor, mergedErr := ah.AppUsecase.GetMergedMovieID(int64(idInt)); mergedErr == nil {
+			http.Redirect(w, r, fmt.Sprintf("/Movie/%d", survivor), http.StatusMovedPermanently)
+			return
+		}
+	}
 	if err != nil {
-		utils.ErrorJson(w, err, http.StatusNotAcceptable)
+		utils.WriteError(w, r, err)
 		return
 	}
 
+	cache := utils.Cache{ETag: utils.VersionETag(data.Version), LastModified: data.Modified, CacheControl: ah.CacheControl}
+	if includeCredits {
+		credits, err := ah.AppUsecase.ListMovieCredits(data.ID)
+		if err != nil {
+			utils.WriteError(w, r, err)
+			return
+		}
+		data.Credits = *credits
+		cache = utils.Cache{CacheControl: ah.CacheControl}
+	}
+
 	jsonResponse := utils.JSONResponse{
 		Error:   false,
 		Message: "Success Getting Movie",
 		Data:    data,
 	}
-	utils.WriteJson(w, http.StatusAccepted, jsonResponse)
+	utils.WriteCachedJson(w, r, http.StatusAccepted, jsonResponse, cache)
 	return
 }
 
@@ -71,18 +113,73 @@ func (ah *AppHandler) UpdateMovie(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	idInt, err := strconv.Atoi(id)
 	if err != nil {
-		utils.ErrorJson(w, errors.New("Id is not a numeric"), http.StatusNotAcceptable)
+		utils.WriteError(w, r, apperror.BadRequest("Id is not a numeric"))
 		return
 	}
 
-	var requestUpdateMovie request.UpdateMovie
-	if err := utils.ReadJson(w, r, &requestUpdateMovie); err != nil {
-		utils.ErrorJson(w, err, http.StatusBadRequest)
+	version, err := utils.IfMatchVersion(r)
+	if err != nil {
+		utils.WriteError(w, r, err)
 		return
 	}
 
-	if err := ah.AppUsecase.UpdateMovie(int64(idInt), requestUpdateMovie); err != nil {
-		utils.ErrorJson(w, err, http.StatusNotAcceptable)
+	switch patchMediaType(r) {
+	case jsonPatchMediaType:
+		var operations []request.PatchOperation
+		if err := utils.ReadJson(w, r, &operations); err != nil {
+			utils.WriteError(w, r, requestBodyError(err))
+			return
+		}
+		requestPatchMovie := request.PatchMovie{Operations: operations, Actor: r.Header.Get("X-Actor"), Version: version}
+		err = ah.AppUsecase.PatchMovie(int64(idInt), requestPatchMovie)
+	case mergePatchMediaType, "application/json", "":
+		var requestUpdateMovie request.UpdateMovie
+		if requestUpdateMovie, err = readMergePatch(w, r); err != nil {
+			utils.WriteError(w, r, err)
+			return
+		}
+		requestUpdateMovie.Actor = r.Header.Get("X-Actor")
+		requestUpdateMovie.Version = version
+		err = ah.AppUsecase.UpdateMovie(int64(idInt), requestUpdateMovie)
+	default:
+		w.Header().Set("Accept-Patch", mergePatchMediaType+", "+jsonPatchMediaType)
+		err = apperror.UnsupportedMediaType("Unsupported Content-Type For PATCH")
+	}
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	jsonResponse := utils.JSONResponse{
+		Error:   false,
+		Message: "Movie Successfully Updated",
+	}
+	utils.WriteJson(w, http.StatusOK, jsonResponse)
+	return
+}
+
+func (ah *AppHandler) ReplaceMovie(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		utils.WriteError(w, r, apperror.BadRequest("Id is not a numeric"))
+		return
+	}
+
+	var requestReplaceMovie request.ReplaceMovie
+	if err := utils.ReadJson(w, r, &requestReplaceMovie); err != nil {
+		utils.WriteError(w, r, requestBodyError(err))
+		return
+	}
+	requestReplaceMovie.Actor = r.Header.Get("X-Actor")
+	if requestReplaceMovie.Version, err = utils.IfMatchVersion(r); err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	err = ah.AppUsecase.ReplaceMovie(int64(idInt), requestReplaceMovie)
+	if err != nil {
+		utils.WriteError(w, r, err)
 		return
 	}
 
@@ -98,12 +195,40 @@ func (ah *AppHandler) DeleteMovie(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	idInt, err := strconv.Atoi(id)
 	if err != nil {
-		utils.ErrorJson(w, errors.New("Id is not a numeric"), http.StatusNotAcceptable)
+		utils.WriteError(w, r, apperror.BadRequest("Id is not a numeric"))
+		return
+	}
+
+	hard := false
+	if value := r.URL.Query().Get("hard"); value != "" {
+		if hard, err = strconv.ParseBool(value); err != nil {
+			utils.WriteError(w, r, apperror.BadRequest("hard is not a valid boolean"))
+			return
+		}
+	}
+
+	version, err := utils.IfMatchVersion(r)
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+	requestDeleteMovie := request.DeleteMovie{Actor: r.Header.Get("X-Actor"), Version: version}
+
+	if hard {
+		if err := ah.AppUsecase.PurgeMovie(int64(idInt), requestDeleteMovie); err != nil {
+			utils.WriteError(w, r, err)
+			return
+		}
+		jsonResponse := utils.JSONResponse{
+			Error:   false,
+			Message: "Movie Permanently Deleted",
+		}
+		utils.WriteJson(w, http.StatusOK, jsonResponse)
 		return
 	}
 
-	if err := ah.AppUsecase.DeleteMovie(int64(idInt)); err != nil {
-		utils.ErrorJson(w, err, http.StatusNotAcceptable)
+	if err := ah.AppUsecase.DeleteMovie(int64(idInt), requestDeleteMovie); err != nil {
+		utils.WriteError(w, r, err)
 		return
 	}
 
@@ -114,3 +239,60 @@ func (ah *AppHandler) DeleteMovie(w http.ResponseWriter, r *http.Request) {
 	utils.WriteJson(w, http.StatusOK, jsonResponse)
 	return
 }
+
+func (ah *AppHandler) SearchMovie(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	page, err := queryInt(query, "page")
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+	pageSize, err := queryInt(query, "page_size")
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	data, pagination, err := ah.AppUsecase.SearchMovie(request.SearchMovie{
+		Query:    query.Get("q"),
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+	pagination.Next, pagination.Prev = paginationLinks(r, pagination)
+
+	jsonResponse := utils.JSONResponse{
+		Error:   false,
+		Message: "Success Searching Movies",
+		Data:    data,
+		Meta:    pagination,
+	}
+	utils.WriteJson(w, http.StatusAccepted, jsonResponse)
+	return
+}
+
+func (ah *AppHandler) SuggestMovie(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	limit, err := queryInt(query, "limit")
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	data, err := ah.AppUsecase.SuggestMovie(query.Get("prefix"), limit)
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	jsonResponse := utils.JSONResponse{
+		Error:   false,
+		Message: "Success Suggesting Movies",
+		Data:    data,
+	}
+	utils.WriteJson(w, http.StatusAccepted, jsonResponse)
+	return
+}
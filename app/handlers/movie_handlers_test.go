@@ -7,13 +7,21 @@ import (
 	"errors"
 	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/textproto"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 	"xsis-code-test/app/usecase"
+	"xsis-code-test/apperror"
 	"xsis-code-test/models/request"
 	"xsis-code-test/models/response"
+	"xsis-code-test/utils"
 )
 
 var mockAppUsecase = new(usecase.MockAppUsecase)
@@ -39,8 +47,8 @@ func TestCreateMovie(t *testing.T) {
 		},
 		{
 			name:           "empty title",
-			expectedcode:   http.StatusNotAcceptable,
-			expectedresult: errors.New("Movie Title Cannot Be Empty"),
+			expectedcode:   http.StatusUnprocessableEntity,
+			expectedresult: apperror.Validation("Movie Title Cannot Be Empty"),
 			input: request.CreateMovie{
 				Title:       "",
 				Description: "Dans 1",
@@ -50,8 +58,8 @@ func TestCreateMovie(t *testing.T) {
 		},
 		{
 			name:           "empty description",
-			expectedcode:   http.StatusNotAcceptable,
-			expectedresult: errors.New("Movie Description Cannot Be Empty"),
+			expectedcode:   http.StatusUnprocessableEntity,
+			expectedresult: apperror.Validation("Movie Description Cannot Be Empty"),
 			input: request.CreateMovie{
 				Title:       "Dans 1",
 				Description: "",
@@ -61,8 +69,8 @@ func TestCreateMovie(t *testing.T) {
 		},
 		{
 			name:           "rating not valid",
-			expectedcode:   http.StatusNotAcceptable,
-			expectedresult: errors.New("Rating between 0 to 10"),
+			expectedcode:   http.StatusUnprocessableEntity,
+			expectedresult: apperror.Validation("Rating between 0 to 10"),
 			input: request.CreateMovie{
 				Title:       "Dans 1",
 				Description: "Dans 1",
@@ -72,8 +80,8 @@ func TestCreateMovie(t *testing.T) {
 		},
 		{
 			name:           "image empty",
-			expectedcode:   http.StatusNotAcceptable,
-			expectedresult: errors.New("Image Cannot Be Empty"),
+			expectedcode:   http.StatusUnprocessableEntity,
+			expectedresult: apperror.Validation("Image Cannot Be Empty"),
 			input: request.CreateMovie{
 				Title:       "Dans 1",
 				Description: "Dans 1",
@@ -81,6 +89,20 @@ func TestCreateMovie(t *testing.T) {
 				Image:       "",
 			},
 		},
+		{
+			name:         "validation errors",
+			expectedcode: http.StatusUnprocessableEntity,
+			expectedresult: utils.ValidationErrors{
+				{Field: "title", Code: "required", Message: "Title Cannot Be Empty", Value: ""},
+				{Field: "rating", Code: "lte", Message: "Rating Must Be Less Than Or Equal To 10", Value: float32(12)},
+			},
+			input: request.CreateMovie{
+				Title:       "",
+				Description: "Dans 1",
+				Rating:      12,
+				Image:       "fafa.jpg",
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -99,17 +121,41 @@ func TestCreateMovie(t *testing.T) {
 	}
 }
 
+func TestCreateMovie_BodyTooLarge(t *testing.T) {
+	body := `{"title":"` + strings.Repeat("a", 1<<20) + `"}`
+	r := httptest.NewRequest("POST", "/Movie", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	appHandler.CreateMovie(w, r)
+
+	var problem utils.Problem
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Equal(t, "/problems/payload-too-large", problem.Type)
+	assert.Equal(t, "request Body Cannot Be Larger Than 1 MB", problem.Detail)
+
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest("POST", "/Movie", strings.NewReader(`{"title":`))
+	r.Header.Set("Content-Type", "application/json")
+	appHandler.CreateMovie(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
 func TestListMovie(t *testing.T) {
 	testcases := []struct {
 		name            string
 		expectedcode    int
+		expectedrequest request.ListMovie
 		expectedresult1 *[]response.ListMovie
-		expectedresult2 error
+		expectedresult2 *response.Pagination
+		expectedresult3 error
+		expectednext    string
 		path            string
 	}{
 		{
-			name:         "valid",
-			expectedcode: http.StatusAccepted,
+			name:            "valid",
+			expectedcode:    http.StatusAccepted,
+			expectedrequest: request.ListMovie{},
 			expectedresult1: &[]response.ListMovie{
 				{
 					ID:          1,
@@ -130,9 +176,75 @@ func TestListMovie(t *testing.T) {
 					UpdatedAt:   "2024-01-13 00:00:00",
 				},
 			},
-			expectedresult2: nil,
+			expectedresult2: &response.Pagination{Total: total(2), Page: 1, PageSize: 10, Limit: 10},
+			expectedresult3: nil,
 			path:            "/Movie",
 		},
+		{
+			name:         "paginated and filtered",
+			expectedcode: http.StatusAccepted,
+			expectedrequest: request.ListMovie{
+				Page:          2,
+				PageSize:      1,
+				Sort:          []string{"rating", "-created_at"},
+				TitleContains: "Dans",
+			},
+			expectedresult1: &[]response.ListMovie{
+				{
+					ID:          2,
+					Title:       "Dans 2",
+					Description: "Dans 2",
+					Rating:      7,
+					Image:       "ta2.jpg",
+					CreatedAt:   "2024-01-13 00:00:00",
+					UpdatedAt:   "2024-01-13 00:00:00",
+				},
+			},
+			expectedresult2: &response.Pagination{Total: total(3), Page: 2, PageSize: 1, Limit: 1, Offset: 1},
+			expectedresult3: nil,
+			expectednext:    "/Movie?page=3&page_size=1&sort=rating%2C-created_at&title_contains=Dans",
+			path:            "/Movie?page=2&page_size=1&sort=rating,-created_at&title_contains=Dans",
+		},
+		{
+			name:            "cursor",
+			expectedcode:    http.StatusAccepted,
+			expectedrequest: request.ListMovie{Limit: 2, Cursor: "abc.def"},
+			expectedresult1: &[]response.ListMovie{},
+			expectedresult2: &response.Pagination{Limit: 2},
+			expectedresult3: nil,
+			path:            "/Movie?limit=2&cursor=abc.def",
+		},
+		{
+			name:         "metadata filters",
+			expectedcode: http.StatusAccepted,
+			expectedrequest: request.ListMovie{
+				Sort:           []string{"-release_date"},
+				MinRuntime:     ptr(90),
+				Language:       "fr",
+				SpokenLanguage: "en",
+				Country:        "FR",
+				Certification:  "FR:12",
+			},
+			expectedresult1: &[]response.ListMovie{},
+			expectedresult2: &response.Pagination{Total: total(0), Page: 1, PageSize: 10, Limit: 10},
+			expectedresult3: nil,
+			path:            "/Movie?sort=-release_date&min_runtime=90&language=FR&spoken_language=en&country=fr&certification=FR:12",
+		},
+		{
+			name:         "invalid min runtime",
+			expectedcode: http.StatusBadRequest,
+			path:         "/Movie?min_runtime=long",
+		},
+		{
+			name:         "invalid min rating",
+			expectedcode: http.StatusBadRequest,
+			path:         "/Movie?min_rating=abc",
+		},
+		{
+			name:         "invalid created after",
+			expectedcode: http.StatusBadRequest,
+			path:         "/Movie?created_after=yesterday",
+		},
 	}
 
 	for _, tc := range testcases {
@@ -140,13 +252,71 @@ func TestListMovie(t *testing.T) {
 			w := httptest.NewRecorder()
 			r := httptest.NewRequest("GET", tc.path, nil)
 			r.Header.Set("Content-Type", "application/json")
-			mockAppUsecase.Mock.On("ListMovie").Return(tc.expectedresult1, tc.expectedresult2)
+			mockAppUsecase.Mock.On("ListMovie", tc.expectedrequest).Return(tc.expectedresult1, tc.expectedresult2, tc.expectedresult3)
 			appHandler.ListMovie(w, r)
 			assert.Equal(t, tc.expectedcode, w.Code)
+			if tc.expectednext != "" {
+				assert.Equal(t, tc.expectednext, tc.expectedresult2.Next)
+				assert.Equal(t, "/Movie?page=1&page_size=1&sort=rating%2C-created_at&title_contains=Dans", tc.expectedresult2.Prev)
+			}
 		})
 	}
 }
 
+func TestPaginationLinks(t *testing.T) {
+	testcases := []struct {
+		name         string
+		path         string
+		pagination   response.Pagination
+		expectedNext string
+		expectedPrev string
+	}{
+		{
+			name:         "first page",
+			path:         "/Movie",
+			pagination:   response.Pagination{Total: total(25), Page: 1, PageSize: 10, Limit: 10},
+			expectedNext: "/Movie?page=2&page_size=10",
+		},
+		{
+			name:         "last page",
+			path:         "/Movie?page=3",
+			pagination:   response.Pagination{Total: total(25), Page: 3, PageSize: 10, Limit: 10, Offset: 20},
+			expectedPrev: "/Movie?page=2&page_size=10",
+		},
+		{
+			name:         "limit offset",
+			path:         "/Movie?limit=5&offset=3&min_rating=4",
+			pagination:   response.Pagination{Total: total(25), Limit: 5, Offset: 3},
+			expectedNext: "/Movie?limit=5&min_rating=4&offset=8",
+			expectedPrev: "/Movie?limit=5&min_rating=4&offset=0",
+		},
+		{
+			name:         "cursor",
+			path:         "/Movie?page=2&sort=-rating&cursor=abc",
+			pagination:   response.Pagination{Limit: 10, NextCursor: "next.sig"},
+			expectedNext: "/Movie?cursor=next.sig&limit=10&sort=-rating",
+		},
+		{
+			name:       "cursor last page",
+			path:       "/Movie?cursor=abc",
+			pagination: response.Pagination{Limit: 10},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", tc.path, nil)
+			next, prev := paginationLinks(r, &tc.pagination)
+			assert.Equal(t, tc.expectedNext, next)
+			assert.Equal(t, tc.expectedPrev, prev)
+		})
+	}
+}
+
+func total(value int64) *int64 {
+	return &value
+}
+
 func TestGetMovie(t *testing.T) {
 	testcases := []struct {
 		name            string
@@ -167,11 +337,34 @@ func TestGetMovie(t *testing.T) {
 				Image:       "ta.jpg",
 				CreatedAt:   "2024-01-13 00:00:00",
 				UpdatedAt:   "2024-01-13 00:00:00",
+				Version:     4,
 			},
 			expectedresult2: nil,
 			path:            "/Movie/{id}",
 			id:              "1",
 		},
+		{
+			name:            "not found",
+			expectedcode:    http.StatusNotFound,
+			expectedresult1: &response.GetMovie{},
+			expectedresult2: apperror.NotFound("Movie Not Found"),
+			path:            "/Movie/{id}",
+			id:              "404",
+		},
+		{
+			name:            "database unavailable",
+			expectedcode:    http.StatusServiceUnavailable,
+			expectedresult1: &response.GetMovie{},
+			expectedresult2: apperror.Unavailable("Database Unavailable", errors.New("connection refused")),
+			path:            "/Movie/{id}",
+			id:              "503",
+		},
+		{
+			name:         "invalid id",
+			expectedcode: http.StatusBadRequest,
+			path:         "/Movie/{id}",
+			id:           "abc",
+		},
 	}
 
 	for _, tc := range testcases {
@@ -184,8 +377,12 @@ func TestGetMovie(t *testing.T) {
 			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 			idInt, _ := strconv.Atoi(tc.id)
 			mockAppUsecase.Mock.On("GetMovie", int64(idInt)).Return(tc.expectedresult1, tc.expectedresult2)
+			mockAppUsecase.Mock.On("GetMergedMovieID", int64(idInt)).Return(int64(0), apperror.NotFound("Movie Not Found"))
 			appHandler.GetMovie(w, r)
 			assert.Equal(t, tc.expectedcode, w.Code)
+			if tc.expectedcode == http.StatusAccepted {
+				assert.Equal(t, `"4"`, w.Header().Get("ETag"))
+			}
 		})
 	}
 }
@@ -204,66 +401,94 @@ func TestUpdateMovie(t *testing.T) {
 			expectedcode:   http.StatusOK,
 			expectedresult: nil,
 			input: request.UpdateMovie{
-				Title:       "Dans 1",
-				Description: "Dans 1",
-				Rating:      4,
-				Image:       "fafa.jpg",
+				Title:       ptr("Dans 1"),
+				Description: ptr("Dans 1"),
+				Rating:      ptr(float32(4)),
+				Image:       ptr("fafa.jpg"),
 			},
 			path: "/Movie/{id}",
 			id:   "1",
 		},
 		{
 			name:           "empty title",
-			expectedcode:   http.StatusNotAcceptable,
-			expectedresult: errors.New("Movie Title Cannot Be Empty"),
+			expectedcode:   http.StatusUnprocessableEntity,
+			expectedresult: apperror.Validation("Movie Title Cannot Be Empty"),
 			input: request.UpdateMovie{
-				Title:       "",
-				Description: "Dans 1",
-				Rating:      4,
-				Image:       "fafa.jpg",
+				Title:       ptr(""),
+				Description: ptr("Dans 1"),
+				Rating:      ptr(float32(4)),
+				Image:       ptr("fafa.jpg"),
 			},
 			path: "/Movie/{id}",
 			id:   "1",
 		},
 		{
 			name:           "empty description",
-			expectedcode:   http.StatusNotAcceptable,
-			expectedresult: errors.New("Movie Description Cannot Be Empty"),
+			expectedcode:   http.StatusUnprocessableEntity,
+			expectedresult: apperror.Validation("Movie Description Cannot Be Empty"),
 			input: request.UpdateMovie{
-				Title:       "Dans 1",
-				Description: "",
-				Rating:      4,
-				Image:       "fafa.jpg",
+				Title:       ptr("Dans 1"),
+				Description: ptr(""),
+				Rating:      ptr(float32(4)),
+				Image:       ptr("fafa.jpg"),
 			},
 			path: "/Movie/{id}",
 			id:   "1",
 		},
 		{
 			name:           "rating not valid",
-			expectedcode:   http.StatusNotAcceptable,
-			expectedresult: errors.New("Rating between 0 to 10"),
+			expectedcode:   http.StatusUnprocessableEntity,
+			expectedresult: apperror.Validation("Rating between 0 to 10"),
 			input: request.UpdateMovie{
-				Title:       "Dans 1",
-				Description: "Dans 1",
-				Rating:      11,
-				Image:       "fafa.jpg",
+				Title:       ptr("Dans 1"),
+				Description: ptr("Dans 1"),
+				Rating:      ptr(float32(11)),
+				Image:       ptr("fafa.jpg"),
 			},
 			path: "/Movie/{id}",
 			id:   "1",
 		},
 		{
 			name:           "image empty",
-			expectedcode:   http.StatusNotAcceptable,
-			expectedresult: errors.New("Image Cannot Be Empty"),
+			expectedcode:   http.StatusUnprocessableEntity,
+			expectedresult: apperror.Validation("Image Cannot Be Empty"),
 			input: request.UpdateMovie{
-				Title:       "Dans 1",
-				Description: "Dans 1",
-				Rating:      4,
-				Image:       "",
+				Title:       ptr("Dans 1"),
+				Description: ptr("Dans 1"),
+				Rating:      ptr(float32(4)),
+				Image:       ptr(""),
 			},
 			path: "/Movie/{id}",
 			id:   "1",
 		},
+		{
+			name:         "validation errors",
+			expectedcode: http.StatusUnprocessableEntity,
+			expectedresult: utils.ValidationErrors{
+				{Field: "image", Code: "imageurl", Message: "Image Must Be A Valid Image URL", Value: "fafa.exe"},
+			},
+			input: request.UpdateMovie{
+				Title:       ptr("Dans 1"),
+				Description: ptr("Dans 1"),
+				Rating:      ptr(float32(4)),
+				Image:       ptr("fafa.exe"),
+			},
+			path: "/Movie/{id}",
+			id:   "2",
+		},
+		{
+			name:           "not found",
+			expectedcode:   http.StatusNotFound,
+			expectedresult: apperror.NotFound("Movie Not Found"),
+			input: request.UpdateMovie{
+				Title:       ptr("Dans 1"),
+				Description: ptr("Dans 1"),
+				Rating:      ptr(float32(4)),
+				Image:       ptr("fafa.jpg"),
+			},
+			path: "/Movie/{id}",
+			id:   "404",
+		},
 	}
 
 	for _, tc := range testcases {
@@ -298,6 +523,13 @@ func TestDeleteMovie(t *testing.T) {
 			path:           "/Movie/{id}",
 			id:             "1",
 		},
+		{
+			name:           "not found or already deleted",
+			expectedcode:   http.StatusNotFound,
+			expectedresult: apperror.NotFound("Movie Not Found"),
+			path:           "/Movie/{id}",
+			id:             "404",
+		},
 	}
 
 	for _, tc := range testcases {
@@ -310,10 +542,901 @@ func TestDeleteMovie(t *testing.T) {
 			rctx.URLParams.Add("id", tc.id)
 			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 			idInt, _ := strconv.Atoi(tc.id)
-			mockAppUsecase.Mock.On("DeleteMovie", int64(idInt)).Return(tc.expectedresult)
+			mockAppUsecase.Mock.On("DeleteMovie", int64(idInt), request.DeleteMovie{}).Return(tc.expectedresult)
 			appHandler.DeleteMovie(w, r)
 
 			assert.Equal(t, tc.expectedcode, w.Code)
 		})
 	}
 }
+
+func TestDeleteMovie_Hard(t *testing.T) {
+	testcases := []struct {
+		name           string
+		expectedcode   int
+		expectedresult error
+		path           string
+		id             string
+		ifMatch        string
+		input          request.DeleteMovie
+	}{
+		{
+			name:           "valid",
+			expectedcode:   http.StatusOK,
+			expectedresult: nil,
+			path:           "/Movie/{id}?hard=true",
+			id:             "21",
+		},
+		{
+			name:           "not found",
+			expectedcode:   http.StatusNotFound,
+			expectedresult: apperror.NotFound("Movie Not Found In Trash"),
+			path:           "/Movie/{id}?hard=true",
+			id:             "22",
+		},
+		{
+			name:           "invalid hard flag",
+			expectedcode:   http.StatusBadRequest,
+			expectedresult: nil,
+			path:           "/Movie/{id}?hard=maybe",
+			id:             "23",
+		},
+		{
+			name:           "stale version",
+			expectedcode:   http.StatusPreconditionFailed,
+			expectedresult: apperror.PreconditionFailed("Movie Has Been Modified"),
+			path:           "/Movie/{id}?hard=true",
+			id:             "24",
+			ifMatch:        `"3"`,
+			input:          request.DeleteMovie{Version: ptr(int64(3))},
+		},
+		{
+			name:         "weak etag",
+			expectedcode: http.StatusPreconditionFailed,
+			path:         "/Movie/{id}?hard=true",
+			id:           "25",
+			ifMatch:      `W/"3"`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("DELETE", tc.path, nil)
+			if tc.ifMatch != "" {
+				r.Header.Set("If-Match", tc.ifMatch)
+			}
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.id)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			idInt, _ := strconv.Atoi(tc.id)
+			mockAppUsecase.Mock.On("PurgeMovie", int64(idInt), tc.input).Return(tc.expectedresult)
+			appHandler.DeleteMovie(w, r)
+
+			assert.Equal(t, tc.expectedcode, w.Code)
+		})
+	}
+	mockAppUsecase.Mock.AssertNotCalled(t, "PurgeMovie", int64(23), request.DeleteMovie{})
+	mockAppUsecase.Mock.AssertNotCalled(t, "PurgeMovie", int64(25), request.DeleteMovie{})
+	mockAppUsecase.Mock.AssertNotCalled(t, "DeleteMovie", int64(21), request.DeleteMovie{})
+}
+
+func TestListTrash(t *testing.T) {
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/Movie/trash?page=1&page_size=1", nil)
+	trash := []response.TrashMovie{{ID: 3, Title: "Deleted", DeletedAt: "2024-01-05 00:00:00"}}
+	mockAppUsecase.Mock.On("ListTrash", request.ListMovie{Page: 1, PageSize: 1}).
+		Return(&trash, &response.Pagination{Total: total(2), Page: 1, PageSize: 1, Limit: 1}, nil)
+	appHandler.ListTrash(w, r)
+
+	var body struct {
+		Data []response.TrashMovie `json:"data"`
+		Meta response.Pagination   `json:"meta"`
+	}
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "2024-01-05 00:00:00", body.Data[0].DeletedAt)
+	assert.Equal(t, "/Movie/trash?page=2&page_size=1", body.Meta.Next)
+}
+
+func TestRestoreMovie(t *testing.T) {
+	testcases := []struct {
+		name           string
+		expectedcode   int
+		expectedresult error
+		id             string
+	}{
+		{
+			name:           "valid",
+			expectedcode:   http.StatusOK,
+			expectedresult: nil,
+			id:             "31",
+		},
+		{
+			name:           "not in trash",
+			expectedcode:   http.StatusNotFound,
+			expectedresult: apperror.NotFound("Movie Not Found In Trash"),
+			id:             "32",
+		},
+		{
+			name:         "invalid id",
+			expectedcode: http.StatusBadRequest,
+			id:           "abc",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("POST", "/Movie/{id}/restore", nil)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.id)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			idInt, _ := strconv.Atoi(tc.id)
+			mockAppUsecase.Mock.On("RestoreMovie", int64(idInt)).Return(tc.expectedresult)
+			appHandler.RestoreMovie(w, r)
+
+			assert.Equal(t, tc.expectedcode, w.Code)
+		})
+	}
+}
+
+func TestSearchMovie(t *testing.T) {
+	testcases := []struct {
+		name            string
+		expectedcode    int
+		expectedrequest request.SearchMovie
+		expectedresult1 *[]response.SearchMovie
+		expectedresult2 *response.Pagination
+		expectedresult3 error
+		path            string
+	}{
+		{
+			name:            "valid",
+			expectedcode:    http.StatusAccepted,
+			expectedrequest: request.SearchMovie{Query: "kubur", Page: 1},
+			expectedresult1: &[]response.SearchMovie{
+				{
+					ID:             1,
+					Title:          "Beranak Dalam Kubur",
+					Description:    "Horror",
+					Rating:         7,
+					Image:          "ta.jpg",
+					CreatedAt:      "2024-01-13 00:00:00",
+					UpdatedAt:      "2024-01-13 00:00:00",
+					Score:          0.5,
+					TitleHighlight: "Beranak Dalam <mark>Kubur</mark>",
+					Snippet:        "Horror",
+				},
+			},
+			expectedresult2: &response.Pagination{Total: total(1), Page: 1, PageSize: 10, Limit: 10},
+			path:            "/Movie/search?q=kubur&page=1",
+		},
+		{
+			name:            "empty query",
+			expectedcode:    http.StatusBadRequest,
+			expectedrequest: request.SearchMovie{},
+			expectedresult1: &[]response.SearchMovie{},
+			expectedresult2: &response.Pagination{},
+			expectedresult3: apperror.BadRequest("Search Query Cannot Be Empty"),
+			path:            "/Movie/search",
+		},
+		{
+			name:         "invalid page",
+			expectedcode: http.StatusBadRequest,
+			path:         "/Movie/search?q=kubur&page=x",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", tc.path, nil)
+			mockAppUsecase.Mock.On("SearchMovie", tc.expectedrequest).Return(tc.expectedresult1, tc.expectedresult2, tc.expectedresult3)
+			appHandler.SearchMovie(w, r)
+			assert.Equal(t, tc.expectedcode, w.Code)
+		})
+	}
+}
+
+func TestSuggestMovie(t *testing.T) {
+	testcases := []struct {
+		name            string
+		expectedcode    int
+		prefix          string
+		limit           int
+		expectedresult1 *[]response.SuggestMovie
+		expectedresult2 error
+		path            string
+	}{
+		{
+			name:            "valid",
+			expectedcode:    http.StatusAccepted,
+			prefix:          "dans",
+			limit:           3,
+			expectedresult1: &[]response.SuggestMovie{{ID: 1, Title: "Dans 1"}},
+			path:            "/Movie/suggest?prefix=dans&limit=3",
+		},
+		{
+			name:            "empty prefix",
+			expectedcode:    http.StatusBadRequest,
+			expectedresult1: &[]response.SuggestMovie{},
+			expectedresult2: apperror.BadRequest("Prefix Cannot Be Empty"),
+			path:            "/Movie/suggest",
+		},
+		{
+			name:         "invalid limit",
+			expectedcode: http.StatusBadRequest,
+			path:         "/Movie/suggest?prefix=dans&limit=-1",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", tc.path, nil)
+			mockAppUsecase.Mock.On("SuggestMovie", tc.prefix, tc.limit).Return(tc.expectedresult1, tc.expectedresult2)
+			appHandler.SuggestMovie(w, r)
+			assert.Equal(t, tc.expectedcode, w.Code)
+		})
+	}
+}
+
+func TestMovieRevision(t *testing.T) {
+	testcases := []struct {
+		name         string
+		handler      http.HandlerFunc
+		method       string
+		id           string
+		rev          string
+		expectedcode int
+	}{
+		{name: "list", handler: appHandler.ListMovieRevision, method: "GET", id: "61", expectedcode: http.StatusAccepted},
+		{name: "get", handler: appHandler.GetMovieRevision, method: "GET", id: "61", rev: "1", expectedcode: http.StatusAccepted},
+		{name: "get missing revision", handler: appHandler.GetMovieRevision, method: "GET", id: "61", rev: "9", expectedcode: http.StatusNotFound},
+		{name: "get invalid revision", handler: appHandler.GetMovieRevision, method: "GET", id: "61", rev: "0", expectedcode: http.StatusBadRequest},
+		{name: "revert", handler: appHandler.RevertMovie, method: "POST", id: "61", rev: "1", expectedcode: http.StatusOK},
+	}
+
+	revision := response.MovieRevision{Revision: 1, Action: "create", Actor: "editor"}
+	mockAppUsecase.Mock.On("ListMovieRevision", int64(61)).Return(&[]response.MovieRevision{revision}, nil)
+	mockAppUsecase.Mock.On("GetMovieRevision", int64(61), 1).Return(&revision, nil)
+	mockAppUsecase.Mock.On("GetMovieRevision", int64(61), 9).Return((*response.MovieRevision)(nil), apperror.NotFound("Movie Revision Not Found"))
+	mockAppUsecase.Mock.On("RevertMovie", int64(61), 1, "editor").Return(nil)
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest(tc.method, "/Movie/{id}/revisions/{rev}", nil)
+			r.Header.Set("X-Actor", "editor")
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.id)
+			rctx.URLParams.Add("rev", tc.rev)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			tc.handler(w, r)
+
+			assert.Equal(t, tc.expectedcode, w.Code)
+		})
+	}
+}
+
+func TestIfMatch(t *testing.T) {
+	version := int64(2)
+	testcases := []struct {
+		name         string
+		method       string
+		id           string
+		ifMatch      string
+		expectedcode int
+	}{
+		{name: "update with stale etag", method: "PATCH", id: "71", ifMatch: `"2"`, expectedcode: http.StatusPreconditionFailed},
+		{name: "delete with stale etag", method: "DELETE", id: "72", ifMatch: `"2"`, expectedcode: http.StatusPreconditionFailed},
+		{name: "malformed etag", method: "DELETE", id: "73", ifMatch: "2", expectedcode: http.StatusBadRequest},
+	}
+
+	updateMovie := request.UpdateMovie{Title: ptr("Dans 1"), Description: ptr("Dans 1"), Rating: ptr(float32(4)), Image: ptr("fafa.jpg"), Version: &version}
+	stale := apperror.PreconditionFailed("Movie Has Been Modified")
+	mockAppUsecase.Mock.On("UpdateMovie", int64(71), updateMovie).Return(stale)
+	mockAppUsecase.Mock.On("DeleteMovie", int64(72), request.DeleteMovie{Version: &version}).Return(stale)
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			body, _ := json.Marshal(updateMovie)
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest(tc.method, "/Movie/{id}", bytes.NewReader(body))
+			r.Header.Set("Content-Type", "application/json")
+			r.Header.Set("If-Match", tc.ifMatch)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.id)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			if tc.method == "PATCH" {
+				appHandler.UpdateMovie(w, r)
+			} else {
+				appHandler.DeleteMovie(w, r)
+			}
+
+			assert.Equal(t, tc.expectedcode, w.Code)
+		})
+	}
+	mockAppUsecase.Mock.AssertNotCalled(t, "DeleteMovie", int64(73), mock.Anything)
+}
+
+func TestGetMovie_NotModified(t *testing.T) {
+	modified := time.Date(2024, 1, 13, 0, 0, 0, 0, time.UTC)
+	mockAppUsecase.Mock.On("GetMovie", int64(91)).Return(&response.GetMovie{ID: 91, Title: "Dans 91", Version: 3, Modified: modified}, nil)
+	handler := &AppHandler{AppUsecase: mockAppUsecase, CacheControl: "public, max-age=30"}
+
+	testcases := []struct {
+		name         string
+		header       string
+		value        string
+		expectedcode int
+	}{
+		{name: "matching etag", header: "If-None-Match", value: `"3"`, expectedcode: http.StatusNotModified},
+		{name: "stale etag", header: "If-None-Match", value: `"2"`, expectedcode: http.StatusAccepted},
+		{name: "not modified since", header: "If-Modified-Since", value: modified.Format(http.TimeFormat), expectedcode: http.StatusNotModified},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "/Movie/91", nil)
+			r.Header.Set(tc.header, tc.value)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "91")
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			handler.GetMovie(w, r)
+
+			assert.Equal(t, tc.expectedcode, w.Code)
+			assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+			assert.Equal(t, "Sat, 13 Jan 2024 00:00:00 GMT", w.Header().Get("Last-Modified"))
+			assert.Equal(t, "public, max-age=30", w.Header().Get("Cache-Control"))
+		})
+	}
+}
+
+func TestUpdateMovie_PatchFormats(t *testing.T) {
+	operations := []request.PatchOperation{{Op: "replace", Path: "/title", Value: json.RawMessage(`"Dans 2"`)}}
+	mockAppUsecase.Mock.On("UpdateMovie", int64(111), request.UpdateMovie{Title: ptr("Dans 2"), Description: ptr(""), Rating: ptr(float32(0)), Actor: "editor"}).Return(nil)
+	mockAppUsecase.Mock.On("PatchMovie", int64(112), request.PatchMovie{Operations: operations, Actor: "editor"}).Return(nil)
+	mockAppUsecase.Mock.On("ReplaceMovie", int64(113), request.ReplaceMovie{Title: "Dans 3", Description: "Dans 3", Rating: 5, Image: "a.jpg", Actor: "editor"}).Return(nil)
+
+	testcases := []struct {
+		name         string
+		method       string
+		id           string
+		contentType  string
+		body         string
+		expectedcode int
+	}{
+		{name: "merge patch with nulls", method: "PATCH", id: "111", contentType: "application/merge-patch+json", body: `{"title":"Dans 2","description":null,"rating":null}`, expectedcode: http.StatusOK},
+		{name: "json patch", method: "PATCH", id: "112", contentType: "application/json-patch+json; charset=utf-8", body: `[{"op":"replace","path":"/title","value":"Dans 2"}]`, expectedcode: http.StatusOK},
+		{name: "merge patch must be object", method: "PATCH", id: "111", contentType: "application/merge-patch+json", body: `[1]`, expectedcode: http.StatusBadRequest},
+		{name: "unsupported content type", method: "PATCH", id: "111", contentType: "text/plain", body: `title=x`, expectedcode: http.StatusUnsupportedMediaType},
+		{name: "put replaces", method: "PUT", id: "113", contentType: "application/json", body: `{"title":"Dans 3","description":"Dans 3","rating":5,"image":"a.jpg"}`, expectedcode: http.StatusOK},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(tc.method, "/Movie/{id}", strings.NewReader(tc.body))
+			r.Header.Set("Content-Type", tc.contentType)
+			r.Header.Set("X-Actor", "editor")
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.id)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			if tc.method == "PUT" {
+				appHandler.ReplaceMovie(w, r)
+			} else {
+				appHandler.UpdateMovie(w, r)
+			}
+			assert.Equal(t, tc.expectedcode, w.Code)
+			if tc.expectedcode == http.StatusUnsupportedMediaType {
+				assert.Equal(t, "application/merge-patch+json, application/json-patch+json", w.Header().Get("Accept-Patch"))
+			}
+		})
+	}
+}
+
+func TestBulkMovie(t *testing.T) {
+	bulk := &response.BulkMovie{
+		Mode:      "best_effort",
+		Succeeded: 1,
+		Failed:    1,
+		Results: []response.BulkMovieResult{
+			{Operation: "create", Index: 0, ID: 9},
+			{Operation: "delete", Index: 0, ID: 10, Err: apperror.NotFound("Movie Not Found")},
+		},
+	}
+	mockAppUsecase.Mock.On("BulkMovie", request.BulkMovie{
+		Create: []request.CreateMovie{{Title: "Bulk", Description: "Bulk", Rating: 4, Image: "bulk.jpg"}},
+		Delete: []request.BulkDeleteMovie{{ID: 10}},
+		Actor:  "editor",
+	}).Return(bulk, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/Movie/bulk", strings.NewReader(`{"create":[{"title":"Bulk","description":"Bulk","rating":4,"image":"bulk.jpg"}],"delete":[{"id":10}]}`))
+	r.Header.Set("X-Actor", "editor")
+	appHandler.BulkMovie(w, r)
+
+	assert.Equal(t, http.StatusMultiStatus, w.Code)
+	var body struct {
+		Data response.BulkMovie `json:"data"`
+	}
+	assert.Nil(t, json.NewDecoder(w.Body).Decode(&body))
+	assert.Equal(t, http.StatusCreated, body.Data.Results[0].Status)
+	assert.Equal(t, http.StatusNotFound, body.Data.Results[1].Status)
+	assert.Equal(t, "Movie Not Found", body.Data.Results[1].Error)
+}
+
+func TestImportMovie(t *testing.T) {
+	mockAppUsecase.Mock.On("StartImport", mock.MatchedBy(func(req request.ImportMovie) bool {
+		content, _ := io.ReadAll(req.File)
+		req.File.Close()
+		return req.Filename == "movies.csv" && req.Mapping["title"] == "Name" && req.Actor == "importer" &&
+			string(content) == "Name\nImported\n"
+	})).Return(&response.ImportJob{ID: 5, Status: "pending"}, nil)
+
+	body := &bytes.Buffer{}
+	form := multipart.NewWriter(body)
+	form.WriteField("mapping", `{"title":"Name"}`)
+	file, _ := form.CreateFormFile("file", "movies.csv")
+	file.Write([]byte("Name\nImported\n"))
+	form.Close()
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/Movie/import", body)
+	r.Header.Set("Content-Type", form.FormDataContentType())
+	r.Header.Set("X-Actor", "importer")
+	appHandler.ImportMovie(w, r)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Equal(t, "/Movie/import/5", w.Header().Get("Location"))
+
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest("POST", "/Movie/import", strings.NewReader(`{}`))
+	r.Header.Set("Content-Type", "application/json")
+	appHandler.ImportMovie(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetImportJobErrors(t *testing.T) {
+	mockAppUsecase.Mock.On("ListImportJobErrors", int64(6)).Return(&[]response.ImportJobError{{Row: 2, Message: "Title Cannot Be Empty", Raw: ",x,1,a.jpg"}}, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/Movie/import/{id}/errors", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "6")
+	r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+	appHandler.GetImportJobErrors(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "row,error,raw\n2,Title Cannot Be Empty,\",x,1,a.jpg\"\n", w.Body.String())
+}
+
+func TestExportMovie(t *testing.T) {
+	movies := []response.ListMovie{{ID: 1, Title: "Dans, 1", Description: "Dans 1", Rating: 7.5, Image: "a.jpg", CreatedAt: "2024-01-01 00:00:00", UpdatedAt: "2024-01-02 00:00:00", Version: 3}}
+	mockAppUsecase.Mock.On("ExportMovie", request.ListMovie{MinRating: ptr(float32(7))}).Return(&movies, nil)
+	mockAppUsecase.Mock.On("ExportMovie", request.ListMovie{Page: 2}).Return(nil, apperror.BadRequest("Export Does Not Support page, page_size, limit, offset or cursor"))
+
+	w := httptest.NewRecorder()
+	appHandler.ExportMovie(w, httptest.NewRequest("GET", "/Movie/export?min_rating=7", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), `.csv"`)
+	assert.Equal(t, "id,title,description,rating,image,created_at,updated_at,version\n1,\"Dans, 1\",Dans 1,7.5,a.jpg,2024-01-01 00:00:00,2024-01-02 00:00:00,3\n", w.Body.String())
+
+	w = httptest.NewRecorder()
+	appHandler.ExportMovie(w, httptest.NewRequest("GET", "/Movie/export?format=ndjson&min_rating=7", nil))
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	var exported response.ListMovie
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &exported))
+	assert.Equal(t, "Dans, 1", exported.Title)
+
+	w = httptest.NewRecorder()
+	appHandler.ExportMovie(w, httptest.NewRequest("GET", "/Movie/export?format=xlsx&min_rating=7", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "PK", w.Body.String()[:2])
+
+	w = httptest.NewRecorder()
+	appHandler.ExportMovie(w, httptest.NewRequest("GET", "/Movie/export?format=pdf", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	appHandler.ExportMovie(w, httptest.NewRequest("GET", "/Movie/export?page=2", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCreateMovie_Duplicate(t *testing.T) {
+	forced := request.CreateMovie{Title: "Inception", Description: "Dream heist", Rating: 9, Image: "inception.jpg", Force: true}
+	mockAppUsecase.Mock.On("CreateMovie", forced).Return(nil)
+	duplicate := request.CreateMovie{Title: "Inception", Description: "Dream heist", Rating: 9, Image: "inception.jpg"}
+	candidates := []response.DuplicateCandidate{{ID: 4, Title: "Inception", Description: "Dream heist", Score: 1}}
+	mockAppUsecase.Mock.On("CreateMovie", duplicate).Return(apperror.WithDetails(apperror.Conflict("Movie May Be A Duplicate, Use force=true To Create Anyway"), candidates))
+
+	body := `{"title":"Inception","description":"Dream heist","rating":9,"image":"inception.jpg"}`
+	w := httptest.NewRecorder()
+	appHandler.CreateMovie(w, httptest.NewRequest("POST", "/Movie", strings.NewReader(body)))
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), `"details":[{"id":4,"title":"Inception","description":"Dream heist","score":1}]`)
+
+	w = httptest.NewRecorder()
+	appHandler.CreateMovie(w, httptest.NewRequest("POST", "/Movie?force=true", strings.NewReader(body)))
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	w = httptest.NewRecorder()
+	appHandler.CreateMovie(w, httptest.NewRequest("POST", "/Movie?force=maybe", strings.NewReader(body)))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestListDuplicates(t *testing.T) {
+	clusters := []response.DuplicateCluster{{Score: 0.9, Movies: []response.ListMovie{{ID: 1}, {ID: 2}}}}
+	mockAppUsecase.Mock.On("ListDuplicates").Return(&clusters, nil)
+
+	w := httptest.NewRecorder()
+	appHandler.ListDuplicates(w, httptest.NewRequest("GET", "/Movie/duplicates", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"score":0.9`)
+}
+
+func TestGetMovie_Merged(t *testing.T) {
+	mockAppUsecase.Mock.On("GetMovie", int64(61)).Return(&response.GetMovie{}, apperror.NotFound("Movie Not Found"))
+	mockAppUsecase.Mock.On("GetMergedMovieID", int64(61)).Return(int64(62), nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/Movie/61", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "61")
+	r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+	appHandler.GetMovie(w, r)
+	assert.Equal(t, http.StatusMovedPermanently, w.Code)
+	assert.Equal(t, "/Movie/62", w.Header().Get("Location"))
+}
+
+func TestMergeMovie(t *testing.T) {
+	testcases := []struct {
+		name         string
+		id           string
+		body         string
+		ifMatch      string
+		expectedcode int
+		req          *request.MergeMovie
+		err          error
+	}{
+		{
+			name:         "valid",
+			id:           "63",
+			body:         `{"source_id":64,"fields":{"title":"source"}}`,
+			ifMatch:      `"2"`,
+			expectedcode: http.StatusOK,
+			req:          &request.MergeMovie{SourceID: 64, Fields: map[string]string{"title": "source"}, Version: ptr(int64(2))},
+		},
+		{
+			name:         "source not found",
+			id:           "65",
+			body:         `{"source_id":66}`,
+			expectedcode: http.StatusNotFound,
+			req:          &request.MergeMovie{SourceID: 66},
+			err:          apperror.NotFound("Source Movie Not Found"),
+		},
+		{
+			name:         "invalid body",
+			id:           "67",
+			body:         `{"source_id":"x"}`,
+			expectedcode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid id",
+			id:           "abc",
+			expectedcode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "/Movie/{id}/merge", strings.NewReader(tc.body))
+			if tc.ifMatch != "" {
+				r.Header.Set("If-Match", tc.ifMatch)
+			}
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.id)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			if tc.req != nil {
+				idInt, _ := strconv.Atoi(tc.id)
+				mockAppUsecase.Mock.On("MergeMovie", int64(idInt), *tc.req).Return(&response.GetMovie{ID: int64(idInt), Version: 3}, tc.err)
+			}
+			appHandler.MergeMovie(w, r)
+			assert.Equal(t, tc.expectedcode, w.Code)
+			if tc.expectedcode == http.StatusOK {
+				assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+			}
+		})
+	}
+}
+
+func TestGenreHandlers(t *testing.T) {
+	mockAppUsecase.Mock.On("CreateGenre", request.CreateGenre{Name: "Drama"}).Return(&response.Genre{ID: 1, Name: "Drama"}, nil)
+	mockAppUsecase.Mock.On("ListGenre").Return(&[]response.Genre{{ID: 1, Name: "Drama"}}, nil)
+	mockAppUsecase.Mock.On("GetGenre", int64(404)).Return((*response.Genre)(nil), apperror.NotFound("Genre Not Found"))
+	mockAppUsecase.Mock.On("UpdateGenre", int64(1), request.UpdateGenre{Name: "Thriller"}).Return(nil)
+	mockAppUsecase.Mock.On("DeleteGenre", int64(1)).Return(nil)
+	mockAppUsecase.Mock.On("ListGenreMovie", int64(1), request.ListMovie{Page: 2, GenreIDs: []int64{3}}).
+		Return(&[]response.ListMovie{{ID: 7, Genres: []response.Genre{{ID: 1, Name: "Drama"}}}}, &response.Pagination{}, nil)
+
+	testcases := []struct {
+		name         string
+		method       string
+		id           string
+		body         string
+		query        string
+		handler      func(http.ResponseWriter, *http.Request)
+		expectedcode int
+		expectedbody string
+	}{
+		{name: "create", method: "POST", body: `{"name":"Drama"}`, handler: appHandler.CreateGenre, expectedcode: http.StatusCreated, expectedbody: `"name":"Drama"`},
+		{name: "create invalid body", method: "POST", body: `{"name":`, handler: appHandler.CreateGenre, expectedcode: http.StatusBadRequest},
+		{name: "list", method: "GET", handler: appHandler.ListGenre, expectedcode: http.StatusOK, expectedbody: `[{"id":1,"name":"Drama"}]`},
+		{name: "get not found", method: "GET", id: "404", handler: appHandler.GetGenre, expectedcode: http.StatusNotFound},
+		{name: "get invalid id", method: "GET", id: "abc", handler: appHandler.GetGenre, expectedcode: http.StatusBadRequest},
+		{name: "update", method: "PUT", id: "1", body: `{"name":"Thriller"}`, handler: appHandler.UpdateGenre, expectedcode: http.StatusOK},
+		{name: "delete", method: "DELETE", id: "1", handler: appHandler.DeleteGenre, expectedcode: http.StatusOK},
+		{name: "movies", method: "GET", id: "1", query: "?page=2&genre=3", handler: appHandler.ListGenreMovie, expectedcode: http.StatusOK, expectedbody: `"genres":[{"id":1,"name":"Drama"}]`},
+		{name: "movies invalid genre filter", method: "GET", id: "1", query: "?genre=x", handler: appHandler.ListGenreMovie, expectedcode: http.StatusBadRequest},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(tc.method, "/Genre"+tc.query, strings.NewReader(tc.body))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.id)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			tc.handler(w, r)
+			assert.Equal(t, tc.expectedcode, w.Code)
+			assert.Contains(t, w.Body.String(), tc.expectedbody)
+		})
+	}
+}
+
+func TestPersonHandlers(t *testing.T) {
+	mockAppUsecase.Mock.On("CreatePerson", request.CreatePerson{Name: "Dans"}).Return(&response.Person{ID: 1, Name: "Dans"}, nil)
+	mockAppUsecase.Mock.On("ListPerson", request.ListPerson{Page: 2, Name: "dan"}).Return(&[]response.Person{{ID: 1, Name: "Dans"}}, &response.Pagination{}, nil)
+	mockAppUsecase.Mock.On("GetPerson", int64(404)).Return((*response.Person)(nil), apperror.NotFound("Person Not Found"))
+	mockAppUsecase.Mock.On("UpdatePerson", int64(1), request.UpdatePerson{Name: "Dans"}).Return(nil)
+	mockAppUsecase.Mock.On("DeletePerson", int64(1)).Return(nil)
+	mockAppUsecase.Mock.On("ListPersonFilmography", int64(1)).Return(&[]response.PersonCredit{{ID: 2, MovieID: 5, Title: "Dans 5", Role: "actor"}}, nil)
+	mockAppUsecase.Mock.On("ListMovieCredits", int64(5)).Return(&[]response.MovieCredit{{ID: 2, PersonID: 1, Name: "Dans", Role: "actor"}}, nil)
+	mockAppUsecase.Mock.On("CreateCredit", int64(5), request.CreateCredit{PersonID: 1, Role: "actor"}).Return(&response.MovieCredit{ID: 2, PersonID: 1, Role: "actor"}, nil)
+	mockAppUsecase.Mock.On("DeleteCredit", int64(5), int64(2)).Return(nil)
+
+	testcases := []struct {
+		name         string
+		method       string
+		id           string
+		creditID     string
+		body         string
+		query        string
+		handler      func(http.ResponseWriter, *http.Request)
+		expectedcode int
+		expectedbody string
+	}{
+		{name: "create", method: "POST", body: `{"name":"Dans"}`, handler: appHandler.CreatePerson, expectedcode: http.StatusCreated, expectedbody: `"name":"Dans"`},
+		{name: "list", method: "GET", query: "?page=2&name=dan", handler: appHandler.ListPerson, expectedcode: http.StatusOK, expectedbody: `"name":"Dans"`},
+		{name: "list invalid page", method: "GET", query: "?page=x", handler: appHandler.ListPerson, expectedcode: http.StatusBadRequest},
+		{name: "get not found", method: "GET", id: "404", handler: appHandler.GetPerson, expectedcode: http.StatusNotFound},
+		{name: "update", method: "PUT", id: "1", body: `{"name":"Dans"}`, handler: appHandler.UpdatePerson, expectedcode: http.StatusOK},
+		{name: "delete", method: "DELETE", id: "1", handler: appHandler.DeletePerson, expectedcode: http.StatusOK},
+		{name: "filmography", method: "GET", id: "1", handler: appHandler.ListPersonFilmography, expectedcode: http.StatusOK, expectedbody: `"title":"Dans 5"`},
+		{name: "movie credits", method: "GET", id: "5", handler: appHandler.ListMovieCredits, expectedcode: http.StatusOK, expectedbody: `"role":"actor"`},
+		{name: "create credit", method: "POST", id: "5", body: `{"person_id":1,"role":"actor"}`, handler: appHandler.CreateMovieCredit, expectedcode: http.StatusCreated},
+		{name: "delete credit", method: "DELETE", id: "5", creditID: "2", handler: appHandler.DeleteMovieCredit, expectedcode: http.StatusOK},
+		{name: "delete credit invalid id", method: "DELETE", id: "5", creditID: "x", handler: appHandler.DeleteMovieCredit, expectedcode: http.StatusBadRequest},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(tc.method, "/Person"+tc.query, strings.NewReader(tc.body))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.id)
+			rctx.URLParams.Add("creditId", tc.creditID)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			tc.handler(w, r)
+			assert.Equal(t, tc.expectedcode, w.Code)
+			assert.Contains(t, w.Body.String(), tc.expectedbody)
+		})
+	}
+}
+
+func TestMovieImageHandlers(t *testing.T) {
+	mockAppUsecase.Mock.On("ListMovieImages", int64(81)).Return(&[]response.MovieImage{{ID: 1, Kind: "poster", URL: "poster.jpg", Primary: true}}, nil)
+	mockAppUsecase.Mock.On("CreateMovieImage", int64(81), request.CreateMovieImage{Kind: "still", URL: "still.jpg"}).Return(&response.MovieImage{ID: 2, Kind: "still", URL: "still.jpg"}, nil)
+	mockAppUsecase.Mock.On("ReorderMovieImages", int64(81), request.ReorderMovieImages{ImageIDs: []int64{2, 1}}).Return(nil)
+	mockAppUsecase.Mock.On("CreateMovieImage", int64(81), request.CreateMovieImage{Kind: "poster", URL: "poster.jpg", Actor: "editor", Version: ptr(int64(3))}).Return(&response.MovieImage{ID: 4, Kind: "poster", URL: "poster.jpg"}, nil)
+	mockAppUsecase.Mock.On("DeleteMovieImage", int64(81), int64(1), request.DeleteMovieImage{}).Return(apperror.Conflict("Cannot Remove The Only Poster Of A Movie"))
+	mockAppUsecase.Mock.On("DeleteMovieImage", int64(81), int64(2), request.DeleteMovieImage{}).Return(nil)
+	mockAppUsecase.Mock.On("DeleteMovieImage", int64(81), int64(4), request.DeleteMovieImage{Actor: "editor", Version: ptr(int64(3))}).Return(apperror.PreconditionFailed("Movie Has Been Modified"))
+
+	testcases := []struct {
+		name         string
+		method       string
+		id           string
+		imageID      string
+		body         string
+		ifMatch      string
+		actor        string
+		handler      func(http.ResponseWriter, *http.Request)
+		expectedcode int
+		expectedbody string
+	}{
+		{name: "list", method: "GET", id: "81", handler: appHandler.ListMovieImages, expectedcode: http.StatusOK, expectedbody: `"primary":true`},
+		{name: "list invalid id", method: "GET", id: "x", handler: appHandler.ListMovieImages, expectedcode: http.StatusBadRequest},
+		{name: "create", method: "POST", id: "81", body: `{"kind":"still","url":"still.jpg"}`, handler: appHandler.CreateMovieImage, expectedcode: http.StatusCreated, expectedbody: `"kind":"still"`},
+		{name: "create invalid body", method: "POST", id: "81", body: `{"kind":`, handler: appHandler.CreateMovieImage, expectedcode: http.StatusBadRequest},
+		{name: "create with actor and if-match", method: "POST", id: "81", body: `{"kind":"poster","url":"poster.jpg"}`, ifMatch: `"3"`, actor: "editor", handler: appHandler.CreateMovieImage, expectedcode: http.StatusCreated},
+		{name: "create weak etag", method: "POST", id: "81", body: `{"kind":"poster","url":"poster.jpg"}`, ifMatch: `W/"3"`, handler: appHandler.CreateMovieImage, expectedcode: http.StatusPreconditionFailed},
+		{name: "reorder", method: "PUT", id: "81", body: `{"image_ids":[2,1]}`, handler: appHandler.ReorderMovieImages, expectedcode: http.StatusOK},
+		{name: "delete only poster", method: "DELETE", id: "81", imageID: "1", handler: appHandler.DeleteMovieImage, expectedcode: http.StatusConflict},
+		{name: "delete", method: "DELETE", id: "81", imageID: "2", handler: appHandler.DeleteMovieImage, expectedcode: http.StatusOK},
+		{name: "delete invalid image id", method: "DELETE", id: "81", imageID: "x", handler: appHandler.DeleteMovieImage, expectedcode: http.StatusBadRequest},
+		{name: "delete stale version", method: "DELETE", id: "81", imageID: "4", ifMatch: `"3"`, actor: "editor", handler: appHandler.DeleteMovieImage, expectedcode: http.StatusPreconditionFailed},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(tc.method, "/Movie/"+tc.id+"/images", strings.NewReader(tc.body))
+			if tc.ifMatch != "" {
+				r.Header.Set("If-Match", tc.ifMatch)
+			}
+			if tc.actor != "" {
+				r.Header.Set("X-Actor", tc.actor)
+			}
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.id)
+			rctx.URLParams.Add("imageId", tc.imageID)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			tc.handler(w, r)
+			assert.Equal(t, tc.expectedcode, w.Code)
+			assert.Contains(t, w.Body.String(), tc.expectedbody)
+		})
+	}
+}
+
+func TestUploadMovieImage(t *testing.T) {
+	mockAppUsecase.Mock.On("UploadMovieImage", int64(82), request.UploadMovieImage{Kind: "poster", Primary: true, Filename: "poster.png", ContentType: "image/png", Data: []byte("\x89PNG")}).
+		Return(&response.MovieImage{ID: 3, Kind: "poster", URL: "/images/movies/82/abc/original.png", Primary: true}, nil)
+
+	upload := func(fields map[string]string, file []byte) (*bytes.Buffer, string) {
+		body := &bytes.Buffer{}
+		form := multipart.NewWriter(body)
+		for key, value := range fields {
+			form.WriteField(key, value)
+		}
+		if file != nil {
+			header := textproto.MIMEHeader{}
+			header.Set("Content-Disposition", `form-data; name="file"; filename="poster.png"`)
+			header.Set("Content-Type", "image/png")
+			part, _ := form.CreatePart(header)
+			part.Write(file)
+		}
+		form.Close()
+		return body, form.FormDataContentType()
+	}
+
+	testcases := []struct {
+		name         string
+		id           string
+		fields       map[string]string
+		file         []byte
+		expectedcode int
+	}{
+		{name: "upload", id: "82", fields: map[string]string{"kind": "poster", "primary": "true"}, file: []byte("\x89PNG"), expectedcode: http.StatusCreated},
+		{name: "invalid id", id: "x", fields: map[string]string{"kind": "poster"}, file: []byte("\x89PNG"), expectedcode: http.StatusBadRequest},
+		{name: "missing file", id: "82", fields: map[string]string{"kind": "poster"}, expectedcode: http.StatusBadRequest},
+		{name: "invalid primary", id: "82", fields: map[string]string{"kind": "poster", "primary": "maybe"}, file: []byte("\x89PNG"), expectedcode: http.StatusBadRequest},
+		{name: "file too large", id: "82", fields: map[string]string{"kind": "poster"}, file: make([]byte, imageUploadMaxBytes+1), expectedcode: http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			body, contentType := upload(tc.fields, tc.file)
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "/Movie/"+tc.id+"/images/upload", body)
+			r.Header.Set("Content-Type", contentType)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.id)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			appHandler.UploadMovieImage(w, r)
+			assert.Equal(t, tc.expectedcode, w.Code)
+		})
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/Movie/82/images/upload", strings.NewReader(`{"kind":"poster"}`))
+	r.Header.Set("Content-Type", "application/json")
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "82")
+	r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+	appHandler.UploadMovieImage(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetImage(t *testing.T) {
+	mockAppUsecase.Mock.On("GetImage", "movies/82/abc/w92.png").Return(&response.ImageFile{
+		ContentType: "image/png",
+		Size:        3,
+		ETag:        `"e1"`,
+		Body:        io.NopCloser(strings.NewReader("png")),
+	}, nil)
+	mockAppUsecase.Mock.On("GetImage", "movies/82/abc/missing.png").Return((*response.ImageFile)(nil), apperror.NotFound("Image Not Found"))
+
+	testcases := []struct {
+		name         string
+		key          string
+		ifNoneMatch  string
+		expectedcode int
+		expectedbody string
+	}{
+		{name: "found", key: "movies/82/abc/w92.png", expectedcode: http.StatusOK, expectedbody: "png"},
+		{name: "not modified", key: "movies/82/abc/w92.png", ifNoneMatch: `"e1"`, expectedcode: http.StatusNotModified},
+		{name: "not found", key: "movies/82/abc/missing.png", expectedcode: http.StatusNotFound, expectedbody: "Image Not Found"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "/images/"+tc.key, nil)
+			if tc.ifNoneMatch != "" {
+				r.Header.Set("If-None-Match", tc.ifNoneMatch)
+			}
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("*", tc.key)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			appHandler.GetImage(w, r)
+			assert.Equal(t, tc.expectedcode, w.Code)
+			assert.Contains(t, w.Body.String(), tc.expectedbody)
+			if tc.expectedcode != http.StatusNotFound {
+				assert.Equal(t, "public, max-age=31536000, immutable", w.Header().Get("Cache-Control"))
+				assert.Equal(t, `"e1"`, w.Header().Get("ETag"))
+			}
+		})
+	}
+}
+
+func TestGetMovie_IncludeCredits(t *testing.T) {
+	mockAppUsecase.Mock.On("GetMovie", int64(71)).Return(&response.GetMovie{ID: 71, Title: "Dans 71", Version: 1}, nil)
+	mockAppUsecase.Mock.On("ListMovieCredits", int64(71)).Return(&[]response.MovieCredit{{ID: 3, PersonID: 1, Name: "Dans", Role: "director"}}, nil)
+
+	testcases := []struct {
+		name         string
+		query        string
+		expectedcode int
+		expectedbody string
+	}{
+		{name: "with credits", query: "?include=credits", expectedcode: http.StatusAccepted, expectedbody: `"credits":[{"id":3`},
+		{name: "unknown include", query: "?include=reviews", expectedcode: http.StatusBadRequest},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "/Movie/71"+tc.query, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "71")
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			appHandler.GetMovie(w, r)
+			assert.Equal(t, tc.expectedcode, w.Code)
+			assert.Contains(t, w.Body.String(), tc.expectedbody)
+		})
+	}
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
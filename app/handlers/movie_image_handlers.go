@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/request"
+	"xsis-code-test/utils"
+)
+
+func (ah *AppHandler) ListMovieImages(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		utils.WriteError(w, r, apperror.BadRequest("Id is not a numeric"))
+		return
+	}
+
+	data, err := ah.AppUsecase.ListMovieImages(int64(idInt))
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	jsonResponse := utils.JSONResponse{
+		Error:   false,
+		Message: "Success Listing Movie Images",
+		Data:    data,
+	}
+	utils.WriteJson(w, http.StatusOK, jsonResponse)
+	return
+}
+
+func (ah *AppHandler) CreateMovieImage(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		utils.WriteError(w, r, apperror.BadRequest("Id is not a numeric"))
+		return
+	}
+
+	var requestCreateImage request.CreateMovieImage
+	if err := utils.ReadJson(w, r, &requestCreateImage); err != nil {
+		utils.WriteError(w, r, requestBodyError(err))
+		return
+	}
+	requestCreateImage.Actor = r.Header.Get("X-Actor")
+	if requestCreateImage.Version, err = utils.IfMatchVersion(r); err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	data, err := ah.AppUsecase.CreateMovieImage(int64(idInt), requestCreateImage)
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	jsonResponse := utils.JSONResponse{
+		Error:   false,
+		Message: "Success Created Movie Image",
+		Data:    data,
+	}
+	utils.WriteJson(w, http.StatusCreated, jsonResponse)
+	return
+}
+
+func (ah *AppHandler) ReorderMovieImages(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		utils.WriteError(w, r, apperror.BadRequest("Id is not a numeric"))
+		return
+	}
+
+	var requestReorder request.ReorderMovieImages
+	if err := utils.ReadJson(w, r, &requestReorder); err != nil {
+		utils.WriteError(w, r, requestBodyError(err))
+		return
+	}
+
+	if err := ah.AppUsecase.ReorderMovieImages(int64(idInt), requestReorder); err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	jsonResponse := utils.JSONResponse{
+		Error:   false,
+		Message: "Movie Images Successfully Reordered",
+	}
+	utils.WriteJson(w, http.StatusOK, jsonResponse)
+	return
+}
+
+func (ah *AppHandler) DeleteMovieImage(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		utils.WriteError(w, r, apperror.BadRequest("Id is not a numeric"))
+		return
+	}
+	imageID, err := strconv.Atoi(chi.URLParam(r, "imageId"))
+	if err != nil {
+		utils.WriteError(w, r, apperror.BadRequest("Image Id is not a numeric"))
+		return
+	}
+
+	version, err := utils.IfMatchVersion(r)
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+	requestDeleteImage := request.DeleteMovieImage{Actor: r.Header.Get("X-Actor"), Version: version}
+
+	if err := ah.AppUsecase.DeleteMovieImage(int64(idInt), int64(imageID), requestDeleteImage); err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	jsonResponse := utils.JSONResponse{
+		Error:   false,
+		Message: "Movie Image Successfully Deleted",
+	}
+	utils.WriteJson(w, http.StatusOK, jsonResponse)
+	return
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/request"
+	"xsis-code-test/utils"
+)
+
+const (
+	imageUploadMaxBytes      = 10 << 20
+	imageUploadMaxFieldBytes = 1 << 10
+	imageUploadMaxBodyBytes  = imageUploadMaxBytes + 64<<10
+	imageCacheControl        = "public, max-age=31536000, immutable"
+)
+
+func (ah *AppHandler) UploadMovieImage(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		utils.WriteError(w, r, apperror.BadRequest("Id is not a numeric"))
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, imageUploadMaxBodyBytes)
+	reader, err := r.MultipartReader()
+	if err != nil {
+		utils.WriteError(w, r, apperror.BadRequest("Upload Must Be A multipart/form-data Request"))
+		return
+	}
+
+	var requestUploadImage request.UploadMovieImage
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err == nil {
+			err = readImageUploadPart(part, &requestUploadImage)
+			part.Close()
+		}
+		if err != nil {
+			utils.WriteError(w, r, imageUploadError(err))
+			return
+		}
+	}
+	if requestUploadImage.Data == nil {
+		utils.WriteError(w, r, apperror.BadRequest("file Is Required"))
+		return
+	}
+	requestUploadImage.Actor = r.Header.Get("X-Actor")
+	if requestUploadImage.Version, err = utils.IfMatchVersion(r); err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	data, err := ah.AppUsecase.UploadMovieImage(int64(idInt), requestUploadImage)
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	jsonResponse := utils.JSONResponse{
+		Error:   false,
+		Message: "Success Uploaded Movie Image",
+		Data:    data,
+	}
+	utils.WriteJson(w, http.StatusCreated, jsonResponse)
+	return
+}
+
+func readImageUploadPart(part *multipart.Part, req *request.UploadMovieImage) error {
+	if part.FormName() == "file" {
+		if req.Data != nil {
+			return apperror.BadRequest("Only One file Can Be Uploaded")
+		}
+		data, err := io.ReadAll(io.LimitReader(part, imageUploadMaxBytes+1))
+		if err != nil {
+			return err
+		}
+		if len(data) > imageUploadMaxBytes {
+			return apperror.PayloadTooLarge(fmt.Sprintf("file Cannot Be Larger Than %d MB", imageUploadMaxBytes>>20))
+		}
+		req.Data = data
+		req.Filename = part.FileName()
+		req.ContentType = part.Header.Get("Content-Type")
+		return nil
+	}
+
+	value, err := io.ReadAll(io.LimitReader(part, imageUploadMaxFieldBytes))
+	if err != nil {
+		return err
+	}
+	switch part.FormName() {
+	case "kind":
+		req.Kind = string(value)
+	case "language":
+		req.Language = string(value)
+	case "primary":
+		primary, err := strconv.ParseBool(strings.TrimSpace(string(value)))
+		if err != nil {
+			return apperror.BadRequest("primary Must Be true Or false")
+		}
+		req.Primary = primary
+	}
+	return nil
+}
+
+func imageUploadError(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return apperror.PayloadTooLarge(fmt.Sprintf("file Cannot Be Larger Than %d MB", imageUploadMaxBytes>>20))
+	}
+	var appErr *apperror.Error
+	if errors.As(err, &appErr) {
+		return err
+	}
+	return apperror.BadRequest(err.Error())
+}
+
+func (ah *AppHandler) GetImage(w http.ResponseWriter, r *http.Request) {
+	data, err := ah.AppUsecase.GetImage(chi.URLParam(r, "*"))
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+	defer data.Body.Close()
+
+	cache := utils.Cache{ETag: data.ETag, LastModified: data.LastModified, CacheControl: imageCacheControl}
+	utils.WriteCachedFile(w, r, data.ContentType, data.Size, data.Body, cache)
+	return
+}
@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"strconv"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/request"
+	"xsis-code-test/utils"
+)
+
+const (
+	importMaxBytes      = 256 << 20
+	importMaxFieldBytes = 64 << 10
+)
+
+type importUpload struct {
+	*os.File
+}
+
+func (iu *importUpload) Close() error {
+	err := iu.File.Close()
+	os.Remove(iu.File.Name())
+	return err
+}
+
+func (ah *AppHandler) ImportMovie(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, importMaxBytes)
+	reader, err := r.MultipartReader()
+	if err != nil {
+		utils.WriteError(w, r, apperror.BadRequest("Import Must Be A multipart/form-data Upload"))
+		return
+	}
+
+	requestImportMovie := request.ImportMovie{Actor: r.Header.Get("X-Actor")}
+	var upload *importUpload
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err == nil {
+			err = readImportPart(part, &requestImportMovie, &upload)
+			part.Close()
+		}
+		if err != nil {
+			if upload != nil {
+				upload.Close()
+			}
+			utils.WriteError(w, r, requestBodyError(err))
+			return
+		}
+	}
+	if upload == nil {
+		utils.WriteError(w, r, apperror.BadRequest("file Is Required"))
+		return
+	}
+	requestImportMovie.File = upload
+
+	data, err := ah.AppUsecase.StartImport(requestImportMovie)
+	if err != nil {
+		upload.Close()
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	headers := http.Header{}
+	headers.Set("Location", fmt.Sprintf("/Movie/import/%d", data.ID))
+	jsonResponse := utils.JSONResponse{
+		Error:   false,
+		Message: "Import Job Accepted",
+		Data:    data,
+	}
+	utils.WriteJson(w, http.StatusAccepted, jsonResponse, headers)
+	return
+}
+
+func readImportPart(part *multipart.Part, req *request.ImportMovie, upload **importUpload) error {
+	switch part.FormName() {
+	case "file":
+		if *upload != nil {
+			return errors.New("Only One file Can Be Imported")
+		}
+		file, err := os.CreateTemp("", "movie-import-*")
+		if err != nil {
+			return err
+		}
+		*upload = &importUpload{File: file}
+		if _, err := io.Copy(file, part); err != nil {
+			return err
+		}
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		req.Filename = part.FileName()
+	case "format":
+		value, err := io.ReadAll(io.LimitReader(part, importMaxFieldBytes))
+		if err != nil {
+			return err
+		}
+		req.Format = string(value)
+	case "mapping":
+		if err := json.NewDecoder(io.LimitReader(part, importMaxFieldBytes)).Decode(&req.Mapping); err != nil {
+			return errors.New("mapping Must Be A JSON Object Of Movie Field To Column")
+		}
+	}
+	return nil
+}
+
+func (ah *AppHandler) GetImportJob(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		utils.WriteError(w, r, apperror.BadRequest("Id is not a numeric"))
+		return
+	}
+
+	data, err := ah.AppUsecase.GetImportJob(int64(idInt))
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	jsonResponse := utils.JSONResponse{
+		Error:   false,
+		Message: "Success Get Import Job",
+		Data:    data,
+	}
+	utils.WriteJson(w, http.StatusOK, jsonResponse)
+	return
+}
+
+func (ah *AppHandler) GetImportJobErrors(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		utils.WriteError(w, r, apperror.BadRequest("Id is not a numeric"))
+		return
+	}
+
+	data, err := ah.AppUsecase.ListImportJobErrors(int64(idInt))
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="import-%d-errors.csv"`, idInt))
+	w.WriteHeader(http.StatusOK)
+	report := csv.NewWriter(w)
+	report.Write([]string{"row", "error", "raw"})
+	for _, jobError := range *data {
+		report.Write([]string{strconv.FormatInt(jobError.Row, 10), jobError.Message, jobError.Raw})
+	}
+	report.Flush()
+	return
+}
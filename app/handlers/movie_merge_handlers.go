@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/request"
+	"xsis-code-test/utils"
+)
+
+func (ah *AppHandler) MergeMovie(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		utils.WriteError(w, r, apperror.BadRequest("Id is not a numeric"))
+		return
+	}
+
+	var requestMergeMovie request.MergeMovie
+	if err := utils.ReadJson(w, r, &requestMergeMovie); err != nil {
+		utils.WriteError(w, r, requestBodyError(err))
+		return
+	}
+	requestMergeMovie.Actor = r.Header.Get("X-Actor")
+	if requestMergeMovie.Version, err = utils.IfMatchVersion(r); err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	data, err := ah.AppUsecase.MergeMovie(int64(idInt), requestMergeMovie)
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	jsonResponse := utils.JSONResponse{
+		Error:   false,
+		Message: "Movies Successfully Merged",
+		Data:    data,
+	}
+	headers := http.Header{}
+	headers.Set("ETag", utils.VersionETag(data.Version))
+	utils.WriteJson(w, http.StatusOK, jsonResponse, headers)
+	return
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/request"
+	"xsis-code-test/utils"
+)
+
+const (
+	mergePatchMediaType = "application/merge-patch+json"
+	jsonPatchMediaType  = "application/json-patch+json"
+)
+
+var mergePatchNulls = map[string]json.RawMessage{
+	"title":                json.RawMessage(`""`),
+	"description":          json.RawMessage(`""`),
+	"rating":               json.RawMessage(`0`),
+	"image":                json.RawMessage(`""`),
+	"release_date":         json.RawMessage(`""`),
+	"runtime_minutes":      json.RawMessage(`0`),
+	"original_language":    json.RawMessage(`""`),
+	"spoken_languages":     json.RawMessage(`[]`),
+	"production_countries": json.RawMessage(`[]`),
+	"certifications":       json.RawMessage(`{}`),
+	"genre_ids":            json.RawMessage(`[]`),
+}
+
+func patchMediaType(r *http.Request) string {
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" {
+		return ""
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return contentType
+	}
+	return mediaType
+}
+
+func readMergePatch(w http.ResponseWriter, r *http.Request) (request.UpdateMovie, error) {
+	var (
+		req    request.UpdateMovie
+		fields map[string]json.RawMessage
+	)
+	if err := utils.ReadJson(w, r, &fields); err != nil {
+		return req, requestBodyError(err)
+	}
+	if fields == nil {
+		return req, apperror.BadRequest("Merge Patch Must Be A JSON Object")
+	}
+
+	for field, value := range fields {
+		if null, ok := mergePatchNulls[field]; ok && string(bytes.TrimSpace(value)) == "null" {
+			fields[field] = null
+		}
+	}
+	body, err := json.Marshal(fields)
+	if err != nil {
+		return req, apperror.BadRequest(err.Error())
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return req, apperror.BadRequest(err.Error())
+	}
+	return req, nil
+}
@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/request"
+	"xsis-code-test/models/response"
+)
+
+func parseListMovie(r *http.Request) (request.ListMovie, error) {
+	var (
+		req request.ListMovie
+		err error
+	)
+	query := r.URL.Query()
+
+	if req.Page, err = queryInt(query, "page"); err != nil {
+		return req, err
+	}
+	if req.PageSize, err = queryInt(query, "page_size"); err != nil {
+		return req, err
+	}
+	if req.Limit, err = queryInt(query, "limit"); err != nil {
+		return req, err
+	}
+	if req.Offset, err = queryInt(query, "offset"); err != nil {
+		return req, err
+	}
+	if req.MinRating, err = queryFloat(query, "min_rating"); err != nil {
+		return req, err
+	}
+	if req.MaxRating, err = queryFloat(query, "max_rating"); err != nil {
+		return req, err
+	}
+	if req.CreatedAfter, err = queryTime(query, "created_after"); err != nil {
+		return req, err
+	}
+	if req.CreatedBefore, err = queryTime(query, "created_before"); err != nil {
+		return req, err
+	}
+	if req.ReleasedAfter, err = queryTime(query, "released_after"); err != nil {
+		return req, err
+	}
+	if req.ReleasedBefore, err = queryTime(query, "released_before"); err != nil {
+		return req, err
+	}
+	if req.MinRuntime, err = queryOptionalInt(query, "min_runtime"); err != nil {
+		return req, err
+	}
+	if req.MaxRuntime, err = queryOptionalInt(query, "max_runtime"); err != nil {
+		return req, err
+	}
+	req.TitleContains = strings.TrimSpace(query.Get("title_contains"))
+	req.Language = strings.ToLower(strings.TrimSpace(query.Get("language")))
+	req.SpokenLanguage = strings.ToLower(strings.TrimSpace(query.Get("spoken_language")))
+	req.Country = strings.ToUpper(strings.TrimSpace(query.Get("country")))
+	req.Certification = strings.TrimSpace(query.Get("certification"))
+	if req.GenreIDs, err = queryIDs(query, "genre"); err != nil {
+		return req, err
+	}
+	req.Cursor = query.Get("cursor")
+
+	if sort := query.Get("sort"); sort != "" {
+		for _, field := range strings.Split(sort, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				req.Sort = append(req.Sort, field)
+			}
+		}
+	}
+
+	return req, nil
+}
+
+func queryInt(query url.Values, key string) (int, error) {
+	value := query.Get(key)
+	if value == "" {
+		return 0, nil
+	}
+	result, err := strconv.Atoi(value)
+	if err != nil || result < 0 {
+		return 0, apperror.BadRequest(fmt.Sprintf("%s is not a valid number", key))
+	}
+	return result, nil
+}
+
+func queryOptionalInt(query url.Values, key string) (*int, error) {
+	if query.Get(key) == "" {
+		return nil, nil
+	}
+	result, err := queryInt(query, key)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func queryIDs(query url.Values, key string) ([]int64, error) {
+	var ids []int64
+	for _, value := range query[key] {
+		for _, field := range strings.Split(value, ",") {
+			if field = strings.TrimSpace(field); field == "" {
+				continue
+			}
+			id, err := strconv.ParseInt(field, 10, 64)
+			if err != nil || id <= 0 {
+				return nil, apperror.BadRequest(fmt.Sprintf("%s is not a valid id list", key))
+			}
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func queryFloat(query url.Values, key string) (*float32, error) {
+	value := query.Get(key)
+	if value == "" {
+		return nil, nil
+	}
+	result, err := strconv.ParseFloat(value, 32)
+	if err != nil {
+		return nil, apperror.BadRequest(fmt.Sprintf("%s is not a valid number", key))
+	}
+	rating := float32(result)
+	return &rating, nil
+}
+
+func queryTime(query url.Values, key string) (*time.Time, error) {
+	value := query.Get(key)
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if result, err := time.Parse(layout, value); err == nil {
+			return &result, nil
+		}
+	}
+	return nil, apperror.BadRequest(fmt.Sprintf("%s must be RFC3339 or YYYY-MM-DD", key))
+}
+
+func paginationLinks(r *http.Request, pagination *response.Pagination) (string, string) {
+	var next, prev string
+
+	if pagination.Total == nil {
+		if pagination.NextCursor != "" {
+			next = pageLink(r, map[string]string{"cursor": pagination.NextCursor, "limit": strconv.Itoa(pagination.Limit)})
+		}
+		return next, prev
+	}
+
+	total := *pagination.Total
+	if pagination.Page > 0 {
+		if int64(pagination.Page*pagination.PageSize) < total {
+			next = pageLink(r, map[string]string{"page": strconv.Itoa(pagination.Page + 1), "page_size": strconv.Itoa(pagination.PageSize)})
+		}
+		if pagination.Page > 1 {
+			prev = pageLink(r, map[string]string{"page": strconv.Itoa(pagination.Page - 1), "page_size": strconv.Itoa(pagination.PageSize)})
+		}
+		return next, prev
+	}
+
+	if int64(pagination.Offset+pagination.Limit) < total {
+		next = pageLink(r, map[string]string{"offset": strconv.Itoa(pagination.Offset + pagination.Limit), "limit": strconv.Itoa(pagination.Limit)})
+	}
+	if pagination.Offset > 0 {
+		prevOffset := pagination.Offset - pagination.Limit
+		if prevOffset < 0 {
+			prevOffset = 0
+		}
+		prev = pageLink(r, map[string]string{"offset": strconv.Itoa(prevOffset), "limit": strconv.Itoa(pagination.Limit)})
+	}
+	return next, prev
+}
+
+func pageLink(r *http.Request, params map[string]string) string {
+	query := r.URL.Query()
+	if _, ok := params["cursor"]; ok {
+		query.Del("page")
+		query.Del("page_size")
+		query.Del("offset")
+	}
+	for key, value := range params {
+		query.Set(key, value)
+	}
+	link := url.URL{Path: r.URL.Path, RawQuery: query.Encode()}
+	return link.String()
+}
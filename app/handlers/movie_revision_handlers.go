@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+	"xsis-code-test/apperror"
+	"xsis-code-test/utils"
+)
+
+func (ah *AppHandler) ListMovieRevision(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		utils.WriteError(w, r, apperror.BadRequest("Id is not a numeric"))
+		return
+	}
+
+	data, err := ah.AppUsecase.ListMovieRevision(int64(idInt))
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	jsonResponse := utils.JSONResponse{
+		Error:   false,
+		Message: "Success Listing Movie Revisions",
+		Data:    data,
+	}
+	utils.WriteJson(w, http.StatusAccepted, jsonResponse)
+	return
+}
+
+func (ah *AppHandler) GetMovieRevision(w http.ResponseWriter, r *http.Request) {
+	idInt, revInt, err := revisionParams(r)
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	data, err := ah.AppUsecase.GetMovieRevision(int64(idInt), revInt)
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	jsonResponse := utils.JSONResponse{
+		Error:   false,
+		Message: "Success Get Movie Revision",
+		Data:    data,
+	}
+	utils.WriteJson(w, http.StatusAccepted, jsonResponse)
+	return
+}
+
+func (ah *AppHandler) RevertMovie(w http.ResponseWriter, r *http.Request) {
+	idInt, revInt, err := revisionParams(r)
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	if err := ah.AppUsecase.RevertMovie(int64(idInt), revInt, r.Header.Get("X-Actor")); err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	jsonResponse := utils.JSONResponse{
+		Error:   false,
+		Message: "Movie Successfully Reverted",
+	}
+	utils.WriteJson(w, http.StatusOK, jsonResponse)
+	return
+}
+
+func revisionParams(r *http.Request) (int, int, error) {
+	idInt, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		return 0, 0, apperror.BadRequest("Id is not a numeric")
+	}
+	revInt, err := strconv.Atoi(chi.URLParam(r, "rev"))
+	if err != nil || revInt <= 0 {
+		return 0, 0, apperror.BadRequest("Revision is not a valid number")
+	}
+	return idInt, revInt, nil
+}
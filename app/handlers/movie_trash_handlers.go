@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+	"xsis-code-test/apperror"
+	"xsis-code-test/utils"
+)
+
+func (ah *AppHandler) ListTrash(w http.ResponseWriter, r *http.Request) {
+	req, err := parseListMovie(r)
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	data, pagination, err := ah.AppUsecase.ListTrash(req)
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+	pagination.Next, pagination.Prev = paginationLinks(r, pagination)
+
+	jsonResponse := utils.JSONResponse{
+		Error:   false,
+		Message: "Success Listing Trashed Movies",
+		Data:    data,
+		Meta:    pagination,
+	}
+	utils.WriteJson(w, http.StatusAccepted, jsonResponse)
+	return
+}
+
+func (ah *AppHandler) RestoreMovie(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		utils.WriteError(w, r, apperror.BadRequest("Id is not a numeric"))
+		return
+	}
+
+	if err := ah.AppUsecase.RestoreMovie(int64(idInt)); err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	jsonResponse := utils.JSONResponse{
+		Error:   false,
+		Message: "Movie Successfully Restored",
+	}
+	utils.WriteJson(w, http.StatusOK, jsonResponse)
+	return
+}
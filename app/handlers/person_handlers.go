@@ -0,0 +1,238 @@
+package handlers
+
+import (
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+	"strings"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/request"
+	"xsis-code-test/utils"
+)
+
+func (ah *AppHandler) CreatePerson(w http.ResponseWriter, r *http.Request) {
+	var requestCreatePerson request.CreatePerson
+	if err := utils.ReadJson(w, r, &requestCreatePerson); err != nil {
+		utils.WriteError(w, r, requestBodyError(err))
+		return
+	}
+
+	data, err := ah.AppUsecase.CreatePerson(requestCreatePerson)
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	jsonResponse := utils.JSONResponse{
+		Error:   false,
+		Message: "Success Created Person",
+		Data:    data,
+	}
+	utils.WriteJson(w, http.StatusCreated, jsonResponse)
+	return
+}
+
+func (ah *AppHandler) ListPerson(w http.ResponseWriter, r *http.Request) {
+	var (
+		req request.ListPerson
+		err error
+	)
+	query := r.URL.Query()
+	if req.Page, err = queryInt(query, "page"); err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+	if req.PageSize, err = queryInt(query, "page_size"); err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+	req.Name = strings.TrimSpace(query.Get("name"))
+
+	data, pagination, err := ah.AppUsecase.ListPerson(req)
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+	pagination.Next, pagination.Prev = paginationLinks(r, pagination)
+
+	jsonResponse := utils.JSONResponse{
+		Error:   false,
+		Message: "Success Listing People",
+		Data:    data,
+		Meta:    pagination,
+	}
+	utils.WriteJson(w, http.StatusOK, jsonResponse)
+	return
+}
+
+func (ah *AppHandler) GetPerson(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		utils.WriteError(w, r, apperror.BadRequest("Id is not a numeric"))
+		return
+	}
+
+	data, err := ah.AppUsecase.GetPerson(int64(idInt))
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	jsonResponse := utils.JSONResponse{
+		Error:   false,
+		Message: "Success Getting Person",
+		Data:    data,
+	}
+	utils.WriteJson(w, http.StatusOK, jsonResponse)
+	return
+}
+
+func (ah *AppHandler) UpdatePerson(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		utils.WriteError(w, r, apperror.BadRequest("Id is not a numeric"))
+		return
+	}
+
+	var requestUpdatePerson request.UpdatePerson
+	if err := utils.ReadJson(w, r, &requestUpdatePerson); err != nil {
+		utils.WriteError(w, r, requestBodyError(err))
+		return
+	}
+
+	if err := ah.AppUsecase.UpdatePerson(int64(idInt), requestUpdatePerson); err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	jsonResponse := utils.JSONResponse{
+		Error:   false,
+		Message: "Person Successfully Updated",
+	}
+	utils.WriteJson(w, http.StatusOK, jsonResponse)
+	return
+}
+
+func (ah *AppHandler) DeletePerson(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		utils.WriteError(w, r, apperror.BadRequest("Id is not a numeric"))
+		return
+	}
+
+	if err := ah.AppUsecase.DeletePerson(int64(idInt)); err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	jsonResponse := utils.JSONResponse{
+		Error:   false,
+		Message: "Person Successfully Deleted",
+	}
+	utils.WriteJson(w, http.StatusOK, jsonResponse)
+	return
+}
+
+func (ah *AppHandler) ListPersonFilmography(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		utils.WriteError(w, r, apperror.BadRequest("Id is not a numeric"))
+		return
+	}
+
+	data, err := ah.AppUsecase.ListPersonFilmography(int64(idInt))
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	jsonResponse := utils.JSONResponse{
+		Error:   false,
+		Message: "Success Listing Filmography",
+		Data:    data,
+	}
+	utils.WriteJson(w, http.StatusOK, jsonResponse)
+	return
+}
+
+func (ah *AppHandler) ListMovieCredits(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		utils.WriteError(w, r, apperror.BadRequest("Id is not a numeric"))
+		return
+	}
+
+	data, err := ah.AppUsecase.ListMovieCredits(int64(idInt))
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	jsonResponse := utils.JSONResponse{
+		Error:   false,
+		Message: "Success Listing Movie Credits",
+		Data:    data,
+	}
+	utils.WriteJson(w, http.StatusOK, jsonResponse)
+	return
+}
+
+func (ah *AppHandler) CreateMovieCredit(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		utils.WriteError(w, r, apperror.BadRequest("Id is not a numeric"))
+		return
+	}
+
+	var requestCreateCredit request.CreateCredit
+	if err := utils.ReadJson(w, r, &requestCreateCredit); err != nil {
+		utils.WriteError(w, r, requestBodyError(err))
+		return
+	}
+
+	data, err := ah.AppUsecase.CreateCredit(int64(idInt), requestCreateCredit)
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	jsonResponse := utils.JSONResponse{
+		Error:   false,
+		Message: "Success Created Credit",
+		Data:    data,
+	}
+	utils.WriteJson(w, http.StatusCreated, jsonResponse)
+	return
+}
+
+func (ah *AppHandler) DeleteMovieCredit(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		utils.WriteError(w, r, apperror.BadRequest("Id is not a numeric"))
+		return
+	}
+	creditID, err := strconv.Atoi(chi.URLParam(r, "creditId"))
+	if err != nil {
+		utils.WriteError(w, r, apperror.BadRequest("Credit Id is not a numeric"))
+		return
+	}
+
+	if err := ah.AppUsecase.DeleteCredit(int64(idInt), int64(creditID)); err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	jsonResponse := utils.JSONResponse{
+		Error:   false,
+		Message: "Credit Successfully Deleted",
+	}
+	utils.WriteJson(w, http.StatusOK, jsonResponse)
+	return
+}
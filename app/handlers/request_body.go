@@ -0,0 +1,16 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"xsis-code-test/apperror"
+)
+
+func requestBodyError(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return apperror.PayloadTooLarge(fmt.Sprintf("request Body Cannot Be Larger Than %d MB", maxBytesErr.Limit>>20))
+	}
+	return apperror.BadRequest(err.Error())
+}
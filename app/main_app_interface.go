@@ -2,31 +2,213 @@ package app
 
 import (
 	"net/http"
+	"time"
 	"xsis-code-test/models/model"
 	"xsis-code-test/models/request"
 	"xsis-code-test/models/response"
 )
 
+type IGenreHandlers interface {
+	CreateGenre(http.ResponseWriter, *http.Request)
+	ListGenre(http.ResponseWriter, *http.Request)
+	GetGenre(http.ResponseWriter, *http.Request)
+	UpdateGenre(http.ResponseWriter, *http.Request)
+	DeleteGenre(http.ResponseWriter, *http.Request)
+	ListGenreMovie(http.ResponseWriter, *http.Request)
+}
+
+type IPersonHandlers interface {
+	CreatePerson(http.ResponseWriter, *http.Request)
+	ListPerson(http.ResponseWriter, *http.Request)
+	GetPerson(http.ResponseWriter, *http.Request)
+	UpdatePerson(http.ResponseWriter, *http.Request)
+	DeletePerson(http.ResponseWriter, *http.Request)
+	ListPersonFilmography(http.ResponseWriter, *http.Request)
+	ListMovieCredits(http.ResponseWriter, *http.Request)
+	CreateMovieCredit(http.ResponseWriter, *http.Request)
+	DeleteMovieCredit(http.ResponseWriter, *http.Request)
+}
+
+type IMovieImageHandlers interface {
+	ListMovieImages(http.ResponseWriter, *http.Request)
+	CreateMovieImage(http.ResponseWriter, *http.Request)
+	ReorderMovieImages(http.ResponseWriter, *http.Request)
+	DeleteMovieImage(http.ResponseWriter, *http.Request)
+	UploadMovieImage(http.ResponseWriter, *http.Request)
+	GetImage(http.ResponseWriter, *http.Request)
+}
+
 type IAppHandlers interface {
+	IGenreHandlers
+	IPersonHandlers
+	IMovieImageHandlers
+
 	CreateMovie(http.ResponseWriter, *http.Request)
 	ListMovie(http.ResponseWriter, *http.Request)
 	GetMovie(http.ResponseWriter, *http.Request)
 	UpdateMovie(http.ResponseWriter, *http.Request)
+	ReplaceMovie(http.ResponseWriter, *http.Request)
 	DeleteMovie(http.ResponseWriter, *http.Request)
+	SearchMovie(http.ResponseWriter, *http.Request)
+	SuggestMovie(http.ResponseWriter, *http.Request)
+	ListTrash(http.ResponseWriter, *http.Request)
+	RestoreMovie(http.ResponseWriter, *http.Request)
+	ListMovieRevision(http.ResponseWriter, *http.Request)
+	GetMovieRevision(http.ResponseWriter, *http.Request)
+	RevertMovie(http.ResponseWriter, *http.Request)
+	BulkMovie(http.ResponseWriter, *http.Request)
+	ImportMovie(http.ResponseWriter, *http.Request)
+	GetImportJob(http.ResponseWriter, *http.Request)
+	GetImportJobErrors(http.ResponseWriter, *http.Request)
+	ExportMovie(http.ResponseWriter, *http.Request)
+	ListDuplicates(http.ResponseWriter, *http.Request)
+	MergeMovie(http.ResponseWriter, *http.Request)
+}
+
+type IGenreUsecase interface {
+	CreateGenre(request.CreateGenre) (*response.Genre, error)
+	ListGenre() (*[]response.Genre, error)
+	GetGenre(int64) (*response.Genre, error)
+	UpdateGenre(int64, request.UpdateGenre) error
+	DeleteGenre(int64) error
+	ListGenreMovie(int64, request.ListMovie) (*[]response.ListMovie, *response.Pagination, error)
+}
+
+type IPersonUsecase interface {
+	CreatePerson(request.CreatePerson) (*response.Person, error)
+	ListPerson(request.ListPerson) (*[]response.Person, *response.Pagination, error)
+	GetPerson(int64) (*response.Person, error)
+	UpdatePerson(int64, request.UpdatePerson) error
+	DeletePerson(int64) error
+	ListPersonFilmography(int64) (*[]response.PersonCredit, error)
+	ListMovieCredits(int64) (*[]response.MovieCredit, error)
+	CreateCredit(int64, request.CreateCredit) (*response.MovieCredit, error)
+	DeleteCredit(int64, int64) error
+}
+
+type IMovieImageUsecase interface {
+	ListMovieImages(int64) (*[]response.MovieImage, error)
+	CreateMovieImage(int64, request.CreateMovieImage) (*response.MovieImage, error)
+	ReorderMovieImages(int64, request.ReorderMovieImages) error
+	DeleteMovieImage(int64, int64, request.DeleteMovieImage) error
+	UploadMovieImage(int64, request.UploadMovieImage) (*response.MovieImage, error)
+	GetImage(string) (*response.ImageFile, error)
 }
 
 type IAppUsecase interface {
+	IGenreUsecase
+	IPersonUsecase
+	IMovieImageUsecase
+
 	CreateMovie(request.CreateMovie) error
-	ListMovie() (*[]response.ListMovie, error)
+	ListMovie(request.ListMovie) (*[]response.ListMovie, *response.Pagination, error)
 	GetMovie(int64) (*response.GetMovie, error)
 	UpdateMovie(int64, request.UpdateMovie) error
-	DeleteMovie(int64) error
+	ReplaceMovie(int64, request.ReplaceMovie) error
+	PatchMovie(int64, request.PatchMovie) error
+	DeleteMovie(int64, request.DeleteMovie) error
+	SearchMovie(request.SearchMovie) (*[]response.SearchMovie, *response.Pagination, error)
+	SuggestMovie(string, int) (*[]response.SuggestMovie, error)
+	ListTrash(request.ListMovie) (*[]response.TrashMovie, *response.Pagination, error)
+	RestoreMovie(int64) error
+	PurgeMovie(int64, request.DeleteMovie) error
+	PurgeExpiredTrash(time.Duration) (int64, error)
+	ListMovieRevision(int64) (*[]response.MovieRevision, error)
+	GetMovieRevision(int64, int) (*response.MovieRevision, error)
+	RevertMovie(int64, int, string) error
+	BulkMovie(request.BulkMovie) (*response.BulkMovie, error)
+	StartImport(request.ImportMovie) (*response.ImportJob, error)
+	GetImportJob(int64) (*response.ImportJob, error)
+	ListImportJobErrors(int64) (*[]response.ImportJobError, error)
+	ExportMovie(request.ListMovie, func(response.ListMovie) error) error
+	ListDuplicates() (*[]response.DuplicateCluster, error)
+	MergeMovie(int64, request.MergeMovie) (*response.GetMovie, error)
+	GetMergedMovieID(int64) (int64, error)
+}
+
+type IAppSearchRepository interface {
+	SearchMovie(request.SearchMovie) (*[]model.MovieSearchResult, int64, error)
+}
+
+type IAppSuggestRepository interface {
+	PutTitle(int64, string)
+	RemoveTitle(int64)
+	SuggestTitle(string, int) []model.MovieSuggestion
+}
+
+type IAppBlobRepository interface {
+	PutBlob(string, []byte, string) error
+	GetBlob(string) (*model.Blob, error)
+	DeleteBlob(string) error
+}
+
+type IAppIdempotencyRepository interface {
+	ReserveIdempotencyKey(model.IdempotencyRecord) (*model.IdempotencyRecord, error)
+	SaveIdempotencyKey(model.IdempotencyRecord) error
+	ReleaseIdempotencyKey(string) error
+}
+
+type IGenreRepository interface {
+	CreateGenre(*model.Genre) error
+	ListGenre() (*[]model.Genre, error)
+	GetGenre(int64) (*model.Genre, error)
+	GetGenres([]int64) (*[]model.Genre, error)
+	UpdateGenre(int64, string) error
+	DeleteGenre(int64) error
+	ListMovieGenres([]int64) (map[int64][]model.Genre, error)
+}
+
+type IPersonRepository interface {
+	CreatePerson(*model.Person) error
+	ListPerson(request.ListPerson) (*[]model.Person, int64, error)
+	GetPerson(int64) (*model.Person, error)
+	UpdatePerson(model.Person) error
+	DeletePerson(int64) error
+	CreateCredit(*model.Credit) error
+	DeleteCredit(int64, int64) error
+	ListMovieCredits(int64) (*[]model.Credit, error)
+	ListPersonCredits(int64) (*[]model.Credit, error)
+}
+
+type IMovieImageRepository interface {
+	ListMovieImages(int64) (*[]model.MovieImage, error)
+	CreateMovieImage(*model.MovieImage, *int64, func(*model.Movie, *model.Movie) (*model.MovieRevision, error)) error
+	ReorderMovieImages(int64, []int64) error
+	DeleteMovieImage(int64, int64, *int64, func(*model.Movie, *model.Movie) (*model.MovieRevision, error)) (*model.MovieImage, error)
+	ListReferencedImageURLs([]string) ([]string, error)
 }
 
 type IAppRepository interface {
-	CreateMovie(model.Movie) error
-	ListMovie() (*[]model.Movie, error)
+	IAppSearchRepository
+	IGenreRepository
+	IPersonRepository
+	IMovieImageRepository
+
+	Transaction(func(IAppRepository) error) error
+	CreateMovie(*model.Movie, *model.MovieRevision) error
+	CreateMovies(*[]model.Movie, []*model.MovieRevision) error
+	ListMovie(request.ListMovie) (*[]model.Movie, int64, error)
+	ListMovieTitle() (*[]model.Movie, error)
+	ListMovieAfter(request.ListMovie, request.MovieCursor) (*[]model.Movie, error)
+	EachMovie(request.ListMovie, func(model.Movie) error) error
 	GetMovie(int64) (*model.Movie, error)
-	UpdateMovie(int64, model.Movie) error
-	DeleteMovie(int64) error
+	GetMovies([]int64) (*[]model.Movie, error)
+	ListDuplicateCandidates(string, int) (*[]model.Movie, error)
+	ListDuplicatePairs(int) (*[]model.MoviePair, error)
+	UpdateMovie(int64, model.Movie, *model.MovieRevision) error
+	DeleteMovie(int64, int64, *model.MovieRevision) error
+	DeleteMovies([]model.Movie, []*model.MovieRevision) error
+	MarkMovieMerged(int64, int64) error
+	GetMergedMovieID(int64) (int64, error)
+	ListTrash(request.ListMovie) (*[]model.Movie, int64, error)
+	RestoreMovie(int64) error
+	PurgeMovie(int64, *int64) (*[]model.MovieImage, error)
+	PurgeTrash(time.Time) (*[]model.MovieImage, []int64, error)
+	ListMovieRevision(int64) (*[]model.MovieRevision, error)
+	GetMovieRevision(int64, int) (*model.MovieRevision, error)
+	CreateImportJob(*model.ImportJob) error
+	UpdateImportJob(model.ImportJob) error
+	GetImportJob(int64) (*model.ImportJob, error)
+	CreateImportJobErrors([]model.ImportJobError) error
+	ListImportJobErrors(int64) (*[]model.ImportJobError, error)
 }
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"mime"
+	"os"
+	"path/filepath"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+)
+
+type LocalBlobRepository struct {
+	Root string
+}
+
+func NewLocalBlobRepository(root string) *LocalBlobRepository {
+	return &LocalBlobRepository{Root: root}
+}
+
+func (lbr *LocalBlobRepository) PutBlob(key string, data []byte, contentType string) error {
+	if !validBlobKey(key) {
+		return errInvalidBlobKey
+	}
+	path := lbr.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return apperror.Internal("Cannot Store Image", err)
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return apperror.Internal("Cannot Store Image", err)
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return apperror.Internal("Cannot Store Image", err)
+	}
+	if err := file.Close(); err != nil {
+		return apperror.Internal("Cannot Store Image", err)
+	}
+	if err := os.Chmod(file.Name(), 0o644); err != nil {
+		return apperror.Internal("Cannot Store Image", err)
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		return apperror.Internal("Cannot Store Image", err)
+	}
+	return nil
+}
+
+func (lbr *LocalBlobRepository) GetBlob(key string) (*model.Blob, error) {
+	if !validBlobKey(key) {
+		return nil, errInvalidBlobKey
+	}
+	file, err := os.Open(lbr.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, errBlobNotFound
+	}
+	if err != nil {
+		return nil, apperror.Internal("Cannot Read Image", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, apperror.Internal("Cannot Read Image", err)
+	}
+	if info.IsDir() {
+		file.Close()
+		return nil, errBlobNotFound
+	}
+
+	contentType := mime.TypeByExtension(filepath.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return &model.Blob{
+		Key:          key,
+		ContentType:  contentType,
+		Size:         info.Size(),
+		ETag:         fmt.Sprintf(`"%x-%x"`, info.ModTime().UnixNano(), info.Size()),
+		LastModified: info.ModTime(),
+		Body:         file,
+	}, nil
+}
+
+func (lbr *LocalBlobRepository) DeleteBlob(key string) error {
+	if !validBlobKey(key) {
+		return errInvalidBlobKey
+	}
+	if err := os.Remove(lbr.path(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return apperror.Internal("Cannot Delete Image", err)
+	}
+	return nil
+}
+
+func (lbr *LocalBlobRepository) path(key string) string {
+	return filepath.Join(lbr.Root, filepath.FromSlash(key))
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"xsis-code-test/apperror"
+)
+
+func TestLocalBlobRepository(t *testing.T) {
+	root := t.TempDir()
+	repo := NewLocalBlobRepository(root)
+
+	assert.Nil(t, repo.PutBlob("movies/1/abc/w92.jpg", []byte("jpeg"), "image/jpeg"))
+	assert.Nil(t, repo.PutBlob("movies/1/abc/w92.jpg", []byte("jpeg2"), "image/jpeg"))
+	_, err := os.Stat(filepath.Join(root, "movies", "1", "abc", "w92.jpg"))
+	assert.Nil(t, err)
+
+	blob, err := repo.GetBlob("movies/1/abc/w92.jpg")
+	assert.Nil(t, err)
+	data, _ := io.ReadAll(blob.Body)
+	blob.Body.Close()
+	assert.Equal(t, "jpeg2", string(data))
+	assert.Equal(t, "image/jpeg", blob.ContentType)
+	assert.Equal(t, int64(5), blob.Size)
+	assert.NotEmpty(t, blob.ETag)
+
+	assert.Nil(t, repo.DeleteBlob("movies/1/abc/w92.jpg"))
+	assert.Nil(t, repo.DeleteBlob("movies/1/abc/w92.jpg"))
+	_, err = repo.GetBlob("movies/1/abc/w92.jpg")
+	assert.True(t, apperror.Is(err, apperror.KindNotFound))
+	_, err = repo.GetBlob("movies/1")
+	assert.True(t, apperror.Is(err, apperror.KindNotFound))
+}
+
+func TestLocalBlobRepository_InvalidKey(t *testing.T) {
+	repo := NewLocalBlobRepository(t.TempDir())
+	for _, key := range []string{"", "../etc/passwd", "/abs/path.jpg", "movies//1.jpg", "movies/./1.jpg", "movies/1 2.jpg", "movies\\1.jpg"} {
+		assert.True(t, apperror.Is(repo.PutBlob(key, []byte("x"), "image/jpeg"), apperror.KindBadRequest), key)
+		_, err := repo.GetBlob(key)
+		assert.True(t, apperror.Is(err, apperror.KindBadRequest), key)
+	}
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"strings"
+	"xsis-code-test/apperror"
+)
+
+const maxBlobKeyLength = 512
+
+var (
+	errBlobNotFound   = apperror.NotFound("Image Not Found")
+	errInvalidBlobKey = apperror.BadRequest("Image Key Is Not Valid")
+)
+
+func validBlobKey(key string) bool {
+	if key == "" || len(key) > maxBlobKeyLength {
+		return false
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return false
+		}
+	}
+	for _, char := range key {
+		switch {
+		case char >= 'a' && char <= 'z', char >= 'A' && char <= 'Z', char >= '0' && char <= '9':
+		case char == '/', char == '-', char == '_', char == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+)
+
+const awsSigningAlgorithm = "AWS4-HMAC-SHA256"
+
+type S3BlobRepository struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	Client          *http.Client
+	Now             func() time.Time
+}
+
+func NewS3BlobRepository(endpoint, region, bucket, accessKeyID, secretAccessKey string) *S3BlobRepository {
+	return &S3BlobRepository{
+		Endpoint:        strings.TrimRight(endpoint, "/"),
+		Region:          region,
+		Bucket:          bucket,
+		AccessKeyID:     accessKeyID,
+		SecretAccessKey: secretAccessKey,
+		Client:          &http.Client{Timeout: 30 * time.Second},
+		Now:             time.Now,
+	}
+}
+
+func (sbr *S3BlobRepository) PutBlob(key string, data []byte, contentType string) error {
+	resp, err := sbr.do(http.MethodPut, key, data, contentType)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return s3StatusError(http.MethodPut, key, resp)
+	}
+	return nil
+}
+
+func (sbr *S3BlobRepository) GetBlob(key string) (*model.Blob, error) {
+	resp, err := sbr.do(http.MethodGet, key, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, errBlobNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, s3StatusError(http.MethodGet, key, resp)
+	}
+
+	blob := model.Blob{
+		Key:         key,
+		ContentType: resp.Header.Get("Content-Type"),
+		Size:        resp.ContentLength,
+		ETag:        resp.Header.Get("ETag"),
+		Body:        resp.Body,
+	}
+	if lastModified, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		blob.LastModified = lastModified
+	}
+	return &blob, nil
+}
+
+func (sbr *S3BlobRepository) DeleteBlob(key string) error {
+	resp, err := sbr.do(http.MethodDelete, key, nil, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return s3StatusError(http.MethodDelete, key, resp)
+	}
+	return nil
+}
+
+func (sbr *S3BlobRepository) do(method, key string, data []byte, contentType string) (*http.Response, error) {
+	if !validBlobKey(key) {
+		return nil, errInvalidBlobKey
+	}
+	req, err := http.NewRequest(method, fmt.Sprintf("%s/%s/%s", sbr.Endpoint, sbr.Bucket, key), bytes.NewReader(data))
+	if err != nil {
+		return nil, apperror.Internal("Image Storage Is Misconfigured", err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	payloadHash := sha256Hex(data)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	signAWSRequest(req, sbr.AccessKeyID, sbr.SecretAccessKey, sbr.Region, "s3", payloadHash, sbr.Now())
+
+	resp, err := sbr.Client.Do(req)
+	if err != nil {
+		return nil, apperror.Unavailable("Image Storage Unavailable", err)
+	}
+	return resp, nil
+}
+
+func s3StatusError(method, key string, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+	return apperror.Unavailable("Image Storage Unavailable", fmt.Errorf("s3 %s %s: %s %s", method, key, resp.Status, body))
+}
+
+func signAWSRequest(req *http.Request, accessKeyID, secretAccessKey, region, service, payloadHash string, at time.Time) {
+	amzDate := at.UTC().Format("20060102T150405Z")
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+	headers := map[string]string{"host": host}
+	for name, values := range req.Header {
+		name = strings.ToLower(name)
+		if strings.HasPrefix(name, "x-amz-") || name == "content-type" {
+			headers[name] = strings.Join(strings.Fields(strings.Join(values, ",")), " ")
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	query := req.URL.Query()
+	queryKeys := make([]string, 0, len(query))
+	for key := range query {
+		queryKeys = append(queryKeys, key)
+	}
+	sort.Strings(queryKeys)
+	queryParts := make([]string, 0, len(queryKeys))
+	for _, key := range queryKeys {
+		values := append([]string(nil), query[key]...)
+		sort.Strings(values)
+		for _, value := range values {
+			queryParts = append(queryParts, awsURIEncode(key, true)+"="+awsURIEncode(value, true))
+		}
+	}
+
+	path := req.URL.Path
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		awsURIEncode(path, false),
+		strings.Join(queryParts, "&"),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := fmt.Sprintf("%s/%s/%s/aws4_request", amzDate[:8], region, service)
+	stringToSign := strings.Join([]string{awsSigningAlgorithm, amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+	signingKey := hmacSHA256([]byte("AWS4"+secretAccessKey), amzDate[:8])
+	for _, part := range []string{region, service, "aws4_request"} {
+		signingKey = hmacSHA256(signingKey, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		awsSigningAlgorithm, accessKeyID, scope, signedHeaders, signature))
+}
+
+func awsURIEncode(value string, encodeSlash bool) string {
+	var encoded strings.Builder
+	for i := 0; i < len(value); i++ {
+		char := value[i]
+		switch {
+		case char >= 'A' && char <= 'Z', char >= 'a' && char <= 'z', char >= '0' && char <= '9',
+			char == '-', char == '_', char == '.', char == '~':
+			encoded.WriteByte(char)
+		case char == '/' && !encodeSlash:
+			encoded.WriteByte(char)
+		default:
+			fmt.Fprintf(&encoded, "%%%02X", char)
+		}
+	}
+	return encoded.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
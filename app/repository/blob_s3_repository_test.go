@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"xsis-code-test/apperror"
+)
+
+type s3StandIn struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	types   map[string]string
+}
+
+func newS3StandIn(t *testing.T, bucket, accessKeyID, secretAccessKey string) *httptest.Server {
+	store := &s3StandIn{objects: make(map[string][]byte), types: make(map[string]string)}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		at, err := time.Parse("20060102T150405Z", r.Header.Get("X-Amz-Date"))
+		if err != nil || r.Header.Get("X-Amz-Content-Sha256") != sha256Hex(body) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		expected := r.Clone(r.Context())
+		expected.Header = r.Header.Clone()
+		expected.Header.Del("Authorization")
+		signAWSRequest(expected, accessKeyID, secretAccessKey, "us-east-1", "s3", sha256Hex(body), at)
+		if r.Header.Get("Authorization") != expected.Header.Get("Authorization") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if !strings.HasPrefix(r.URL.Path, "/"+bucket+"/") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		key := strings.TrimPrefix(r.URL.Path, "/"+bucket+"/")
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		switch r.Method {
+		case http.MethodPut:
+			store.objects[key] = body
+			store.types[key] = r.Header.Get("Content-Type")
+		case http.MethodGet:
+			data, ok := store.objects[key]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				io.WriteString(w, "<Error><Code>NoSuchKey</Code></Error>")
+				return
+			}
+			sum := md5.Sum(data)
+			w.Header().Set("Content-Type", store.types[key])
+			w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:])+`"`)
+			w.Header().Set("Last-Modified", at.Format(http.TimeFormat))
+			w.Write(data)
+		case http.MethodDelete:
+			delete(store.objects, key)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+}
+
+func TestS3BlobRepository(t *testing.T) {
+	server := newS3StandIn(t, "posters", "test-key", "test-secret")
+	defer server.Close()
+
+	repo := NewS3BlobRepository(server.URL+"/", "us-east-1", "posters", "test-key", "test-secret")
+	assert.Nil(t, repo.PutBlob("movies/1/abc/original.png", []byte("png"), "image/png"))
+
+	blob, err := repo.GetBlob("movies/1/abc/original.png")
+	assert.Nil(t, err)
+	data, _ := io.ReadAll(blob.Body)
+	blob.Body.Close()
+	assert.Equal(t, "png", string(data))
+	assert.Equal(t, "image/png", blob.ContentType)
+	assert.Equal(t, int64(3), blob.Size)
+	assert.Equal(t, `"bff139fa05ac583f685a523ab3d110a0"`, blob.ETag)
+
+	assert.Nil(t, repo.DeleteBlob("movies/1/abc/original.png"))
+	_, err = repo.GetBlob("movies/1/abc/original.png")
+	assert.True(t, apperror.Is(err, apperror.KindNotFound))
+
+	_, err = repo.GetBlob("../secret")
+	assert.True(t, apperror.Is(err, apperror.KindBadRequest))
+}
+
+func TestS3BlobRepository_Unauthorized(t *testing.T) {
+	server := newS3StandIn(t, "posters", "test-key", "test-secret")
+	defer server.Close()
+
+	repo := NewS3BlobRepository(server.URL, "us-east-1", "posters", "test-key", "wrong-secret")
+	err := repo.PutBlob("movies/1/abc/original.png", []byte("png"), "image/png")
+	assert.True(t, apperror.Is(err, apperror.KindUnavailable))
+}
+
+func TestSignAWSRequest(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	at := time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+	signAWSRequest(req, "AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "us-east-1", "service", sha256Hex(nil), at)
+
+	assert.Equal(t, "20150830T123600Z", req.Header.Get("X-Amz-Date"))
+	assert.Equal(t, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, "+
+		"SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		req.Header.Get("Authorization"))
+}
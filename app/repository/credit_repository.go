@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+)
+
+var errCreditNotFound = apperror.NotFound("Credit Not Found")
+
+func (ar *AppRepository) CreateCredit(credit *model.Credit) error {
+	if err := ar.DB.Omit("Movie", "Person").Create(credit).Error; err != nil {
+		return wrapDBError(err, "Cannot Perform DB Creation")
+	}
+	return nil
+}
+
+func (ar *AppRepository) DeleteCredit(movieID, creditID int64) error {
+	result := ar.DB.Where("id = ? AND movie_id = ?", creditID, movieID).Delete(&model.Credit{})
+	if result.Error != nil {
+		return wrapDBError(result.Error, "Cannot Perform DB Delete")
+	}
+	if result.RowsAffected == 0 {
+		return errCreditNotFound
+	}
+	return nil
+}
+
+func (ar *AppRepository) ListMovieCredits(movieID int64) (*[]model.Credit, error) {
+	credits := make([]model.Credit, 0)
+
+	err := ar.DB.Joins("Person").
+		Where("credits.movie_id = ?", movieID).
+		Order("credits.billing_order").
+		Order("credits.id").
+		Find(&credits).Error
+	if err != nil {
+		return nil, wrapDBError(err, "Cannot Perform DB Query")
+	}
+
+	return &credits, nil
+}
+
+func (ar *AppRepository) ListPersonCredits(personID int64) (*[]model.Credit, error) {
+	credits := make([]model.Credit, 0)
+
+	err := ar.DB.Joins("Movie").
+		Where("credits.person_id = ? AND \"Movie\".deleted_at is null", personID).
+		Order("\"Movie\".created_at DESC").
+		Order("credits.id").
+		Find(&credits).Error
+	if err != nil {
+		return nil, wrapDBError(err, "Cannot Perform DB Query")
+	}
+
+	return &credits, nil
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"log"
+	"net"
+	"strings"
+	"xsis-code-test/apperror"
+)
+
+func wrapDBError(err error, message string) error {
+	log.Println(err.Error())
+
+	var (
+		pgErr  *pgconn.PgError
+		netErr net.Error
+	)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return apperror.New(apperror.KindNotFound, "Record Not Found", err)
+	case errors.As(err, &pgErr):
+		switch {
+		case pgErr.Code == "23505":
+			return apperror.New(apperror.KindConflict, "Record Already Exists", err)
+		case pgErr.Code == "23503":
+			return apperror.New(apperror.KindConflict, "Record Is Referenced By Another Record", err)
+		case pgErr.Code == "23502", pgErr.Code == "23514", strings.HasPrefix(pgErr.Code, "22"):
+			return apperror.New(apperror.KindValidation, "Record Violates A Database Constraint", err)
+		case strings.HasPrefix(pgErr.Code, "08"), strings.HasPrefix(pgErr.Code, "57P"), pgErr.Code == "53300":
+			return apperror.Unavailable("Database Unavailable", err)
+		}
+	case errors.As(err, &netErr), errors.Is(err, driver.ErrBadConn),
+		errors.Is(err, sql.ErrConnDone), errors.Is(err, context.DeadlineExceeded):
+		return apperror.Unavailable("Database Unavailable", err)
+	}
+	return apperror.Internal(message, err)
+}
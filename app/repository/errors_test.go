@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"testing"
+	"xsis-code-test/apperror"
+)
+
+func TestWrapDBError(t *testing.T) {
+	testcases := []struct {
+		name         string
+		err          error
+		expectedKind apperror.Kind
+	}{
+		{name: "record not found", err: gorm.ErrRecordNotFound, expectedKind: apperror.KindNotFound},
+		{name: "unique violation", err: &pgconn.PgError{Code: "23505"}, expectedKind: apperror.KindConflict},
+		{name: "foreign key violation", err: &pgconn.PgError{Code: "23503"}, expectedKind: apperror.KindConflict},
+		{name: "check violation", err: &pgconn.PgError{Code: "23514"}, expectedKind: apperror.KindValidation},
+		{name: "admin shutdown", err: &pgconn.PgError{Code: "57P01"}, expectedKind: apperror.KindUnavailable},
+		{name: "bad connection", err: fmt.Errorf("query: %w", driver.ErrBadConn), expectedKind: apperror.KindUnavailable},
+		{name: "syntax error", err: &pgconn.PgError{Code: "42601"}, expectedKind: apperror.KindInternal},
+		{name: "unknown", err: errors.New("boom"), expectedKind: apperror.KindInternal},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := wrapDBError(tc.err, "Cannot Perform DB Query")
+			assert.Equal(t, tc.expectedKind, apperror.KindOf(err))
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+}
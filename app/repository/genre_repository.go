@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"time"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+)
+
+var errGenreNotFound = apperror.NotFound("Genre Not Found")
+
+func (ar *AppRepository) CreateGenre(genre *model.Genre) error {
+	if err := ar.DB.Create(genre).Error; err != nil {
+		return wrapDBError(err, "Cannot Perform DB Creation")
+	}
+	return nil
+}
+
+func (ar *AppRepository) ListGenre() (*[]model.Genre, error) {
+	genres := make([]model.Genre, 0)
+
+	if err := ar.DB.Order("name").Find(&genres).Error; err != nil {
+		return nil, wrapDBError(err, "Cannot Perform DB Query")
+	}
+
+	return &genres, nil
+}
+
+func (ar *AppRepository) GetGenre(id int64) (*model.Genre, error) {
+	var genre model.Genre
+
+	if err := ar.DB.Where("id = ?", id).First(&genre).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errGenreNotFound
+		}
+		return nil, wrapDBError(err, "Cannot Perform DB Query")
+	}
+
+	return &genre, nil
+}
+
+func (ar *AppRepository) GetGenres(ids []int64) (*[]model.Genre, error) {
+	genres := make([]model.Genre, 0, len(ids))
+	if len(ids) == 0 {
+		return &genres, nil
+	}
+
+	if err := ar.DB.Where("id IN ?", ids).Order("name").Find(&genres).Error; err != nil {
+		return nil, wrapDBError(err, "Cannot Perform DB Query")
+	}
+
+	return &genres, nil
+}
+
+func (ar *AppRepository) UpdateGenre(id int64, name string) error {
+	err := ar.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Genre{}).Where("id = ?", id).Updates(map[string]any{"name": name, "updated_at": time.Now()})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errGenreNotFound
+		}
+		return touchGenreMovies(tx, id)
+	})
+	if errors.Is(err, errGenreNotFound) {
+		return errGenreNotFound
+	}
+	if err != nil {
+		return wrapDBError(err, "Cannot Perform DB Update")
+	}
+	return nil
+}
+
+func (ar *AppRepository) DeleteGenre(id int64) error {
+	err := ar.DB.Transaction(func(tx *gorm.DB) error {
+		if err := touchGenreMovies(tx, id); err != nil {
+			return err
+		}
+		if err := tx.Where("genre_id = ?", id).Delete(&model.MovieGenre{}).Error; err != nil {
+			return err
+		}
+		result := tx.Where("id = ?", id).Delete(&model.Genre{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errGenreNotFound
+		}
+		return nil
+	})
+	if errors.Is(err, errGenreNotFound) {
+		return errGenreNotFound
+	}
+	if err != nil {
+		return wrapDBError(err, "Cannot Perform DB Delete")
+	}
+	return nil
+}
+
+func touchGenreMovies(tx *gorm.DB, genreID int64) error {
+	linked := tx.Model(&model.MovieGenre{}).Select("movie_id").Where("genre_id = ?", genreID)
+	return tx.Model(&model.Movie{}).
+		Where("id IN (?)", linked).
+		Updates(map[string]any{"version": gorm.Expr("version + 1"), "updated_at": time.Now()}).Error
+}
+
+func (ar *AppRepository) ListMovieGenres(movieIDs []int64) (map[int64][]model.Genre, error) {
+	genres := make(map[int64][]model.Genre, len(movieIDs))
+	if len(movieIDs) == 0 {
+		return genres, nil
+	}
+
+	var rows []struct {
+		MovieID int64
+		model.Genre
+	}
+	err := ar.DB.Model(&model.Genre{}).
+		Select("movie_genres.movie_id, genres.*").
+		Joins("JOIN movie_genres ON movie_genres.genre_id = genres.id").
+		Where("movie_genres.movie_id IN ?", movieIDs).
+		Order("genres.name").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, wrapDBError(err, "Cannot Perform DB Query")
+	}
+
+	for _, row := range rows {
+		genres[row.MovieID] = append(genres[row.MovieID], row.Genre)
+	}
+	return genres, nil
+}
+
+func replaceMovieGenres(tx *gorm.DB, movieID int64, genres []model.Genre) error {
+	if err := tx.Where("movie_id = ?", movieID).Delete(&model.MovieGenre{}).Error; err != nil {
+		return err
+	}
+	return createMovieGenres(tx, []model.Movie{{ID: movieID, Genres: genres}})
+}
+
+func createMovieGenres(tx *gorm.DB, movies []model.Movie) error {
+	links := make([]model.MovieGenre, 0)
+	for _, movie := range movies {
+		for _, genre := range movie.Genres {
+			links = append(links, model.MovieGenre{MovieID: movie.ID, GenreID: genre.ID})
+		}
+	}
+	if len(links) == 0 {
+		return nil
+	}
+	return tx.CreateInBatches(links, bulkBatchSize).Error
+}
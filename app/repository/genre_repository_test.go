@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+	"xsis-code-test/models/request"
+)
+
+func TestCreateGenre(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"genres\" (.+) VALUES (.+)").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+	mock.ExpectCommit()
+	genre := model.Genre{Name: "Drama"}
+	assert.Nil(t, repo.CreateGenre(&genre))
+	assert.Equal(t, int64(5), genre.ID)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestGetGenre_NotFound(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	mock.ExpectQuery("SELECT (.+) FROM \"genres\" WHERE id = .+ LIMIT .+").WithArgs(9, sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+	_, err := repo.GetGenre(9)
+	assert.True(t, apperror.Is(err, apperror.KindNotFound))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+const touchSQL = "UPDATE \"movies\" SET \"updated_at\"=.+,\"version\"=version \\+ 1 WHERE id IN \\(SELECT \"movie_id\" FROM \"movie_genres\" WHERE genre_id = .+\\)"
+
+func TestUpdateGenre(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"genres\" SET \"name\"=.+,\"updated_at\"=.+ WHERE id = .+").WithArgs("Thriller", sqlmock.AnyArg(), 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(touchSQL).WithArgs(sqlmock.AnyArg(), 2).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+	assert.Nil(t, repo.UpdateGenre(2, "Thriller"))
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"genres\" SET .+ WHERE id = .+").WithArgs("Thriller", sqlmock.AnyArg(), 9).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+	assert.True(t, apperror.Is(repo.UpdateGenre(9, "Thriller"), apperror.KindNotFound))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteGenre(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectExec(touchSQL).WithArgs(sqlmock.AnyArg(), 2).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec("DELETE FROM \"movie_genres\" WHERE genre_id = .+").WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec("DELETE FROM \"genres\" WHERE id = .+").WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	assert.Nil(t, repo.DeleteGenre(2))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestListMovieGenres(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	rows := sqlmock.NewRows([]string{"movie_id", "id", "name"}).
+		AddRow(1, 3, "Action").
+		AddRow(1, 4, "Drama").
+		AddRow(2, 4, "Drama")
+	expectedSQL := "SELECT movie_genres.movie_id, genres.\\* FROM \"genres\" JOIN movie_genres ON movie_genres.genre_id = genres.id WHERE movie_genres.movie_id IN .+ ORDER BY genres.name"
+	mock.ExpectQuery(expectedSQL).WithArgs(1, 2).WillReturnRows(rows)
+	genres, err := repo.ListMovieGenres([]int64{1, 2})
+	assert.Nil(t, err)
+	assert.Equal(t, []model.Genre{{ID: 3, Name: "Action"}, {ID: 4, Name: "Drama"}}, genres[1])
+	assert.Equal(t, []model.Genre{{ID: 4, Name: "Drama"}}, genres[2])
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateMovie_Genres(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"movies\" SET (.+) WHERE id = .+ AND deleted_at is null AND version = .+").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE movies SET search_vector = .+ WHERE id = .+").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM \"movie_genres\" WHERE movie_id = .+").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO \"movie_genres\" \\(\"movie_id\",\"genre_id\"\\) VALUES \\(.+\\),\\(.+\\)").WithArgs(1, 3, 1, 4).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+	movie := model.Movie{Title: "Dans 1", Version: 1, Genres: []model.Genre{{ID: 3}, {ID: 4}}}
+	assert.Nil(t, repo.UpdateMovie(1, movie, nil))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestListMovie_GenreFilter(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	filterSQL := "deleted_at is null AND id IN \\(SELECT movie_id FROM movie_genres WHERE genre_id IN \\(.+\\)\\)"
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM \"movies\" WHERE " + filterSQL).WithArgs(3, 4).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery("SELECT \\* FROM \"movies\" WHERE " + filterSQL).WithArgs(3, 4).WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(1, "Dans 1"))
+	movies, total, err := repo.ListMovie(request.ListMovie{GenreIDs: []int64{3, 4}})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Len(t, *movies, 1)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"sync"
+	"time"
+	"xsis-code-test/models/model"
+)
+
+type MemoryIdempotencyRepository struct {
+	mu        sync.Mutex
+	records   map[string]model.IdempotencyRecord
+	lastPurge time.Time
+}
+
+func NewMemoryIdempotencyRepository() *MemoryIdempotencyRepository {
+	return &MemoryIdempotencyRepository{records: make(map[string]model.IdempotencyRecord)}
+}
+
+func (mir *MemoryIdempotencyRepository) ReserveIdempotencyKey(record model.IdempotencyRecord) (*model.IdempotencyRecord, error) {
+	mir.mu.Lock()
+	defer mir.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(mir.lastPurge) > idempotencyPurgeInterval {
+		mir.lastPurge = now
+		for key, existing := range mir.records {
+			if existing.ExpiresAt.Before(now) {
+				delete(mir.records, key)
+			}
+		}
+	}
+
+	if existing, ok := mir.records[record.Key]; ok && !existing.ExpiresAt.Before(now) {
+		return &existing, nil
+	}
+	record.Completed = false
+	mir.records[record.Key] = record
+	return nil, nil
+}
+
+func (mir *MemoryIdempotencyRepository) SaveIdempotencyKey(record model.IdempotencyRecord) error {
+	mir.mu.Lock()
+	defer mir.mu.Unlock()
+
+	existing, ok := mir.records[record.Key]
+	if !ok {
+		return nil
+	}
+	existing.Completed = true
+	existing.Status = record.Status
+	existing.Header = record.Header
+	existing.Body = append([]byte(nil), record.Body...)
+	mir.records[record.Key] = existing
+	return nil
+}
+
+func (mir *MemoryIdempotencyRepository) ReleaseIdempotencyKey(key string) error {
+	mir.mu.Lock()
+	defer mir.mu.Unlock()
+
+	if existing, ok := mir.records[key]; ok && !existing.Completed {
+		delete(mir.records, key)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"xsis-code-test/models/model"
+)
+
+func TestMemoryIdempotencyRepository(t *testing.T) {
+	repo := NewMemoryIdempotencyRepository()
+	record := model.IdempotencyRecord{Key: "k1", Fingerprint: "f1", ExpiresAt: time.Now().Add(time.Hour)}
+
+	existing, err := repo.ReserveIdempotencyKey(record)
+	assert.Nil(t, err)
+	assert.Nil(t, existing)
+
+	existing, _ = repo.ReserveIdempotencyKey(record)
+	assert.False(t, existing.Completed)
+
+	assert.Nil(t, repo.SaveIdempotencyKey(model.IdempotencyRecord{Key: "k1", Status: 201, Header: "{}", Body: []byte("ok")}))
+	assert.Nil(t, repo.ReleaseIdempotencyKey("k1"))
+	existing, _ = repo.ReserveIdempotencyKey(record)
+	assert.True(t, existing.Completed)
+	assert.Equal(t, 201, existing.Status)
+	assert.Equal(t, "ok", string(existing.Body))
+
+	expired := model.IdempotencyRecord{Key: "k2", Fingerprint: "f2", ExpiresAt: time.Now().Add(-time.Second)}
+	repo.ReserveIdempotencyKey(expired)
+	existing, _ = repo.ReserveIdempotencyKey(model.IdempotencyRecord{Key: "k2", Fingerprint: "f3", ExpiresAt: time.Now().Add(time.Hour)})
+	assert.Nil(t, existing)
+}
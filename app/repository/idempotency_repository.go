@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"sync/atomic"
+	"time"
+	"xsis-code-test/models/model"
+)
+
+const idempotencyPurgeInterval = time.Minute
+
+var lastIdempotencyPurge atomic.Int64
+
+func (ar *AppRepository) ReserveIdempotencyKey(record model.IdempotencyRecord) (*model.IdempotencyRecord, error) {
+	now := time.Now()
+	last := lastIdempotencyPurge.Load()
+	if now.Sub(time.Unix(0, last)) > idempotencyPurgeInterval && lastIdempotencyPurge.CompareAndSwap(last, now.UnixNano()) {
+		if err := ar.DB.Where("expires_at < ?", now).Delete(&model.IdempotencyRecord{}).Error; err != nil {
+			return nil, wrapDBError(err, "Cannot Perform DB Delete")
+		}
+	}
+
+	if record.Header == "" {
+		record.Header = "{}"
+	}
+	result := ar.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"fingerprint", "completed", "status", "header", "body", "created_at", "expires_at"}),
+		Where:     clause.Where{Exprs: []clause.Expression{clause.Lt{Column: clause.Column{Table: "idempotency_records", Name: "expires_at"}, Value: now}}},
+	}).Create(&record)
+	if result.Error != nil {
+		return nil, wrapDBError(result.Error, "Cannot Perform DB Creation")
+	}
+	if result.RowsAffected > 0 {
+		return nil, nil
+	}
+
+	var existing model.IdempotencyRecord
+	if err := ar.DB.Where("key = ?", record.Key).First(&existing).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ar.ReserveIdempotencyKey(record)
+		}
+		return nil, wrapDBError(err, "Cannot Perform DB Query")
+	}
+	return &existing, nil
+}
+
+func (ar *AppRepository) SaveIdempotencyKey(record model.IdempotencyRecord) error {
+	record.Completed = true
+	err := ar.DB.Model(&model.IdempotencyRecord{}).Where("key = ?", record.Key).
+		Select("completed", "status", "header", "body").
+		Updates(&record).Error
+	if err != nil {
+		return wrapDBError(err, "Cannot Perform DB Update")
+	}
+	return nil
+}
+
+func (ar *AppRepository) ReleaseIdempotencyKey(key string) error {
+	if err := ar.DB.Where("key = ? AND completed = ?", key, false).Delete(&model.IdempotencyRecord{}).Error; err != nil {
+		return wrapDBError(err, "Cannot Perform DB Delete")
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"xsis-code-test/models/model"
+)
+
+func TestReserveIdempotencyKey(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	lastIdempotencyPurge.Store(time.Now().UnixNano())
+	insertSQL := "INSERT INTO \"idempotency_records\" (.+) VALUES (.+) ON CONFLICT \\(\"key\"\\) DO UPDATE SET (.+) WHERE \"idempotency_records\".\"expires_at\" < .+"
+	mock.ExpectBegin()
+	mock.ExpectExec(insertSQL).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	rows := sqlmock.NewRows([]string{"key", "fingerprint", "completed", "status", "header", "body"}).
+		AddRow("k1", "f1", true, 201, "{}", []byte("ok"))
+	mock.ExpectQuery("SELECT (.+) FROM \"idempotency_records\" WHERE key = .+ LIMIT .+").WithArgs("k1", sqlmock.AnyArg()).WillReturnRows(rows)
+
+	existing, err := repo.ReserveIdempotencyKey(model.IdempotencyRecord{Key: "k1", Fingerprint: "f1", ExpiresAt: time.Now().Add(time.Hour)})
+	assert.Nil(t, err)
+	assert.True(t, existing.Completed)
+	assert.Equal(t, 201, existing.Status)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestReleaseIdempotencyKey(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM \"idempotency_records\" WHERE key = .+ AND completed = .+").WithArgs("k1", false).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	assert.Nil(t, repo.ReleaseIdempotencyKey("k1"))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
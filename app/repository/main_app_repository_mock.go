@@ -2,27 +2,48 @@ package repository
 
 import (
 	"github.com/stretchr/testify/mock"
+	"time"
+	"xsis-code-test/app"
 	"xsis-code-test/models/model"
+	"xsis-code-test/models/request"
 )
 
 type AppRepositoryMock struct {
 	Mock mock.Mock
 }
 
-func (arm *AppRepositoryMock) CreateMovie(movie model.Movie) error {
-	arguments := arm.Mock.Called(movie)
+func (arm *AppRepositoryMock) CreateMovie(movie *model.Movie, revision *model.MovieRevision) error {
+	arguments := arm.Mock.Called(movie, revision)
 	if arguments.Get(0) == nil {
 		return nil
 	}
 	return arguments.Get(0).(error)
 }
 
-func (arm *AppRepositoryMock) ListMovie() (*[]model.Movie, error) {
+func (arm *AppRepositoryMock) ListMovie(req request.ListMovie) (*[]model.Movie, int64, error) {
+	arguments := arm.Mock.Called(req)
+
+	if arguments.Get(2) == nil {
+		movie := arguments.Get(0).(*[]model.Movie)
+		return movie, arguments.Get(1).(int64), nil
+	}
+	return arguments.Get(0).(*[]model.Movie), arguments.Get(1).(int64), arguments.Get(2).(error)
+}
+
+func (arm *AppRepositoryMock) ListMovieTitle() (*[]model.Movie, error) {
 	arguments := arm.Mock.Called()
 
 	if arguments.Get(1) == nil {
-		movie := arguments.Get(0).(*[]model.Movie)
-		return movie, nil
+		return arguments.Get(0).(*[]model.Movie), nil
+	}
+	return arguments.Get(0).(*[]model.Movie), arguments.Get(1).(error)
+}
+
+func (arm *AppRepositoryMock) ListMovieAfter(req request.ListMovie, cursor request.MovieCursor) (*[]model.Movie, error) {
+	arguments := arm.Mock.Called(req, cursor)
+
+	if arguments.Get(1) == nil {
+		return arguments.Get(0).(*[]model.Movie), nil
 	}
 	return arguments.Get(0).(*[]model.Movie), arguments.Get(1).(error)
 }
@@ -37,22 +58,414 @@ func (arm *AppRepositoryMock) GetMovie(id int64) (*model.Movie, error) {
 	return arguments.Get(0).(*model.Movie), arguments.Get(1).(error)
 }
 
-func (arm *AppRepositoryMock) UpdateMovie(id int64, movie model.Movie) error {
-	arguments := arm.Mock.Called(id, movie)
+func (arm *AppRepositoryMock) UpdateMovie(id int64, movie model.Movie, revision *model.MovieRevision) error {
+	arguments := arm.Mock.Called(id, movie, revision)
+
+	if arguments.Get(0) == nil {
+		return nil
+	}
+
+	return arguments.Get(0).(error)
+}
+
+func (arm *AppRepositoryMock) DeleteMovie(id int64, version int64, revision *model.MovieRevision) error {
+	arguments := arm.Mock.Called(id, version, revision)
+
+	if arguments.Get(0) == nil {
+		return nil
+	}
+
+	return arguments.Get(0).(error)
+}
+
+func (arm *AppRepositoryMock) SearchMovie(req request.SearchMovie) (*[]model.MovieSearchResult, int64, error) {
+	arguments := arm.Mock.Called(req)
+
+	if arguments.Get(2) == nil {
+		return arguments.Get(0).(*[]model.MovieSearchResult), arguments.Get(1).(int64), nil
+	}
+	return arguments.Get(0).(*[]model.MovieSearchResult), arguments.Get(1).(int64), arguments.Get(2).(error)
+}
+
+func (arm *AppRepositoryMock) ListTrash(req request.ListMovie) (*[]model.Movie, int64, error) {
+	arguments := arm.Mock.Called(req)
+
+	if arguments.Get(2) == nil {
+		return arguments.Get(0).(*[]model.Movie), arguments.Get(1).(int64), nil
+	}
+	return arguments.Get(0).(*[]model.Movie), arguments.Get(1).(int64), arguments.Get(2).(error)
+}
+
+func (arm *AppRepositoryMock) RestoreMovie(id int64) error {
+	arguments := arm.Mock.Called(id)
+
+	if arguments.Get(0) == nil {
+		return nil
+	}
+
+	return arguments.Get(0).(error)
+}
+
+func (arm *AppRepositoryMock) PurgeMovie(id int64, version *int64) (*[]model.MovieImage, error) {
+	arguments := arm.Mock.Called(id, version)
+
+	if arguments.Get(1) == nil {
+		return arguments.Get(0).(*[]model.MovieImage), nil
+	}
+
+	return arguments.Get(0).(*[]model.MovieImage), arguments.Get(1).(error)
+}
+
+func (arm *AppRepositoryMock) PurgeTrash(deletedBefore time.Time) (*[]model.MovieImage, []int64, error) {
+	arguments := arm.Mock.Called(deletedBefore)
+
+	if arguments.Get(2) == nil {
+		return arguments.Get(0).(*[]model.MovieImage), arguments.Get(1).([]int64), nil
+	}
+	return arguments.Get(0).(*[]model.MovieImage), arguments.Get(1).([]int64), arguments.Get(2).(error)
+}
+
+func (arm *AppRepositoryMock) ListMovieRevision(movieID int64) (*[]model.MovieRevision, error) {
+	arguments := arm.Mock.Called(movieID)
+
+	if arguments.Get(1) == nil {
+		return arguments.Get(0).(*[]model.MovieRevision), nil
+	}
+	return arguments.Get(0).(*[]model.MovieRevision), arguments.Get(1).(error)
+}
+
+func (arm *AppRepositoryMock) GetMovieRevision(movieID int64, revision int) (*model.MovieRevision, error) {
+	arguments := arm.Mock.Called(movieID, revision)
+
+	if arguments.Get(1) == nil {
+		return arguments.Get(0).(*model.MovieRevision), nil
+	}
+	return arguments.Get(0).(*model.MovieRevision), arguments.Get(1).(error)
+}
+
+func (arm *AppRepositoryMock) Transaction(fn func(app.IAppRepository) error) error {
+	arguments := arm.Mock.Called(fn)
+
+	if arguments.Get(0) != nil {
+		return arguments.Get(0).(error)
+	}
+	return fn(arm)
+}
+
+func (arm *AppRepositoryMock) CreateMovies(movies *[]model.Movie, revisions []*model.MovieRevision) error {
+	arguments := arm.Mock.Called(movies, revisions)
+
+	if arguments.Get(0) == nil {
+		return nil
+	}
+
+	return arguments.Get(0).(error)
+}
+
+func (arm *AppRepositoryMock) GetMovies(ids []int64) (*[]model.Movie, error) {
+	arguments := arm.Mock.Called(ids)
+
+	if arguments.Get(1) == nil {
+		return arguments.Get(0).(*[]model.Movie), nil
+	}
+	return arguments.Get(0).(*[]model.Movie), arguments.Get(1).(error)
+}
+
+func (arm *AppRepositoryMock) DeleteMovies(movies []model.Movie, revisions []*model.MovieRevision) error {
+	arguments := arm.Mock.Called(movies, revisions)
+
+	if arguments.Get(0) == nil {
+		return nil
+	}
+
+	return arguments.Get(0).(error)
+}
+
+func (arm *AppRepositoryMock) CreateImportJob(job *model.ImportJob) error {
+	arguments := arm.Mock.Called(job)
+
+	if arguments.Get(0) == nil {
+		return nil
+	}
+
+	return arguments.Get(0).(error)
+}
+
+func (arm *AppRepositoryMock) UpdateImportJob(job model.ImportJob) error {
+	arguments := arm.Mock.Called(job)
+
+	if arguments.Get(0) == nil {
+		return nil
+	}
+
+	return arguments.Get(0).(error)
+}
+
+func (arm *AppRepositoryMock) GetImportJob(id int64) (*model.ImportJob, error) {
+	arguments := arm.Mock.Called(id)
+
+	if arguments.Get(1) == nil {
+		return arguments.Get(0).(*model.ImportJob), nil
+	}
+	return arguments.Get(0).(*model.ImportJob), arguments.Get(1).(error)
+}
+
+func (arm *AppRepositoryMock) CreateImportJobErrors(jobErrors []model.ImportJobError) error {
+	arguments := arm.Mock.Called(jobErrors)
+
+	if arguments.Get(0) == nil {
+		return nil
+	}
+
+	return arguments.Get(0).(error)
+}
+
+func (arm *AppRepositoryMock) ListImportJobErrors(jobID int64) (*[]model.ImportJobError, error) {
+	arguments := arm.Mock.Called(jobID)
+
+	if arguments.Get(1) == nil {
+		return arguments.Get(0).(*[]model.ImportJobError), nil
+	}
+	return arguments.Get(0).(*[]model.ImportJobError), arguments.Get(1).(error)
+}
+
+func (arm *AppRepositoryMock) EachMovie(req request.ListMovie, fn func(model.Movie) error) error {
+	arguments := arm.Mock.Called(req)
+
+	if movies, ok := arguments.Get(0).(*[]model.Movie); ok {
+		for _, movie := range *movies {
+			if err := fn(movie); err != nil {
+				return err
+			}
+		}
+	}
+	if arguments.Get(1) == nil {
+		return nil
+	}
+	return arguments.Get(1).(error)
+}
+
+func (arm *AppRepositoryMock) ListDuplicateCandidates(title string, limit int) (*[]model.Movie, error) {
+	arguments := arm.Mock.Called(title, limit)
+
+	if arguments.Get(1) == nil {
+		return arguments.Get(0).(*[]model.Movie), nil
+	}
+	return arguments.Get(0).(*[]model.Movie), arguments.Get(1).(error)
+}
+
+func (arm *AppRepositoryMock) ListDuplicatePairs(limit int) (*[]model.MoviePair, error) {
+	arguments := arm.Mock.Called(limit)
+
+	if arguments.Get(1) == nil {
+		return arguments.Get(0).(*[]model.MoviePair), nil
+	}
+	return arguments.Get(0).(*[]model.MoviePair), arguments.Get(1).(error)
+}
+
+func (arm *AppRepositoryMock) MarkMovieMerged(sourceID, targetID int64) error {
+	arguments := arm.Mock.Called(sourceID, targetID)
+
+	if arguments.Get(0) == nil {
+		return nil
+	}
+	return arguments.Get(0).(error)
+}
+
+func (arm *AppRepositoryMock) GetMergedMovieID(id int64) (int64, error) {
+	arguments := arm.Mock.Called(id)
+
+	if arguments.Get(1) == nil {
+		return arguments.Get(0).(int64), nil
+	}
+	return arguments.Get(0).(int64), arguments.Get(1).(error)
+}
+
+func (arm *AppRepositoryMock) CreateGenre(genre *model.Genre) error {
+	arguments := arm.Mock.Called(genre)
 
 	if arguments.Get(0) == nil {
 		return nil
 	}
+	return arguments.Get(0).(error)
+}
+
+func (arm *AppRepositoryMock) ListGenre() (*[]model.Genre, error) {
+	arguments := arm.Mock.Called()
+
+	if arguments.Get(1) == nil {
+		return arguments.Get(0).(*[]model.Genre), nil
+	}
+	return arguments.Get(0).(*[]model.Genre), arguments.Get(1).(error)
+}
+
+func (arm *AppRepositoryMock) GetGenre(id int64) (*model.Genre, error) {
+	arguments := arm.Mock.Called(id)
+
+	if arguments.Get(1) == nil {
+		return arguments.Get(0).(*model.Genre), nil
+	}
+	return arguments.Get(0).(*model.Genre), arguments.Get(1).(error)
+}
+
+func (arm *AppRepositoryMock) GetGenres(ids []int64) (*[]model.Genre, error) {
+	arguments := arm.Mock.Called(ids)
+
+	if arguments.Get(1) == nil {
+		return arguments.Get(0).(*[]model.Genre), nil
+	}
+	return arguments.Get(0).(*[]model.Genre), arguments.Get(1).(error)
+}
+
+func (arm *AppRepositoryMock) UpdateGenre(id int64, name string) error {
+	arguments := arm.Mock.Called(id, name)
 
+	if arguments.Get(0) == nil {
+		return nil
+	}
 	return arguments.Get(0).(error)
 }
 
-func (arm *AppRepositoryMock) DeleteMovie(id int64) error {
+func (arm *AppRepositoryMock) DeleteGenre(id int64) error {
 	arguments := arm.Mock.Called(id)
 
 	if arguments.Get(0) == nil {
 		return nil
 	}
+	return arguments.Get(0).(error)
+}
+
+func (arm *AppRepositoryMock) ListMovieGenres(movieIDs []int64) (map[int64][]model.Genre, error) {
+	arguments := arm.Mock.Called(movieIDs)
+
+	if arguments.Get(1) == nil {
+		return arguments.Get(0).(map[int64][]model.Genre), nil
+	}
+	return arguments.Get(0).(map[int64][]model.Genre), arguments.Get(1).(error)
+}
+
+func (arm *AppRepositoryMock) CreatePerson(person *model.Person) error {
+	arguments := arm.Mock.Called(person)
 
+	if arguments.Get(0) == nil {
+		return nil
+	}
 	return arguments.Get(0).(error)
 }
+
+func (arm *AppRepositoryMock) ListPerson(req request.ListPerson) (*[]model.Person, int64, error) {
+	arguments := arm.Mock.Called(req)
+
+	if arguments.Get(2) == nil {
+		return arguments.Get(0).(*[]model.Person), arguments.Get(1).(int64), nil
+	}
+	return arguments.Get(0).(*[]model.Person), arguments.Get(1).(int64), arguments.Get(2).(error)
+}
+
+func (arm *AppRepositoryMock) GetPerson(id int64) (*model.Person, error) {
+	arguments := arm.Mock.Called(id)
+
+	if arguments.Get(1) == nil {
+		return arguments.Get(0).(*model.Person), nil
+	}
+	return arguments.Get(0).(*model.Person), arguments.Get(1).(error)
+}
+
+func (arm *AppRepositoryMock) UpdatePerson(person model.Person) error {
+	arguments := arm.Mock.Called(person)
+
+	if arguments.Get(0) == nil {
+		return nil
+	}
+	return arguments.Get(0).(error)
+}
+
+func (arm *AppRepositoryMock) DeletePerson(id int64) error {
+	arguments := arm.Mock.Called(id)
+
+	if arguments.Get(0) == nil {
+		return nil
+	}
+	return arguments.Get(0).(error)
+}
+
+func (arm *AppRepositoryMock) CreateCredit(credit *model.Credit) error {
+	arguments := arm.Mock.Called(credit)
+
+	if arguments.Get(0) == nil {
+		return nil
+	}
+	return arguments.Get(0).(error)
+}
+
+func (arm *AppRepositoryMock) DeleteCredit(movieID, creditID int64) error {
+	arguments := arm.Mock.Called(movieID, creditID)
+
+	if arguments.Get(0) == nil {
+		return nil
+	}
+	return arguments.Get(0).(error)
+}
+
+func (arm *AppRepositoryMock) ListMovieCredits(movieID int64) (*[]model.Credit, error) {
+	arguments := arm.Mock.Called(movieID)
+
+	if arguments.Get(1) == nil {
+		return arguments.Get(0).(*[]model.Credit), nil
+	}
+	return arguments.Get(0).(*[]model.Credit), arguments.Get(1).(error)
+}
+
+func (arm *AppRepositoryMock) ListPersonCredits(personID int64) (*[]model.Credit, error) {
+	arguments := arm.Mock.Called(personID)
+
+	if arguments.Get(1) == nil {
+		return arguments.Get(0).(*[]model.Credit), nil
+	}
+	return arguments.Get(0).(*[]model.Credit), arguments.Get(1).(error)
+}
+
+func (arm *AppRepositoryMock) ListMovieImages(movieID int64) (*[]model.MovieImage, error) {
+	arguments := arm.Mock.Called(movieID)
+
+	if arguments.Get(1) == nil {
+		return arguments.Get(0).(*[]model.MovieImage), nil
+	}
+	return arguments.Get(0).(*[]model.MovieImage), arguments.Get(1).(error)
+}
+
+func (arm *AppRepositoryMock) CreateMovieImage(image *model.MovieImage, version *int64, revise func(*model.Movie, *model.Movie) (*model.MovieRevision, error)) error {
+	arguments := arm.Mock.Called(image, version)
+
+	if arguments.Get(0) == nil {
+		return nil
+	}
+	return arguments.Get(0).(error)
+}
+
+func (arm *AppRepositoryMock) ListReferencedImageURLs(urls []string) ([]string, error) {
+	arguments := arm.Mock.Called(urls)
+
+	if arguments.Get(1) == nil {
+		return arguments.Get(0).([]string), nil
+	}
+	return arguments.Get(0).([]string), arguments.Get(1).(error)
+}
+
+func (arm *AppRepositoryMock) ReorderMovieImages(movieID int64, imageIDs []int64) error {
+	arguments := arm.Mock.Called(movieID, imageIDs)
+
+	if arguments.Get(0) == nil {
+		return nil
+	}
+	return arguments.Get(0).(error)
+}
+
+func (arm *AppRepositoryMock) DeleteMovieImage(movieID, imageID int64, version *int64, revise func(*model.Movie, *model.Movie) (*model.MovieRevision, error)) (*model.MovieImage, error) {
+	arguments := arm.Mock.Called(movieID, imageID, version)
+
+	if arguments.Get(1) == nil {
+		return arguments.Get(0).(*model.MovieImage), nil
+	}
+	return arguments.Get(0).(*model.MovieImage), arguments.Get(1).(error)
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"time"
+	"xsis-code-test/app"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+)
+
+const bulkBatchSize = 100
+
+var errBulkDeleteConflict = apperror.Conflict("Movies Changed During Bulk Delete")
+
+func (ar *AppRepository) Transaction(fn func(app.IAppRepository) error) error {
+	return ar.DB.Transaction(func(tx *gorm.DB) error {
+		return fn(&AppRepository{DB: tx})
+	})
+}
+
+func (ar *AppRepository) CreateMovies(movies *[]model.Movie, revisions []*model.MovieRevision) error {
+	if len(*movies) == 0 {
+		return nil
+	}
+
+	err := ar.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.CreateInBatches(movies, bulkBatchSize).Error; err != nil {
+			return err
+		}
+		ids := make([]int64, 0, len(*movies))
+		for i, movie := range *movies {
+			ids = append(ids, movie.ID)
+			if i < len(revisions) && revisions[i] != nil {
+				revisions[i].MovieID = movie.ID
+			}
+		}
+		if err := tx.Exec("UPDATE movies SET search_vector = "+searchVectorSQL+" WHERE id IN ?", ids).Error; err != nil {
+			return err
+		}
+		if err := createMovieGenres(tx, *movies); err != nil {
+			return err
+		}
+		if err := createPrimaryPosters(tx, *movies); err != nil {
+			return err
+		}
+		return createRevisions(tx, revisions)
+	})
+	if err != nil {
+		return wrapDBError(err, "Cannot Perform DB Creation")
+	}
+	return nil
+}
+
+func (ar *AppRepository) GetMovies(ids []int64) (*[]model.Movie, error) {
+	movies := make([]model.Movie, 0, len(ids))
+	if len(ids) == 0 {
+		return &movies, nil
+	}
+
+	if err := ar.DB.Where("id IN ? AND deleted_at is null", ids).Find(&movies).Error; err != nil {
+		return nil, wrapDBError(err, "Cannot Perform DB Query")
+	}
+
+	return &movies, nil
+}
+
+func (ar *AppRepository) DeleteMovies(movies []model.Movie, revisions []*model.MovieRevision) error {
+	if len(movies) == 0 {
+		return nil
+	}
+
+	keys := make([][]any, 0, len(movies))
+	for _, movie := range movies {
+		keys = append(keys, []any{movie.ID, movie.Version})
+	}
+	err := ar.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Movie{}).
+			Where("(id, version) IN ? AND deleted_at is null", keys).
+			Updates(map[string]any{"deleted_at": time.Now(), "version": gorm.Expr("version + 1")})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != int64(len(movies)) {
+			return errBulkDeleteConflict
+		}
+		return createRevisions(tx, revisions)
+	})
+	if errors.Is(err, errBulkDeleteConflict) {
+		return err
+	}
+	if err != nil {
+		return wrapDBError(err, "Cannot Perform DB Delete")
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+)
+
+func TestCreateMovies(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"movies\" (.+) VALUES (.+),(.+)").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7).AddRow(8))
+	mock.ExpectExec("UPDATE movies SET search_vector = .+ WHERE id IN .+").WithArgs(7, 8).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectQuery("SELECT movie_id, MAX\\(revision\\) AS revision FROM \"movie_revisions\" WHERE movie_id IN .+ GROUP BY \"movie_id\"").
+		WithArgs(7, 8).WillReturnRows(sqlmock.NewRows([]string{"movie_id", "revision"}))
+	mock.ExpectQuery("INSERT INTO \"movie_revisions\" (.+) VALUES (.+),(.+)").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	mock.ExpectCommit()
+
+	movies := []model.Movie{{Title: "First"}, {Title: "Second"}}
+	revisions := []*model.MovieRevision{{Action: "create", Snapshot: "{}"}, {Action: "create", Snapshot: "{}"}}
+	err := repo.CreateMovies(&movies, revisions)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(7), movies[0].ID)
+	assert.Equal(t, int64(8), revisions[1].MovieID)
+	assert.Equal(t, 1, revisions[1].Revision)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestGetMovies(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	rows := sqlmock.NewRows([]string{"id", "title", "version"}).AddRow(3, "Third", 2)
+	mock.ExpectQuery("SELECT (.+) FROM \"movies\" WHERE id IN .+ AND deleted_at is null").WithArgs(3, 4).WillReturnRows(rows)
+	movies, err := repo.GetMovies([]int64{3, 4})
+	assert.Nil(t, err)
+	assert.Len(t, *movies, 1)
+	assert.Equal(t, int64(2), (*movies)[0].Version)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteMovies_Conflict(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"movies\" SET .+ WHERE \\(id, version\\) IN .+ AND deleted_at is null").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectRollback()
+
+	err := repo.DeleteMovies([]model.Movie{{ID: 3, Version: 1}, {ID: 4, Version: 2}}, nil)
+	assert.True(t, apperror.Is(err, apperror.KindConflict))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
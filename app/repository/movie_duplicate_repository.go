@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"gorm.io/gorm/clause"
+	"xsis-code-test/models/model"
+)
+
+func (ar *AppRepository) ListDuplicateCandidates(title string, limit int) (*[]model.Movie, error) {
+	movies := make([]model.Movie, 0)
+
+	err := ar.DB.Where("deleted_at is null AND title % ?", title).
+		Clauses(clause.OrderBy{Expression: clause.Expr{SQL: "similarity(title, ?) DESC", Vars: []any{title}}}).
+		Limit(limit).
+		Find(&movies).Error
+	if err != nil {
+		return nil, wrapDBError(err, "Cannot Perform DB Query")
+	}
+
+	return &movies, nil
+}
+
+func (ar *AppRepository) ListDuplicatePairs(limit int) (*[]model.MoviePair, error) {
+	pairs := make([]model.MoviePair, 0)
+
+	err := ar.DB.Raw(`SELECT a.id AS left_id, b.id AS right_id FROM movies a
+		JOIN movies b ON a.id < b.id AND a.title % b.title
+		WHERE a.deleted_at is null AND b.deleted_at is null
+		ORDER BY similarity(a.title, b.title) DESC, a.id, b.id LIMIT ?`, limit).
+		Scan(&pairs).Error
+	if err != nil {
+		return nil, wrapDBError(err, "Cannot Perform DB Query")
+	}
+
+	return &pairs, nil
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestListDuplicateCandidates(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	rows := sqlmock.NewRows([]string{"id", "title"}).AddRow(1, "Inception")
+	expectedSQL := "SELECT (.+) FROM \"movies\" WHERE deleted_at is null AND title % .+ ORDER BY similarity\\(title, .+\\) DESC LIMIT .+"
+	mock.ExpectQuery(expectedSQL).WithArgs("Inceptoin", "Inceptoin", sqlmock.AnyArg()).WillReturnRows(rows)
+	movies, err := repo.ListDuplicateCandidates("Inceptoin", 20)
+	assert.Nil(t, err)
+	assert.Equal(t, "Inception", (*movies)[0].Title)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestListDuplicatePairs(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	rows := sqlmock.NewRows([]string{"left_id", "right_id"}).AddRow(1, 2)
+	expectedSQL := "SELECT a.id AS left_id, b.id AS right_id FROM movies a\\s+JOIN movies b ON a.id < b.id AND a.title % b.title"
+	mock.ExpectQuery(expectedSQL).WithArgs(100).WillReturnRows(rows)
+	pairs, err := repo.ListDuplicatePairs(100)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), (*pairs)[0].RightID)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
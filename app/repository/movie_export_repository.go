@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"xsis-code-test/models/model"
+	"xsis-code-test/models/request"
+)
+
+const exportBatchSize = 500
+
+func (ar *AppRepository) EachMovie(req request.ListMovie, fn func(model.Movie) error) error {
+	keys := req.SortKeys()
+	var cursor *request.MovieCursor
+	movies := make([]model.Movie, 0, exportBatchSize)
+
+	for {
+		movies = movies[:0]
+		query := ar.DB.Scopes(filterMovie(req))
+		if cursor != nil {
+			query = query.Scopes(keysetMovie(*cursor))
+		}
+		if err := query.Scopes(sortMovie(keys)).Limit(exportBatchSize).Find(&movies).Error; err != nil {
+			return wrapDBError(err, "Cannot Perform DB Query")
+		}
+
+		for _, movie := range movies {
+			if err := fn(movie); err != nil {
+				return err
+			}
+		}
+		if len(movies) < exportBatchSize {
+			return nil
+		}
+		next := request.NewMovieCursor(keys, movies[len(movies)-1])
+		cursor = &next
+	}
+}
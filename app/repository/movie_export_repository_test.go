@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"xsis-code-test/models/model"
+	"xsis-code-test/models/request"
+)
+
+func TestEachMovie(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	rows := sqlmock.NewRows([]string{"id", "title"}).AddRow(1, "First").AddRow(2, "Second")
+	expectedSQL := "SELECT (.+) FROM \"movies\" WHERE deleted_at is null AND rating >= .+ ORDER BY \"title\" DESC,\"id\" LIMIT .+"
+	mock.ExpectQuery(expectedSQL).WillReturnRows(rows)
+
+	titles := make([]string, 0)
+	minRating := float32(5)
+	err := repo.EachMovie(request.ListMovie{Sort: []string{"-title"}, MinRating: &minRating}, func(movie model.Movie) error {
+		titles = append(titles, movie.Title)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"First", "Second"}, titles)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestEachMovie_PagesByReleaseDate(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	releaseDate := time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
+	firstBatch := sqlmock.NewRows([]string{"id", "title", "release_date"})
+	for i := 1; i <= exportBatchSize; i++ {
+		firstBatch.AddRow(i, "Movie", releaseDate.AddDate(0, 0, i/2))
+	}
+	lastDate := releaseDate.AddDate(0, 0, exportBatchSize/2)
+	orderSQL := "ORDER BY COALESCE\\(release_date, DATE '0001-01-01'\\),\"id\" LIMIT .+"
+	mock.ExpectQuery("SELECT (.+) FROM \"movies\" WHERE deleted_at is null " + orderSQL).
+		WithArgs(sqlmock.AnyArg()).WillReturnRows(firstBatch)
+	keysetSQL := "\\(COALESCE\\(release_date, DATE '0001-01-01'\\) > .+ OR \\(COALESCE\\(release_date, DATE '0001-01-01'\\) = .+ AND \"id\" > .+\\)\\)"
+	nextSQL := "SELECT (.+) FROM \"movies\" WHERE deleted_at is null AND " + keysetSQL + " " + orderSQL
+	mock.ExpectQuery(nextSQL).
+		WithArgs(lastDate, lastDate, int64(exportBatchSize), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "release_date"}).AddRow(exportBatchSize+1, "Last", lastDate.AddDate(0, 0, 1)))
+
+	count := 0
+	err := repo.EachMovie(request.ListMovie{Sort: []string{"release_date"}}, func(movie model.Movie) error {
+		count++
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, exportBatchSize+1, count)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestMovieCursor(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	cursor := request.NewMovieCursor([]string{"-rating", "created_at", "-runtime_minutes", "id"}, model.Movie{ID: 9, Rating: 7.5, RuntimeMinutes: 120, CreatedAt: createdAt})
+	assert.Equal(t, []any{float32(7.5), createdAt, 120, int64(9)}, cursor.Values)
+}
@@ -0,0 +1,260 @@
+package repository
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+)
+
+const posterKind = "poster"
+
+var (
+	errMovieImageNotFound = apperror.NotFound("Movie Image Not Found")
+	errLastPoster         = apperror.Conflict("Cannot Remove The Only Poster Of A Movie")
+	errImageOrderMismatch = apperror.Validation("image_ids Must List Every Image Of The Movie Exactly Once")
+)
+
+func (ar *AppRepository) ListMovieImages(movieID int64) (*[]model.MovieImage, error) {
+	images := make([]model.MovieImage, 0)
+
+	if err := ar.DB.Where("movie_id = ?", movieID).Order("position").Order("id").Find(&images).Error; err != nil {
+		return nil, wrapDBError(err, "Cannot Perform DB Query")
+	}
+
+	return &images, nil
+}
+
+func (ar *AppRepository) CreateMovieImage(image *model.MovieImage, version *int64, revise func(*model.Movie, *model.Movie) (*model.MovieRevision, error)) error {
+	err := ar.DB.Transaction(func(tx *gorm.DB) error {
+		movie, err := lockMovie(tx, image.MovieID, version)
+		if err != nil {
+			return err
+		}
+		var primaries int64
+		if err := tx.Model(&model.MovieImage{}).Where("movie_id = ? AND kind = ? AND is_primary", image.MovieID, image.Kind).Count(&primaries).Error; err != nil {
+			return err
+		}
+		if primaries == 0 {
+			image.Primary = true
+		} else if image.Primary {
+			if err := unsetPrimaryImage(tx, image.MovieID, image.Kind); err != nil {
+				return err
+			}
+		}
+
+		position, err := nextImagePosition(tx, image.MovieID)
+		if err != nil {
+			return err
+		}
+		image.Position = position
+		if err := tx.Create(image).Error; err != nil {
+			return err
+		}
+		if image.Primary && image.Kind == posterKind {
+			return setMovieImage(tx, movie, image.URL, revise)
+		}
+		return nil
+	})
+	if errors.Is(err, errMovieNotFound) || errors.Is(err, errVersionConflict) {
+		return err
+	}
+	if err != nil {
+		return wrapDBError(err, "Cannot Perform DB Creation")
+	}
+	return nil
+}
+
+func (ar *AppRepository) ReorderMovieImages(movieID int64, imageIDs []int64) error {
+	err := ar.DB.Transaction(func(tx *gorm.DB) error {
+		var existing []int64
+		if err := tx.Model(&model.MovieImage{}).Where("movie_id = ?", movieID).Pluck("id", &existing).Error; err != nil {
+			return err
+		}
+		if len(existing) != len(imageIDs) {
+			return errImageOrderMismatch
+		}
+		known := make(map[int64]bool, len(existing))
+		for _, id := range existing {
+			known[id] = true
+		}
+		for _, id := range imageIDs {
+			if !known[id] {
+				return errImageOrderMismatch
+			}
+			delete(known, id)
+		}
+		for position, id := range imageIDs {
+			if err := tx.Model(&model.MovieImage{}).Where("id = ?", id).Update("position", position).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, errImageOrderMismatch) {
+		return errImageOrderMismatch
+	}
+	if err != nil {
+		return wrapDBError(err, "Cannot Perform DB Update")
+	}
+	return nil
+}
+
+func (ar *AppRepository) DeleteMovieImage(movieID, imageID int64, version *int64, revise func(*model.Movie, *model.Movie) (*model.MovieRevision, error)) (*model.MovieImage, error) {
+	var image model.MovieImage
+	err := ar.DB.Transaction(func(tx *gorm.DB) error {
+		movie, err := lockMovie(tx, movieID, version)
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("id = ? AND movie_id = ?", imageID, movieID).First(&image).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errMovieImageNotFound
+			}
+			return err
+		}
+		if err := tx.Delete(&image).Error; err != nil {
+			return err
+		}
+		if !image.Primary {
+			return nil
+		}
+
+		var next model.MovieImage
+		err = tx.Where("movie_id = ? AND kind = ?", movieID, image.Kind).Order("position").Order("id").First(&next).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			if image.Kind == posterKind {
+				return errLastPoster
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&next).Update("is_primary", true).Error; err != nil {
+			return err
+		}
+		if image.Kind == posterKind {
+			return setMovieImage(tx, movie, next.URL, revise)
+		}
+		return nil
+	})
+	if errors.Is(err, errMovieImageNotFound) || errors.Is(err, errLastPoster) ||
+		errors.Is(err, errMovieNotFound) || errors.Is(err, errVersionConflict) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, wrapDBError(err, "Cannot Perform DB Delete")
+	}
+	return &image, nil
+}
+
+func (ar *AppRepository) ListReferencedImageURLs(urls []string) ([]string, error) {
+	referenced := make([]string, 0)
+	if len(urls) == 0 {
+		return referenced, nil
+	}
+
+	err := ar.DB.Raw("SELECT image FROM movies WHERE image IN ? UNION SELECT url FROM movie_images WHERE url IN ?", urls, urls).
+		Scan(&referenced).Error
+	if err != nil {
+		return nil, wrapDBError(err, "Cannot Perform DB Query")
+	}
+	return referenced, nil
+}
+
+func unsetPrimaryImage(tx *gorm.DB, movieID int64, kind string) error {
+	return tx.Model(&model.MovieImage{}).
+		Where("movie_id = ? AND kind = ? AND is_primary", movieID, kind).
+		Update("is_primary", false).Error
+}
+
+func nextImagePosition(tx *gorm.DB, movieID int64) (int, error) {
+	var position int
+	err := tx.Model(&model.MovieImage{}).Select("COALESCE(MAX(position) + 1, 0)").Where("movie_id = ?", movieID).Scan(&position).Error
+	return position, err
+}
+
+func lockMovie(tx *gorm.DB, movieID int64, version *int64) (*model.Movie, error) {
+	var movie model.Movie
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND deleted_at is null", movieID).First(&movie).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errMovieNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if version != nil && *version != movie.Version {
+		return nil, errVersionConflict
+	}
+	return &movie, nil
+}
+
+func setMovieImage(tx *gorm.DB, before *model.Movie, url string, revise func(*model.Movie, *model.Movie) (*model.MovieRevision, error)) error {
+	if before.Image == url {
+		return nil
+	}
+	after := *before
+	after.Image = url
+	after.UpdatedAt = time.Now()
+	after.Version = before.Version + 1
+
+	var revision *model.MovieRevision
+	if revise != nil {
+		var err error
+		if revision, err = revise(before, &after); err != nil {
+			return err
+		}
+	}
+	err := tx.Model(&model.Movie{}).
+		Where("id = ?", before.ID).
+		Updates(map[string]any{"image": url, "updated_at": after.UpdatedAt, "version": gorm.Expr("version + 1")}).Error
+	if err != nil {
+		return err
+	}
+	return createRevision(tx, before.ID, revision)
+}
+
+func createPrimaryPosters(tx *gorm.DB, movies []model.Movie) error {
+	posters := make([]model.MovieImage, 0)
+	for _, movie := range movies {
+		if movie.Image != "" {
+			posters = append(posters, model.MovieImage{MovieID: movie.ID, Kind: posterKind, URL: movie.Image, Primary: true, CreatedAt: movie.CreatedAt})
+		}
+	}
+	if len(posters) == 0 {
+		return nil
+	}
+	return tx.CreateInBatches(posters, bulkBatchSize).Error
+}
+
+func syncPrimaryPoster(tx *gorm.DB, movieID int64, url string) error {
+	if url == "" {
+		return nil
+	}
+
+	var current []model.MovieImage
+	if err := tx.Where("movie_id = ? AND kind = ? AND is_primary", movieID, posterKind).Limit(1).Find(&current).Error; err != nil {
+		return err
+	}
+	if len(current) > 0 {
+		if current[0].URL == url {
+			return nil
+		}
+		if len(current[0].Thumbnails) == 0 {
+			return tx.Model(&current[0]).Updates(map[string]any{"url": url, "width": 0, "height": 0}).Error
+		}
+		if err := tx.Model(&current[0]).Update("is_primary", false).Error; err != nil {
+			return err
+		}
+	}
+
+	position, err := nextImagePosition(tx, movieID)
+	if err != nil {
+		return err
+	}
+	poster := model.MovieImage{MovieID: movieID, Kind: posterKind, URL: url, Primary: true, Position: position, CreatedAt: time.Now()}
+	return tx.Create(&poster).Error
+}
@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+)
+
+func TestListMovieImages(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	rows := sqlmock.NewRows([]string{"id", "movie_id", "kind", "url", "is_primary", "position"}).
+		AddRow(1, 3, "poster", "poster.jpg", true, 0).
+		AddRow(2, 3, "backdrop", "backdrop.jpg", true, 1)
+	mock.ExpectQuery("SELECT \\* FROM \"movie_images\" WHERE movie_id = .+ ORDER BY position,id").WithArgs(3).WillReturnRows(rows)
+	images, err := repo.ListMovieImages(3)
+	assert.Nil(t, err)
+	assert.Len(t, *images, 2)
+	assert.True(t, (*images)[0].Primary)
+	assert.Equal(t, "backdrop.jpg", (*images)[1].URL)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateMovieImage_PrimaryPoster(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM \"movies\" WHERE id = .+ AND deleted_at is null .+ FOR UPDATE").WithArgs(3, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "image", "version"}).AddRow(3, "Dans 3", "old.jpg", 2))
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM \"movie_images\" WHERE movie_id = .+ AND kind = .+ AND is_primary").
+		WithArgs(3, "poster").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectExec("UPDATE \"movie_images\" SET \"is_primary\"=.+ WHERE movie_id = .+ AND kind = .+ AND is_primary").
+		WithArgs(false, 3, "poster").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT COALESCE\\(MAX\\(position\\) \\+ 1, 0\\) FROM \"movie_images\" WHERE movie_id = .+").
+		WithArgs(3).WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow(2))
+	mock.ExpectQuery("INSERT INTO \"movie_images\" (.+) VALUES (.+)").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+	mock.ExpectExec("UPDATE \"movies\" SET (.+) WHERE id = .+").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT COALESCE\\(MAX\\(revision\\), 0\\) FROM \"movie_revisions\" WHERE movie_id = .+").
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow(4))
+	mock.ExpectQuery("INSERT INTO \"movie_revisions\" (.+) VALUES (.+)").
+		WithArgs(3, 5, "update", sqlmock.AnyArg(), sqlmock.AnyArg(), "editor", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+	mock.ExpectCommit()
+
+	var before, after model.Movie
+	revise := func(from, to *model.Movie) (*model.MovieRevision, error) {
+		before, after = *from, *to
+		return &model.MovieRevision{Action: "update", Snapshot: "{}", Diff: "{}", Actor: "editor", CreatedAt: time.Now()}, nil
+	}
+	image := model.MovieImage{MovieID: 3, Kind: "poster", URL: "new.jpg", Primary: true}
+	version := int64(2)
+	assert.Nil(t, repo.CreateMovieImage(&image, &version, revise))
+	assert.Equal(t, int64(5), image.ID)
+	assert.Equal(t, 2, image.Position)
+	assert.Equal(t, "old.jpg", before.Image)
+	assert.Equal(t, "new.jpg", after.Image)
+	assert.Equal(t, int64(3), after.Version)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestReorderMovieImages_Mismatch(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT \"id\" FROM \"movie_images\" WHERE movie_id = .+").WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	mock.ExpectRollback()
+
+	err := repo.ReorderMovieImages(3, []int64{2, 9})
+	assert.True(t, apperror.Is(err, apperror.KindValidation))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteMovieImage_OnlyPoster(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM \"movies\" WHERE id = .+ AND deleted_at is null .+ FOR UPDATE").WithArgs(3, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "image", "version"}).AddRow(3, "poster.jpg", 1))
+	mock.ExpectQuery("SELECT \\* FROM \"movie_images\" WHERE id = .+ AND movie_id = .+ LIMIT .+").WithArgs(5, 3, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "movie_id", "kind", "url", "is_primary"}).AddRow(5, 3, "poster", "poster.jpg", true))
+	mock.ExpectExec("DELETE FROM \"movie_images\" WHERE \"movie_images\".\"id\" = .+").WithArgs(5).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT \\* FROM \"movie_images\" WHERE movie_id = .+ AND kind = .+ ORDER BY position,id,\"movie_images\".\"id\" LIMIT .+").WithArgs(3, "poster", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectRollback()
+
+	_, err := repo.DeleteMovieImage(3, 5, nil, nil)
+	assert.True(t, apperror.Is(err, apperror.KindConflict))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteMovieImage_VersionConflict(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM \"movies\" WHERE id = .+ AND deleted_at is null .+ FOR UPDATE").WithArgs(3, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "version"}).AddRow(3, 4))
+	mock.ExpectRollback()
+
+	version := int64(2)
+	_, err := repo.DeleteMovieImage(3, 5, &version, nil)
+	assert.True(t, apperror.Is(err, apperror.KindPreconditionFailed))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateMovie_SyncsPrimaryPoster(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	posterSQL := "SELECT \\* FROM \"movie_images\" WHERE movie_id = .+ AND kind = .+ AND is_primary LIMIT .+"
+	expectMovieUpdate := func() {
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE \"movies\" SET (.+) WHERE id = .+ AND deleted_at is null AND version = .+").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE movies SET search_vector = .+ WHERE id = .+").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+
+	expectMovieUpdate()
+	mock.ExpectQuery(posterSQL).WithArgs(1, "poster", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "movie_id", "kind", "url", "is_primary", "thumbnails"}).AddRow(4, 1, "poster", "old.jpg", true, "{}"))
+	mock.ExpectExec("UPDATE \"movie_images\" SET \"height\"=.+,\"url\"=.+,\"width\"=.+ WHERE \"id\" = .+").
+		WithArgs(0, "new.jpg", 0, 4).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	assert.Nil(t, repo.UpdateMovie(1, model.Movie{Title: "Dans 1", Image: "new.jpg", Version: 1}, nil))
+
+	expectMovieUpdate()
+	mock.ExpectQuery(posterSQL).WithArgs(1, "poster", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "movie_id", "kind", "url", "is_primary", "thumbnails"}).AddRow(5, 1, "poster", "/images/a.png", true, `{"original":"/images/a.png"}`))
+	mock.ExpectExec("UPDATE \"movie_images\" SET \"is_primary\"=.+ WHERE \"id\" = .+").WithArgs(false, 5).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT COALESCE\\(MAX\\(position\\) \\+ 1, 0\\) FROM \"movie_images\" WHERE movie_id = .+").
+		WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow(3))
+	mock.ExpectQuery("INSERT INTO \"movie_images\" (.+) VALUES (.+)").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(6))
+	mock.ExpectCommit()
+	assert.Nil(t, repo.UpdateMovie(1, model.Movie{Title: "Dans 1", Image: "new.jpg", Version: 2}, nil))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestListReferencedImageURLs(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	mock.ExpectQuery("SELECT image FROM movies WHERE image IN \\(.+\\) UNION SELECT url FROM movie_images WHERE url IN \\(.+\\)").
+		WithArgs("/images/a.png", "/images/b.png", "/images/a.png", "/images/b.png").
+		WillReturnRows(sqlmock.NewRows([]string{"image"}).AddRow("/images/a.png"))
+
+	referenced, err := repo.ListReferencedImageURLs([]string{"/images/a.png", "/images/b.png"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"/images/a.png"}, referenced)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
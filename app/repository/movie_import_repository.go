@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+)
+
+var errImportJobNotFound = apperror.NotFound("Import Job Not Found")
+
+func (ar *AppRepository) CreateImportJob(job *model.ImportJob) error {
+	if err := ar.DB.Create(job).Error; err != nil {
+		return wrapDBError(err, "Cannot Perform DB Creation")
+	}
+	return nil
+}
+
+func (ar *AppRepository) UpdateImportJob(job model.ImportJob) error {
+	err := ar.DB.Model(&model.ImportJob{}).Where("id = ?", job.ID).
+		Select("status", "processed", "inserted", "skipped", "failed", "error", "started_at", "finished_at").
+		Updates(&job).Error
+	if err != nil {
+		return wrapDBError(err, "Cannot Perform DB Update")
+	}
+	return nil
+}
+
+func (ar *AppRepository) GetImportJob(id int64) (*model.ImportJob, error) {
+	var job model.ImportJob
+
+	if err := ar.DB.Where("id = ?", id).First(&job).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errImportJobNotFound
+		}
+		return nil, wrapDBError(err, "Cannot Perform DB Query")
+	}
+
+	return &job, nil
+}
+
+func (ar *AppRepository) CreateImportJobErrors(jobErrors []model.ImportJobError) error {
+	if len(jobErrors) == 0 {
+		return nil
+	}
+	if err := ar.DB.CreateInBatches(&jobErrors, bulkBatchSize).Error; err != nil {
+		return wrapDBError(err, "Cannot Perform DB Creation")
+	}
+	return nil
+}
+
+func (ar *AppRepository) ListImportJobErrors(jobID int64) (*[]model.ImportJobError, error) {
+	jobErrors := make([]model.ImportJobError, 0)
+
+	if err := ar.DB.Where("job_id = ?", jobID).Order("row").Find(&jobErrors).Error; err != nil {
+		return nil, wrapDBError(err, "Cannot Perform DB Query")
+	}
+
+	return &jobErrors, nil
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+)
+
+func TestUpdateImportJob(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	expectedSQL := "UPDATE \"import_jobs\" SET \"status\"=.+,\"processed\"=.+,\"inserted\"=.+,\"skipped\"=.+,\"failed\"=.+,\"error\"=.+,\"started_at\"=.+,\"finished_at\"=.+ WHERE id = .+"
+	mock.ExpectBegin()
+	mock.ExpectExec(expectedSQL).WithArgs("running", 10, 8, 1, 1, "", nil, nil, 5).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	err := repo.UpdateImportJob(model.ImportJob{ID: 5, Status: "running", Processed: 10, Inserted: 8, Skipped: 1, Failed: 1})
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestGetImportJob_NotFound(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	mock.ExpectQuery("SELECT (.+) FROM \"import_jobs\" WHERE id = .+").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	_, err := repo.GetImportJob(5)
+	assert.True(t, apperror.Is(err, apperror.KindNotFound))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestListImportJobErrors(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	rows := sqlmock.NewRows([]string{"id", "job_id", "row", "message", "raw"}).AddRow(1, 5, 3, "Title Cannot Be Empty", ",x,1,a.jpg")
+	mock.ExpectQuery("SELECT (.+) FROM \"import_job_errors\" WHERE job_id = .+ ORDER BY row").WithArgs(5).WillReturnRows(rows)
+	jobErrors, err := repo.ListImportJobErrors(5)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), (*jobErrors)[0].Row)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"xsis-code-test/models/model"
+)
+
+func (ar *AppRepository) MarkMovieMerged(sourceID, targetID int64) error {
+	err := ar.DB.Model(&model.Movie{}).
+		Where("id = ? OR merged_into = ?", sourceID, sourceID).
+		Update("merged_into", targetID).Error
+	if err != nil {
+		return wrapDBError(err, "Cannot Perform DB Update")
+	}
+	return nil
+}
+
+func (ar *AppRepository) GetMergedMovieID(id int64) (int64, error) {
+	var movie model.Movie
+
+	err := ar.DB.Select("merged_into").
+		Where("id = ? AND deleted_at is not null AND merged_into is not null", id).
+		First(&movie).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, errMovieNotFound
+		}
+		return 0, wrapDBError(err, "Cannot Perform DB Query")
+	}
+
+	return *movie.MergedInto, nil
+}
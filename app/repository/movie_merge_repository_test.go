@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"xsis-code-test/apperror"
+)
+
+func TestMarkMovieMerged(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	expectedSQL := "UPDATE \"movies\" SET \"merged_into\"=.+ WHERE id = .+ OR merged_into = .+"
+	mock.ExpectBegin()
+	mock.ExpectExec(expectedSQL).WithArgs(2, sqlmock.AnyArg(), 1, 1).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+	err := repo.MarkMovieMerged(1, 2)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestGetMergedMovieID(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	expectedSQL := "SELECT \"merged_into\" FROM \"movies\" WHERE id = .+ AND deleted_at is not null AND merged_into is not null ORDER BY .+ LIMIT .+"
+	mock.ExpectQuery(expectedSQL).WithArgs(1, sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"merged_into"}).AddRow(2))
+	id, err := repo.GetMergedMovieID(1)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), id)
+
+	mock.ExpectQuery(expectedSQL).WithArgs(3, sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"merged_into"}))
+	_, err = repo.GetMergedMovieID(3)
+	assert.True(t, apperror.Is(err, apperror.KindNotFound))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
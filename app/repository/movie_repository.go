@@ -2,51 +2,264 @@ package repository
 
 import (
 	"errors"
-	"log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
 	"time"
+	"xsis-code-test/apperror"
 	"xsis-code-test/models/model"
+	"xsis-code-test/models/request"
 )
 
-func (ar *AppRepository) CreateMovie(movie model.Movie) error {
-	if err := ar.DB.Create(&movie).Error; err != nil {
-		log.Println(err.Error())
-		return errors.New("Cannot Perform DB Creation")
+var (
+	errMovieNotFound   = apperror.NotFound("Movie Not Found")
+	errVersionConflict = apperror.PreconditionFailed("Movie Has Been Modified")
+)
+
+var movieUpdateColumns = []string{
+	"title", "description", "rating", "image", "release_date", "runtime_minutes", "original_language",
+	"spoken_languages", "production_countries", "certifications", "updated_at", "version",
+}
+
+func (ar *AppRepository) CreateMovie(movie *model.Movie, revision *model.MovieRevision) error {
+	err := ar.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(movie).Error; err != nil {
+			return err
+		}
+		if err := refreshSearchVector(tx, movie.ID); err != nil {
+			return err
+		}
+		if err := createMovieGenres(tx, []model.Movie{*movie}); err != nil {
+			return err
+		}
+		if err := createPrimaryPosters(tx, []model.Movie{*movie}); err != nil {
+			return err
+		}
+		return createRevision(tx, movie.ID, revision)
+	})
+	if err != nil {
+		return wrapDBError(err, "Cannot Perform DB Creation")
 	}
 	return nil
 }
-func (ar *AppRepository) ListMovie() (*[]model.Movie, error) {
+
+func (ar *AppRepository) ListMovie(req request.ListMovie) (*[]model.Movie, int64, error) {
+	movies := make([]model.Movie, 0)
+	var total int64
+
+	if err := ar.DB.Model(&model.Movie{}).Scopes(filterMovie(req)).Count(&total).Error; err != nil {
+		return nil, 0, wrapDBError(err, "Cannot Perform DB Query")
+	}
+
+	query := ar.DB.Scopes(filterMovie(req), sortMovie(req.SortKeys()))
+	if req.Limit > 0 {
+		query = query.Limit(req.Limit)
+	}
+	if err := query.Offset(req.Offset).Find(&movies).Error; err != nil {
+		return nil, 0, wrapDBError(err, "Cannot Perform DB Query")
+	}
+
+	return &movies, total, nil
+}
+
+func (ar *AppRepository) ListMovieTitle() (*[]model.Movie, error) {
+	movies := make([]model.Movie, 0)
+
+	if err := ar.DB.Select("id", "title").Where("deleted_at is null").Find(&movies).Error; err != nil {
+		return nil, wrapDBError(err, "Cannot Perform DB Query")
+	}
+
+	return &movies, nil
+}
+
+func filterMovie(req request.ListMovie) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("deleted_at is null").Scopes(criteriaMovie(req))
+	}
+}
+
+func criteriaMovie(req request.ListMovie) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if req.MinRating != nil {
+			db = db.Where("rating >= ?", *req.MinRating)
+		}
+		if req.MaxRating != nil {
+			db = db.Where("rating <= ?", *req.MaxRating)
+		}
+		if req.TitleContains != "" {
+			db = db.Where("title ILIKE ?", "%"+escapeLike(req.TitleContains)+"%")
+		}
+		if len(req.GenreIDs) > 0 {
+			db = db.Where("id IN (SELECT movie_id FROM movie_genres WHERE genre_id IN ?)", req.GenreIDs)
+		}
+		if req.CreatedAfter != nil {
+			db = db.Where("created_at >= ?", *req.CreatedAfter)
+		}
+		if req.CreatedBefore != nil {
+			db = db.Where("created_at < ?", *req.CreatedBefore)
+		}
+		if req.ReleasedAfter != nil {
+			db = db.Where("release_date >= ?", *req.ReleasedAfter)
+		}
+		if req.ReleasedBefore != nil {
+			db = db.Where("release_date < ?", *req.ReleasedBefore)
+		}
+		if req.MinRuntime != nil {
+			db = db.Where("runtime_minutes >= ?", *req.MinRuntime)
+		}
+		if req.MaxRuntime != nil {
+			db = db.Where("runtime_minutes <= ?", *req.MaxRuntime)
+		}
+		if req.Language != "" {
+			db = db.Where("original_language = ?", req.Language)
+		}
+		if req.SpokenLanguage != "" {
+			db = db.Where("spoken_languages @> jsonb_build_array(?::text)", req.SpokenLanguage)
+		}
+		if req.Country != "" {
+			db = db.Where("production_countries @> jsonb_build_array(?::text)", req.Country)
+		}
+		if country, rating, ok := strings.Cut(req.Certification, ":"); ok {
+			db = db.Where("certifications ->> ? = ?", country, rating)
+		}
+		return db
+	}
+}
+
+func (ar *AppRepository) ListMovieAfter(req request.ListMovie, cursor request.MovieCursor) (*[]model.Movie, error) {
 	movies := make([]model.Movie, 0)
 
-	if err := ar.DB.Where("deleted_at is null").Find(&movies).Error; err != nil {
-		log.Println(err.Error())
-		return nil, errors.New("Cannot Perform DB Query")
+	query := ar.DB.Scopes(filterMovie(req), keysetMovie(cursor), sortMovie(cursor.Sort))
+	if err := query.Limit(req.Limit).Find(&movies).Error; err != nil {
+		return nil, wrapDBError(err, "Cannot Perform DB Query")
 	}
 
 	return &movies, nil
 }
+
+func sortMovie(keys []string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		for _, key := range keys {
+			db = db.Order(clause.OrderByColumn{
+				Column: movieSortColumn(key),
+				Desc:   strings.HasPrefix(key, "-"),
+			})
+		}
+		return db
+	}
+}
+
+func keysetMovie(cursor request.MovieCursor) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		conditions := make([]clause.Expression, 0, len(cursor.Sort))
+		for i, key := range cursor.Sort {
+			exprs := make([]clause.Expression, 0, i+1)
+			for j := 0; j < i; j++ {
+				column := movieSortColumn(cursor.Sort[j])
+				exprs = append(exprs, clause.Eq{Column: column, Value: cursor.Values[j]})
+			}
+			column := movieSortColumn(key)
+			if strings.HasPrefix(key, "-") {
+				exprs = append(exprs, clause.Lt{Column: column, Value: cursor.Values[i]})
+			} else {
+				exprs = append(exprs, clause.Gt{Column: column, Value: cursor.Values[i]})
+			}
+			conditions = append(conditions, clause.And(exprs...))
+		}
+		return db.Where(clause.Or(conditions...))
+	}
+}
+
+func movieSortColumn(key string) clause.Column {
+	name := strings.TrimPrefix(key, "-")
+	if name == "release_date" {
+		return clause.Column{Name: "COALESCE(release_date, DATE '0001-01-01')", Raw: true}
+	}
+	return clause.Column{Name: name}
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
 func (ar *AppRepository) GetMovie(id int64) (*model.Movie, error) {
 	var movie model.Movie
 
-	if err := ar.DB.Where("id = ?", id).Find(&movie).Error; err != nil {
-		log.Println(err.Error())
-		return nil, errors.New("Cannot Perform DB Query")
+	if err := ar.DB.Where("id = ? AND deleted_at is null", id).First(&movie).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errMovieNotFound
+		}
+		return nil, wrapDBError(err, "Cannot Perform DB Query")
 	}
 
 	return &movie, nil
 }
-func (ar *AppRepository) UpdateMovie(id int64, movie model.Movie) error {
+
+func (ar *AppRepository) UpdateMovie(id int64, movie model.Movie, revision *model.MovieRevision) error {
+	version := movie.Version
+	movie.Version = version + 1
 	movie.UpdatedAt = time.Now()
-	if err := ar.DB.Model(&model.Movie{}).Where("id = ?", id).Updates(&movie).Error; err != nil {
-		log.Println(err.Error())
-		return errors.New("Cannot Perform DB Update")
+	err := ar.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Movie{}).Where("id = ? AND deleted_at is null AND version = ?", id, version).
+			Select(movieUpdateColumns).
+			Updates(&movie)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return staleMovie(tx, id)
+		}
+		if err := refreshSearchVector(tx, id); err != nil {
+			return err
+		}
+		if movie.Genres != nil {
+			if err := replaceMovieGenres(tx, id, movie.Genres); err != nil {
+				return err
+			}
+		}
+		if err := syncPrimaryPoster(tx, id, movie.Image); err != nil {
+			return err
+		}
+		return createRevision(tx, id, revision)
+	})
+	if errors.Is(err, errMovieNotFound) || errors.Is(err, errVersionConflict) {
+		return err
+	}
+	if err != nil {
+		return wrapDBError(err, "Cannot Perform DB Update")
 	}
 	return nil
 }
 
-func (ar *AppRepository) DeleteMovie(id int64) error {
-	if err := ar.DB.Model(&model.Movie{}).Where("id = ?", id).Update("deleted_at", time.Now()).Error; err != nil {
-		log.Println(err.Error())
-		return errors.New("Cannot Perform DB Delete")
+func (ar *AppRepository) DeleteMovie(id int64, version int64, revision *model.MovieRevision) error {
+	err := ar.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Movie{}).
+			Where("id = ? AND deleted_at is null AND version = ?", id, version).
+			Updates(map[string]any{"deleted_at": time.Now(), "version": gorm.Expr("version + 1")})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return staleMovie(tx, id)
+		}
+		return createRevision(tx, id, revision)
+	})
+	if errors.Is(err, errMovieNotFound) || errors.Is(err, errVersionConflict) {
+		return err
+	}
+	if err != nil {
+		return wrapDBError(err, "Cannot Perform DB Delete")
 	}
 	return nil
 }
+
+func staleMovie(tx *gorm.DB, id int64) error {
+	var count int64
+	if err := tx.Model(&model.Movie{}).Where("id = ? AND deleted_at is null", id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return errMovieNotFound
+	}
+	return errVersionConflict
+}
@@ -9,7 +9,9 @@ import (
 	"gorm.io/gorm/logger"
 	"testing"
 	"time"
+	"xsis-code-test/apperror"
 	"xsis-code-test/models/model"
+	"xsis-code-test/models/request"
 )
 
 func NewRepoMock(t *testing.T) (*sql.DB, *gorm.DB, sqlmock.Sqlmock) {
@@ -38,9 +40,10 @@ func TestCreateMovie(t *testing.T) {
 	expectedSQL := "INSERT INTO \"movies\" (.+) VALUES (.+)"
 	mock.ExpectBegin()
 	mock.ExpectQuery(expectedSQL).WillReturnRows(addRow)
+	mock.ExpectExec("UPDATE movies SET search_vector = .+ WHERE id = .+").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
 	var reqMovie model.Movie
-	repo.CreateMovie(reqMovie)
+	repo.CreateMovie(&reqMovie, nil)
 	assert.Nil(t, mock.ExpectationsWereMet())
 }
 
@@ -52,7 +55,7 @@ func TestCreateMovie_Error(t *testing.T) {
 	mock.ExpectBegin()
 	mock.ExpectCommit()
 	var reqMovie model.Movie
-	err := repo.CreateMovie(reqMovie)
+	err := repo.CreateMovie(&reqMovie, nil)
 	assert.NotNil(t, err)
 }
 
@@ -61,12 +64,13 @@ func TestUpdateMovie(t *testing.T) {
 	defer sqlDB.Close()
 
 	repo := NewAppRepository(db)
-	expectedSQL := "UPDATE \"movies\" SET \"updated_at\"=.+ WHERE id =.+"
+	expectedSQL := "UPDATE \"movies\" SET \"title\"=.+\"rating\"=.+\"release_date\"=.+\"runtime_minutes\"=.+\"updated_at\"=.+\"version\"=.+ WHERE id =.+"
 	mock.ExpectBegin()
 	mock.ExpectExec(expectedSQL).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("UPDATE movies SET search_vector = .+ WHERE id = .+").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
 	var reqMovie model.Movie
-	err := repo.UpdateMovie(1, reqMovie)
+	err := repo.UpdateMovie(1, reqMovie, nil)
 	assert.Nil(t, err)
 	assert.Nil(t, mock.ExpectationsWereMet())
 }
@@ -81,7 +85,7 @@ func TestUpdateMovie_Error(t *testing.T) {
 	mock.ExpectExec(expectedSQL).WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 	var reqMovie model.Movie
-	err := repo.UpdateMovie(1, reqMovie)
+	err := repo.UpdateMovie(1, reqMovie, nil)
 	assert.NotNil(t, err)
 }
 
@@ -94,7 +98,7 @@ func TestDeleteMovie(t *testing.T) {
 	mock.ExpectBegin()
 	mock.ExpectExec(expectedSQL).WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
-	err := repo.DeleteMovie(1)
+	err := repo.DeleteMovie(1, 1, nil)
 	assert.Nil(t, err)
 	assert.Nil(t, mock.ExpectationsWereMet())
 }
@@ -108,7 +112,7 @@ func TestDeleteMovie_Error(t *testing.T) {
 	mock.ExpectBegin()
 	mock.ExpectExec(expectedSQL).WillReturnResult(sqlmock.NewResult(0, 0))
 	mock.ExpectCommit()
-	err := repo.DeleteMovie(3)
+	err := repo.DeleteMovie(3, 1, nil)
 	assert.NotNil(t, err)
 }
 
@@ -120,9 +124,11 @@ func TestFindMovie_shouldFound(t *testing.T) {
 	movies := sqlmock.NewRows([]string{"id", "title", "description", "rating", "image", "created_at", "updated_at", "deleted_at"}).
 		AddRow(1, "beranakdalamkubur", "kubur dalam anak", 5, "ini.jpg", time.Now(), time.Now(), nil)
 
-	expectedSQL := "SELECT (.+) FROM \"movies\" WHERE deleted_at is null"
+	countSQL := "SELECT count\\(\\*\\) FROM \"movies\" WHERE deleted_at is null"
+	expectedSQL := "SELECT (.+) FROM \"movies\" WHERE deleted_at is null ORDER BY \"id\" LIMIT .+"
+	mock.ExpectQuery(countSQL).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
 	mock.ExpectQuery(expectedSQL).WillReturnRows(movies)
-	_, res := implObj.ListMovie()
+	_, _, res := implObj.ListMovie(request.ListMovie{Limit: 10})
 	assert.Nil(t, res)
 	assert.Nil(t, mock.ExpectationsWereMet())
 }
@@ -133,13 +139,89 @@ func TestFindMovie_shouldNotFound(t *testing.T) {
 
 	implObj := NewAppRepository(db)
 	movies := sqlmock.NewRows([]string{"id", "title", "description", "rating", "image", "created_at", "updated_at", "deleted_at"})
-	expectedSQL := "SELECT (.+) FROM \"movies\" WHERE deleted_at is null"
+	countSQL := "SELECT count\\(\\*\\) FROM \"movies\" WHERE deleted_at is null"
+	expectedSQL := "SELECT (.+) FROM \"movies\" WHERE deleted_at is null ORDER BY \"id\" LIMIT .+"
+	mock.ExpectQuery(countSQL).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
 	mock.ExpectQuery(expectedSQL).WillReturnRows(movies)
-	_, res := implObj.ListMovie()
+	_, _, res := implObj.ListMovie(request.ListMovie{Limit: 10})
 	assert.Nil(t, res)
 	assert.Nil(t, mock.ExpectationsWereMet())
 }
 
+func TestFindMovie_withFilterAndSort(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	implObj := NewAppRepository(db)
+	minRating := float32(5)
+	createdAfter := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	req := request.ListMovie{
+		Limit:         5,
+		Offset:        10,
+		Sort:          []string{"rating", "-created_at"},
+		MinRating:     &minRating,
+		TitleContains: "50%",
+		CreatedAfter:  &createdAfter,
+	}
+	movies := sqlmock.NewRows([]string{"id", "title", "description", "rating", "image", "created_at", "updated_at", "deleted_at"}).
+		AddRow(1, "50% off", "kubur dalam anak", 5, "ini.jpg", time.Now(), time.Now(), nil)
+
+	countSQL := "SELECT count\\(\\*\\) FROM \"movies\" WHERE deleted_at is null AND rating >= .+ AND title ILIKE .+ AND created_at >= .+"
+	expectedSQL := "SELECT (.+) FROM \"movies\" WHERE (.+) ORDER BY \"rating\",\"created_at\" DESC,\"id\" LIMIT .+ OFFSET .+"
+	mock.ExpectQuery(countSQL).WithArgs(minRating, "%50\\%%", createdAfter).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(11))
+	mock.ExpectQuery(expectedSQL).WillReturnRows(movies)
+	result, total, err := implObj.ListMovie(req)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(11), total)
+	assert.Len(t, *result, 1)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestFindMovie_withMetadataFilterAndSort(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	implObj := NewAppRepository(db)
+	releasedAfter := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	minRuntime := 90
+	req := request.ListMovie{
+		Limit:          5,
+		Sort:           []string{"-release_date"},
+		ReleasedAfter:  &releasedAfter,
+		MinRuntime:     &minRuntime,
+		Language:       "fr",
+		SpokenLanguage: "en",
+		Country:        "FR",
+		Certification:  "FR:12",
+	}
+
+	countSQL := "SELECT count\\(\\*\\) FROM \"movies\" WHERE deleted_at is null AND release_date >= .+ AND runtime_minutes >= .+ AND original_language = .+ AND spoken_languages @> jsonb_build_array\\(.+::text\\) AND production_countries @> jsonb_build_array\\(.+::text\\) AND certifications ->> .+ = .+"
+	expectedSQL := "SELECT (.+) FROM \"movies\" WHERE (.+) ORDER BY COALESCE\\(release_date, DATE '0001-01-01'\\) DESC,\"id\" LIMIT .+"
+	mock.ExpectQuery(countSQL).WithArgs(releasedAfter, minRuntime, "fr", "en", "FR", "FR", "12").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(expectedSQL).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	_, total, err := implObj.ListMovie(req)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), total)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestFindMovieAfter(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	implObj := NewAppRepository(db)
+	cursor := request.MovieCursor{Sort: []string{"-rating", "id"}, Values: []any{float32(7.5), int64(12)}}
+	movies := sqlmock.NewRows([]string{"id", "title", "description", "rating", "image", "created_at", "updated_at", "deleted_at"}).
+		AddRow(13, "beranakdalamkubur", "kubur dalam anak", 7.5, "ini.jpg", time.Now(), time.Now(), nil)
+
+	expectedSQL := "SELECT (.+) FROM \"movies\" WHERE deleted_at is null AND \\(\"rating\" < .+ OR \\(\"rating\" = .+ AND \"id\" > .+\\)\\) ORDER BY \"rating\" DESC,\"id\" LIMIT .+"
+	mock.ExpectQuery(expectedSQL).WithArgs(float32(7.5), float32(7.5), int64(12), sqlmock.AnyArg()).WillReturnRows(movies)
+	result, err := implObj.ListMovieAfter(request.ListMovie{Limit: 3, Sort: []string{"-rating"}}, cursor)
+	assert.Nil(t, err)
+	assert.Len(t, *result, 1)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
 func TestImplementation_GetMovieById(t *testing.T) {
 	sqlDB, db, mock := NewRepoMock(t)
 	defer sqlDB.Close()
@@ -154,3 +236,99 @@ func TestImplementation_GetMovieById(t *testing.T) {
 	assert.Nil(t, res)
 	assert.Nil(t, mock.ExpectationsWereMet())
 }
+
+func TestSearchMovie(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	implObj := NewAppRepository(db)
+	results := sqlmock.NewRows([]string{"id", "title", "description", "rating", "image", "created_at", "updated_at", "deleted_at", "rank", "title_highlight", "snippet"}).
+		AddRow(1, "beranak dalam kubur", "kubur dalam anak", 5, "ini.jpg", time.Now(), time.Now(), nil, 0.6, "beranak dalam <mark>kubur</mark>", "<mark>kubur</mark> dalam anak")
+
+	countSQL := "SELECT count\\(\\*\\) FROM movies, websearch_to_tsquery\\(.+\\) query WHERE movies.deleted_at is null AND movies.search_vector @@ query"
+	expectedSQL := "SELECT movies.\\*, ts_rank_cd(.+) AS rank, ts_headline(.+) AS title_highlight, ts_headline(.+) AS snippet FROM movies, websearch_to_tsquery\\(.+\\) query WHERE (.+) ORDER BY rank DESC, movies.id LIMIT .+"
+	mock.ExpectQuery(countSQL).WithArgs("english", "kubur").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(expectedSQL).WillReturnRows(results)
+	movies, total, err := implObj.SearchMovie(request.SearchMovie{Query: "kubur", Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, "<mark>kubur</mark> dalam anak", (*movies)[0].Snippet)
+	assert.Equal(t, float32(0.6), (*movies)[0].Rank)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestGetMovie_NotFound(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	implObj := NewAppRepository(db)
+	movies := sqlmock.NewRows([]string{"id", "title", "description", "rating", "image", "created_at", "updated_at", "deleted_at"})
+	movieSQL := "SELECT (.+) FROM \"movies\" WHERE id = .+ AND deleted_at is null ORDER BY \"movies\".\"id\" LIMIT .+"
+	mock.ExpectQuery(movieSQL).WithArgs(int64(9), sqlmock.AnyArg()).WillReturnRows(movies)
+	movie, err := implObj.GetMovie(9)
+	assert.Nil(t, movie)
+	assert.True(t, apperror.Is(err, apperror.KindNotFound))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateMovie_NotFound(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	expectedSQL := "UPDATE \"movies\" SET .+ WHERE id = .+ AND deleted_at is null"
+	mock.ExpectBegin()
+	mock.ExpectExec(expectedSQL).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM \"movies\" WHERE id = .+ AND deleted_at is null").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectRollback()
+	err := repo.UpdateMovie(9, model.Movie{Title: "Dans 9"}, nil)
+	assert.True(t, apperror.Is(err, apperror.KindNotFound))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteMovie_NotFound(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	expectedSQL := "UPDATE \"movies\" SET \"deleted_at\"=.+ WHERE id = .+ AND deleted_at is null"
+	mock.ExpectBegin()
+	mock.ExpectExec(expectedSQL).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM \"movies\" WHERE id = .+ AND deleted_at is null").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectRollback()
+	err := repo.DeleteMovie(9, 1, nil)
+	assert.True(t, apperror.Is(err, apperror.KindNotFound))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateMovie_VersionConflict(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	expectedSQL := "UPDATE \"movies\" SET .+\"version\"=.+ WHERE id = .+ AND deleted_at is null AND version = .+"
+	mock.ExpectBegin()
+	mock.ExpectExec(expectedSQL).
+		WithArgs("Dans 1", "", sqlmock.AnyArg(), "", sqlmock.AnyArg(), 0, "", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), int64(4), int64(1), int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM \"movies\" WHERE id = .+ AND deleted_at is null").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectRollback()
+	err := repo.UpdateMovie(1, model.Movie{Title: "Dans 1", Version: 3}, nil)
+	assert.True(t, apperror.Is(err, apperror.KindPreconditionFailed))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteMovie_VersionConflict(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	expectedSQL := "UPDATE \"movies\" SET \"deleted_at\"=.+,\"version\"=version \\+ 1.+ WHERE id = .+ AND deleted_at is null AND version = .+"
+	mock.ExpectBegin()
+	mock.ExpectExec(expectedSQL).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM \"movies\" WHERE id = .+ AND deleted_at is null").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectRollback()
+	err := repo.DeleteMovie(1, 2, nil)
+	assert.True(t, apperror.Is(err, apperror.KindPreconditionFailed))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+)
+
+var errRevisionNotFound = apperror.NotFound("Movie Revision Not Found")
+
+func createRevision(tx *gorm.DB, movieID int64, revision *model.MovieRevision) error {
+	if revision == nil {
+		return nil
+	}
+
+	var latest int
+	err := tx.Model(&model.MovieRevision{}).
+		Select("COALESCE(MAX(revision), 0)").
+		Where("movie_id = ?", movieID).
+		Scan(&latest).Error
+	if err != nil {
+		return err
+	}
+
+	revision.MovieID = movieID
+	revision.Revision = latest + 1
+	return tx.Create(revision).Error
+}
+
+func createRevisions(tx *gorm.DB, revisions []*model.MovieRevision) error {
+	pending := make([]*model.MovieRevision, 0, len(revisions))
+	movieIDs := make([]int64, 0, len(revisions))
+	for _, revision := range revisions {
+		if revision != nil {
+			pending = append(pending, revision)
+			movieIDs = append(movieIDs, revision.MovieID)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	var latest []struct {
+		MovieID  int64
+		Revision int
+	}
+	err := tx.Model(&model.MovieRevision{}).
+		Select("movie_id, MAX(revision) AS revision").
+		Where("movie_id IN ?", movieIDs).
+		Group("movie_id").
+		Scan(&latest).Error
+	if err != nil {
+		return err
+	}
+
+	next := make(map[int64]int, len(latest))
+	for _, row := range latest {
+		next[row.MovieID] = row.Revision
+	}
+	for _, revision := range pending {
+		next[revision.MovieID]++
+		revision.Revision = next[revision.MovieID]
+	}
+	return tx.CreateInBatches(pending, bulkBatchSize).Error
+}
+
+func (ar *AppRepository) ListMovieRevision(movieID int64) (*[]model.MovieRevision, error) {
+	revisions := make([]model.MovieRevision, 0)
+
+	if err := ar.DB.Where("movie_id = ?", movieID).Order("revision DESC").Find(&revisions).Error; err != nil {
+		return nil, wrapDBError(err, "Cannot Perform DB Query")
+	}
+
+	return &revisions, nil
+}
+
+func (ar *AppRepository) GetMovieRevision(movieID int64, revision int) (*model.MovieRevision, error) {
+	var movieRevision model.MovieRevision
+
+	if err := ar.DB.Where("movie_id = ? AND revision = ?", movieID, revision).First(&movieRevision).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errRevisionNotFound
+		}
+		return nil, wrapDBError(err, "Cannot Perform DB Query")
+	}
+
+	return &movieRevision, nil
+}
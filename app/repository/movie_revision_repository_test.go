@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+)
+
+func TestUpdateMovie_WritesRevision(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"movies\" SET .+ WHERE id = .+ AND deleted_at is null").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE movies SET search_vector = .+ WHERE id = .+").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT COALESCE\\(MAX\\(revision\\), 0\\) FROM \"movie_revisions\" WHERE movie_id = .+").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow(2))
+	mock.ExpectQuery("INSERT INTO \"movie_revisions\" (.+) VALUES (.+)").
+		WithArgs(1, 3, "update", `{"title":"Dans 1"}`, `{}`, "editor", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	mock.ExpectCommit()
+
+	revision := &model.MovieRevision{Action: "update", Snapshot: `{"title":"Dans 1"}`, Diff: `{}`, Actor: "editor", CreatedAt: time.Now()}
+	err := repo.UpdateMovie(1, model.Movie{Title: "Dans 1"}, revision)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), revision.MovieID)
+	assert.Equal(t, 3, revision.Revision)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteMovie_RevisionErrorRollsBack(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"movies\" SET \"deleted_at\"=.+ WHERE id = .+ AND deleted_at is null").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT COALESCE\\(MAX\\(revision\\), 0\\) FROM \"movie_revisions\" WHERE movie_id = .+").
+		WillReturnError(sqlmock.ErrCancelled)
+	mock.ExpectRollback()
+
+	err := repo.DeleteMovie(1, 1, &model.MovieRevision{Action: "delete"})
+	assert.NotNil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestListMovieRevision(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	rows := sqlmock.NewRows([]string{"id", "movie_id", "revision", "action", "snapshot", "diff", "actor", "created_at"}).
+		AddRow(2, 1, 2, "update", `{}`, `{}`, "editor", time.Now()).
+		AddRow(1, 1, 1, "create", `{}`, `{}`, "editor", time.Now())
+	mock.ExpectQuery("SELECT (.+) FROM \"movie_revisions\" WHERE movie_id = .+ ORDER BY revision DESC").WithArgs(1).WillReturnRows(rows)
+
+	revisions, err := repo.ListMovieRevision(1)
+	assert.Nil(t, err)
+	assert.Len(t, *revisions, 2)
+	assert.Equal(t, 2, (*revisions)[0].Revision)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestGetMovieRevision_NotFound(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	rows := sqlmock.NewRows([]string{"id", "movie_id", "revision", "action", "snapshot", "diff", "actor", "created_at"})
+	mock.ExpectQuery("SELECT (.+) FROM \"movie_revisions\" WHERE movie_id = .+ AND revision = .+ LIMIT .+").WithArgs(1, 5, sqlmock.AnyArg()).WillReturnRows(rows)
+
+	revision, err := repo.GetMovieRevision(1, 5)
+	assert.Nil(t, revision)
+	assert.True(t, apperror.Is(err, apperror.KindNotFound))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"xsis-code-test/models/model"
+	"xsis-code-test/models/request"
+)
+
+const (
+	titleWeight       = 1.0
+	descriptionWeight = 0.4
+	snippetWords      = 25
+)
+
+var searchStopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "by": true,
+	"for": true, "from": true, "in": true, "is": true, "it": true, "of": true, "on": true, "or": true,
+	"the": true, "to": true, "with": true,
+}
+
+type MemorySearchRepository struct {
+	mu     sync.RWMutex
+	movies map[int64]model.Movie
+}
+
+func NewMemorySearchRepository(movies ...model.Movie) *MemorySearchRepository {
+	msr := &MemorySearchRepository{movies: make(map[int64]model.Movie)}
+	for _, movie := range movies {
+		msr.PutMovie(movie)
+	}
+	return msr
+}
+
+func (msr *MemorySearchRepository) PutMovie(movie model.Movie) {
+	msr.mu.Lock()
+	defer msr.mu.Unlock()
+	msr.movies[movie.ID] = movie
+}
+
+func (msr *MemorySearchRepository) RemoveMovie(id int64) {
+	msr.mu.Lock()
+	defer msr.mu.Unlock()
+	delete(msr.movies, id)
+}
+
+func (msr *MemorySearchRepository) SearchMovie(req request.SearchMovie) (*[]model.MovieSearchResult, int64, error) {
+	include, exclude := parseSearchQuery(req.Query)
+	results := make([]model.MovieSearchResult, 0)
+
+	msr.mu.RLock()
+	for _, movie := range msr.movies {
+		if movie.DeletedAt != nil || len(include) == 0 {
+			continue
+		}
+		titleTerms := searchTerms(movie.Title)
+		descriptionTerms := searchTerms(movie.Description)
+		if containsAny(titleTerms, exclude) || containsAny(descriptionTerms, exclude) {
+			continue
+		}
+
+		rank, matched := 0.0, true
+		for _, term := range include {
+			titleHits, descriptionHits := countTerm(titleTerms, term), countTerm(descriptionTerms, term)
+			if titleHits+descriptionHits == 0 {
+				matched = false
+				break
+			}
+			rank += titleWeight*float64(titleHits) + descriptionWeight*float64(descriptionHits)
+		}
+		if !matched {
+			continue
+		}
+		rank /= 1 + math.Log(float64(1+len(titleTerms)+len(descriptionTerms)))
+
+		results = append(results, model.MovieSearchResult{
+			Movie:          movie,
+			Rank:           float32(rank),
+			TitleHighlight: highlight(movie.Title, include, 0),
+			Snippet:        highlight(movie.Description, include, snippetWords),
+		})
+	}
+	msr.mu.RUnlock()
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Rank != results[j].Rank {
+			return results[i].Rank > results[j].Rank
+		}
+		return results[i].ID < results[j].ID
+	})
+
+	total := int64(len(results))
+	if req.Offset >= len(results) {
+		results = results[:0]
+	} else {
+		results = results[req.Offset:]
+	}
+	if req.Limit > 0 && len(results) > req.Limit {
+		results = results[:req.Limit]
+	}
+	return &results, total, nil
+}
+
+func parseSearchQuery(query string) ([]string, []string) {
+	include, exclude := make([]string, 0), make([]string, 0)
+	for _, word := range strings.Fields(query) {
+		negate := strings.HasPrefix(word, "-")
+		for _, term := range searchTerms(word) {
+			if negate {
+				exclude = append(exclude, term)
+			} else {
+				include = append(include, term)
+			}
+		}
+	}
+	return include, exclude
+}
+
+func searchTerms(text string) []string {
+	terms := make([]string, 0)
+	for _, word := range splitWords(text) {
+		word = strings.ToLower(word)
+		if searchStopWords[word] {
+			continue
+		}
+		terms = append(terms, stemWord(word))
+	}
+	return terms
+}
+
+func splitWords(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func stemWord(word string) string {
+	for _, suffix := range []string{"ing", "ies", "ed", "es", "s"} {
+		if len(word) > len(suffix)+2 && strings.HasSuffix(word, suffix) {
+			if suffix == "ies" {
+				return strings.TrimSuffix(word, suffix) + "y"
+			}
+			return strings.TrimSuffix(word, suffix)
+		}
+	}
+	return word
+}
+
+func countTerm(terms []string, term string) int {
+	count := 0
+	for _, t := range terms {
+		if t == term {
+			count++
+		}
+	}
+	return count
+}
+
+func containsAny(terms []string, candidates []string) bool {
+	for _, candidate := range candidates {
+		if countTerm(terms, candidate) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func highlight(text string, include []string, maxWords int) string {
+	words := strings.Fields(text)
+	first := -1
+	for i, word := range words {
+		for _, term := range searchTerms(word) {
+			if countTerm(include, term) > 0 {
+				words[i] = "<mark>" + word + "</mark>"
+				if first < 0 {
+					first = i
+				}
+				break
+			}
+		}
+	}
+	if maxWords <= 0 || len(words) <= maxWords {
+		return strings.Join(words, " ")
+	}
+
+	start := first - maxWords/2
+	if start < 0 {
+		start = 0
+	}
+	end := start + maxWords
+	if end > len(words) {
+		end, start = len(words), len(words)-maxWords
+	}
+	snippet := strings.Join(words[start:end], " ")
+	if start > 0 {
+		snippet = "... " + snippet
+	}
+	if end < len(words) {
+		snippet += " ..."
+	}
+	return snippet
+}
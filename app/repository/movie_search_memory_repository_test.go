@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"xsis-code-test/models/model"
+	"xsis-code-test/models/request"
+)
+
+func newMemorySearchFixture() *MemorySearchRepository {
+	deletedAt := time.Now()
+	return NewMemorySearchRepository(
+		model.Movie{ID: 1, Title: "Inception", Description: "A thief who steals corporate secrets through dream-sharing technology"},
+		model.Movie{ID: 2, Title: "Dream House", Description: "A family moves into a house with a dark past"},
+		model.Movie{ID: 3, Title: "Interstellar", Description: "Explorers travel through a wormhole in space while dreaming of home"},
+		model.Movie{ID: 4, Title: "Dreams", Description: "Deleted dreams", DeletedAt: &deletedAt},
+	)
+}
+
+func TestMemorySearchMovie(t *testing.T) {
+	testcases := []struct {
+		name          string
+		query         string
+		expectedIDs   []int64
+		expectedTotal int64
+	}{
+		{
+			name:          "title match ranks first",
+			query:         "dream",
+			expectedIDs:   []int64{2, 3, 1},
+			expectedTotal: 3,
+		},
+		{
+			name:          "all terms must match",
+			query:         "dream thief",
+			expectedIDs:   []int64{1},
+			expectedTotal: 1,
+		},
+		{
+			name:          "excluded term",
+			query:         "dreams -house",
+			expectedIDs:   []int64{3, 1},
+			expectedTotal: 2,
+		},
+		{
+			name:          "stop words only",
+			query:         "the of",
+			expectedIDs:   []int64{},
+			expectedTotal: 0,
+		},
+	}
+
+	repo := newMemorySearchFixture()
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			results, total, err := repo.SearchMovie(request.SearchMovie{Query: tc.query, Limit: 10})
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedTotal, total)
+			ids := make([]int64, 0)
+			for _, result := range *results {
+				ids = append(ids, result.ID)
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+		})
+	}
+}
+
+func TestMemorySearchMovie_HighlightAndPaging(t *testing.T) {
+	repo := newMemorySearchFixture()
+
+	results, total, err := repo.SearchMovie(request.SearchMovie{Query: "thief", Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, "Inception", (*results)[0].TitleHighlight)
+	assert.Contains(t, (*results)[0].Snippet, "<mark>thief</mark>")
+
+	results, total, err = repo.SearchMovie(request.SearchMovie{Query: "dream", Limit: 1, Offset: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), total)
+	assert.Len(t, *results, 1)
+
+	repo.RemoveMovie(2)
+	repo.PutMovie(model.Movie{ID: 5, Title: "Daydream", Description: "Nothing here"})
+	results, _, _ = repo.SearchMovie(request.SearchMovie{Query: "house", Limit: 10})
+	assert.Len(t, *results, 0)
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+	"xsis-code-test/models/model"
+	"xsis-code-test/models/request"
+)
+
+const (
+	searchConfig       = "english"
+	searchVectorSQL    = "setweight(to_tsvector('english', coalesce(title, '')), 'A') || setweight(to_tsvector('english', coalesce(description, '')), 'B')"
+	searchHighlightOpt = "StartSel=<mark>, StopSel=</mark>, HighlightAll=true"
+	searchSnippetOpt   = "StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=25, MinWords=10, FragmentDelimiter=\" ... \""
+)
+
+func refreshSearchVector(tx *gorm.DB, id int64) error {
+	return tx.Exec("UPDATE movies SET search_vector = "+searchVectorSQL+" WHERE id = ?", id).Error
+}
+
+func (ar *AppRepository) SearchMovie(req request.SearchMovie) (*[]model.MovieSearchResult, int64, error) {
+	results := make([]model.MovieSearchResult, 0)
+	var total int64
+
+	search := func(db *gorm.DB) *gorm.DB {
+		return db.Table("movies, websearch_to_tsquery(?, ?) query", searchConfig, req.Query).
+			Where("movies.deleted_at is null AND movies.search_vector @@ query")
+	}
+
+	if err := ar.DB.Scopes(search).Count(&total).Error; err != nil {
+		return nil, 0, wrapDBError(err, "Cannot Perform DB Query")
+	}
+
+	err := ar.DB.Scopes(search).
+		Select("movies.*, ts_rank_cd(movies.search_vector, query) AS rank, "+
+			"ts_headline(?, movies.title, query, ?) AS title_highlight, "+
+			"ts_headline(?, movies.description, query, ?) AS snippet",
+			searchConfig, searchHighlightOpt, searchConfig, searchSnippetOpt).
+		Order("rank DESC, movies.id").
+		Limit(req.Limit).
+		Offset(req.Offset).
+		Find(&results).Error
+	if err != nil {
+		return nil, 0, wrapDBError(err, "Cannot Perform DB Query")
+	}
+
+	return &results, total, nil
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"xsis-code-test/models/model"
+	"xsis-code-test/utils"
+)
+
+const (
+	titlePrefixScore   = 3.0
+	wordPrefixScore    = 2.0
+	minTrigramQuery    = 3
+	minTrigramCoverage = 0.5
+)
+
+type suggestWord struct {
+	word string
+	id   int64
+}
+
+type MemorySuggestRepository struct {
+	mu       sync.RWMutex
+	titles   map[int64]string
+	words    []suggestWord
+	trigrams map[string]map[int64]struct{}
+}
+
+func NewMemorySuggestRepository() *MemorySuggestRepository {
+	return &MemorySuggestRepository{
+		titles:   make(map[int64]string),
+		words:    make([]suggestWord, 0),
+		trigrams: make(map[string]map[int64]struct{}),
+	}
+}
+
+func (msr *MemorySuggestRepository) PutTitle(id int64, title string) {
+	msr.mu.Lock()
+	defer msr.mu.Unlock()
+
+	msr.removeTitle(id)
+	msr.titles[id] = title
+	normalized := utils.NormalizeText(title)
+	for _, word := range strings.Fields(normalized) {
+		entry := suggestWord{word: word, id: id}
+		i := sort.Search(len(msr.words), func(i int) bool { return !lessSuggestWord(msr.words[i], entry) })
+		msr.words = append(msr.words, suggestWord{})
+		copy(msr.words[i+1:], msr.words[i:])
+		msr.words[i] = entry
+	}
+	for trigram := range utils.Trigrams(normalized) {
+		if msr.trigrams[trigram] == nil {
+			msr.trigrams[trigram] = make(map[int64]struct{})
+		}
+		msr.trigrams[trigram][id] = struct{}{}
+	}
+}
+
+func (msr *MemorySuggestRepository) RemoveTitle(id int64) {
+	msr.mu.Lock()
+	defer msr.mu.Unlock()
+	msr.removeTitle(id)
+}
+
+func (msr *MemorySuggestRepository) removeTitle(id int64) {
+	title, ok := msr.titles[id]
+	if !ok {
+		return
+	}
+	delete(msr.titles, id)
+
+	normalized := utils.NormalizeText(title)
+	for _, word := range strings.Fields(normalized) {
+		entry := suggestWord{word: word, id: id}
+		i := sort.Search(len(msr.words), func(i int) bool { return !lessSuggestWord(msr.words[i], entry) })
+		if i < len(msr.words) && msr.words[i] == entry {
+			msr.words = append(msr.words[:i], msr.words[i+1:]...)
+		}
+	}
+	for trigram := range utils.Trigrams(normalized) {
+		delete(msr.trigrams[trigram], id)
+		if len(msr.trigrams[trigram]) == 0 {
+			delete(msr.trigrams, trigram)
+		}
+	}
+}
+
+func (msr *MemorySuggestRepository) SuggestTitle(prefix string, limit int) []model.MovieSuggestion {
+	query := utils.NormalizeText(prefix)
+	if query == "" || limit <= 0 {
+		return []model.MovieSuggestion{}
+	}
+
+	msr.mu.RLock()
+	defer msr.mu.RUnlock()
+
+	scores := make(map[int64]float64)
+	queryWords := strings.Fields(query)
+	lastWord := queryWords[len(queryWords)-1]
+	i := sort.Search(len(msr.words), func(i int) bool { return msr.words[i].word >= lastWord })
+	for ; i < len(msr.words) && strings.HasPrefix(msr.words[i].word, lastWord); i++ {
+		id := msr.words[i].id
+		title := utils.NormalizeText(msr.titles[id])
+		switch {
+		case strings.HasPrefix(title, query):
+			scores[id] = titlePrefixScore
+		case len(queryWords) == 1 || strings.Contains(title, query):
+			if scores[id] < wordPrefixScore {
+				scores[id] = wordPrefixScore
+			}
+		}
+	}
+
+	if len([]rune(query)) >= minTrigramQuery {
+		queryTrigrams := utils.Trigrams(query)
+		shared := make(map[int64]int)
+		for trigram := range queryTrigrams {
+			for id := range msr.trigrams[trigram] {
+				shared[id]++
+			}
+		}
+		for id, count := range shared {
+			coverage := float64(count) / float64(len(queryTrigrams))
+			if coverage >= minTrigramCoverage && coverage > scores[id] {
+				scores[id] = coverage
+			}
+		}
+	}
+
+	suggestions := make([]model.MovieSuggestion, 0, len(scores))
+	for id, score := range scores {
+		suggestions = append(suggestions, model.MovieSuggestion{ID: id, Title: msr.titles[id], Score: float32(score)})
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if len(a.Title) != len(b.Title) {
+			return len(a.Title) < len(b.Title)
+		}
+		if a.Title != b.Title {
+			return a.Title < b.Title
+		}
+		return a.ID < b.ID
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+func lessSuggestWord(a, b suggestWord) bool {
+	if a.word != b.word {
+		return a.word < b.word
+	}
+	return a.id < b.id
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newMemorySuggestFixture() *MemorySuggestRepository {
+	repo := NewMemorySuggestRepository()
+	repo.PutTitle(1, "Inception")
+	repo.PutTitle(2, "The Dark Knight")
+	repo.PutTitle(3, "The Dark Knight Rises")
+	repo.PutTitle(4, "Interstellar")
+	repo.PutTitle(5, "Insidious")
+	return repo
+}
+
+func TestMemorySuggestTitle(t *testing.T) {
+	testcases := []struct {
+		name        string
+		prefix      string
+		limit       int
+		expectedIDs []int64
+	}{
+		{
+			name:        "title prefix",
+			prefix:      "in",
+			limit:       10,
+			expectedIDs: []int64{1, 5, 4},
+		},
+		{
+			name:        "word prefix",
+			prefix:      "Kni",
+			limit:       10,
+			expectedIDs: []int64{2, 3},
+		},
+		{
+			name:        "multi word prefix",
+			prefix:      "dark knight r",
+			limit:       10,
+			expectedIDs: []int64{3, 2},
+		},
+		{
+			name:        "typo",
+			prefix:      "incpetion",
+			limit:       10,
+			expectedIDs: []int64{1},
+		},
+		{
+			name:        "limit",
+			prefix:      "the",
+			limit:       1,
+			expectedIDs: []int64{2},
+		},
+		{
+			name:        "no match",
+			prefix:      "zzz",
+			limit:       10,
+			expectedIDs: []int64{},
+		},
+	}
+
+	repo := newMemorySuggestFixture()
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ids := make([]int64, 0)
+			for _, suggestion := range repo.SuggestTitle(tc.prefix, tc.limit) {
+				ids = append(ids, suggestion.ID)
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+		})
+	}
+}
+
+func TestMemorySuggestTitle_Sync(t *testing.T) {
+	repo := newMemorySuggestFixture()
+
+	repo.PutTitle(1, "Memento")
+	assert.Empty(t, repo.SuggestTitle("incep", 10))
+	assert.Equal(t, int64(1), repo.SuggestTitle("meme", 10)[0].ID)
+
+	repo.RemoveTitle(2)
+	repo.RemoveTitle(2)
+	suggestions := repo.SuggestTitle("dark", 10)
+	assert.Len(t, suggestions, 1)
+	assert.Equal(t, int64(3), suggestions[0].ID)
+	assert.Len(t, repo.words, 7)
+}
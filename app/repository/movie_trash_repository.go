@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+	"xsis-code-test/models/request"
+)
+
+var errTrashNotFound = apperror.NotFound("Movie Not Found In Trash")
+
+func (ar *AppRepository) ListTrash(req request.ListMovie) (*[]model.Movie, int64, error) {
+	movies := make([]model.Movie, 0)
+	var total int64
+
+	trash := ar.DB.Model(&model.Movie{}).Where("deleted_at is not null").Scopes(criteriaMovie(req))
+	if err := trash.Count(&total).Error; err != nil {
+		return nil, 0, wrapDBError(err, "Cannot Perform DB Query")
+	}
+
+	query := ar.DB.Where("deleted_at is not null").Scopes(criteriaMovie(req), sortMovie([]string{"-deleted_at", "id"}))
+	if req.Limit > 0 {
+		query = query.Limit(req.Limit)
+	}
+	if err := query.Offset(req.Offset).Find(&movies).Error; err != nil {
+		return nil, 0, wrapDBError(err, "Cannot Perform DB Query")
+	}
+
+	return &movies, total, nil
+}
+
+func (ar *AppRepository) RestoreMovie(id int64) error {
+	result := ar.DB.Model(&model.Movie{}).Where("id = ? AND deleted_at is not null", id).Updates(map[string]any{"deleted_at": nil, "merged_into": nil})
+	if result.Error != nil {
+		return wrapDBError(result.Error, "Cannot Perform DB Update")
+	}
+	if result.RowsAffected == 0 {
+		return errTrashNotFound
+	}
+	return nil
+}
+
+func (ar *AppRepository) PurgeMovie(id int64, version *int64) (*[]model.MovieImage, error) {
+	images := make([]model.MovieImage, 0)
+	err := ar.DB.Transaction(func(tx *gorm.DB) error {
+		var movie model.Movie
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND deleted_at is not null", id).First(&movie).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errTrashNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if version != nil && *version != movie.Version {
+			return errVersionConflict
+		}
+
+		if err := tx.Where("movie_id = ?", id).Delete(&model.MovieRevision{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("movie_id = ?", id).Delete(&model.MovieGenre{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("movie_id = ?", id).Delete(&model.Credit{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.Returning{}).Where("movie_id = ?", id).Delete(&images).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&model.Movie{}).Error
+	})
+	if errors.Is(err, errTrashNotFound) || errors.Is(err, errVersionConflict) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, wrapDBError(err, "Cannot Perform DB Delete")
+	}
+	return &images, nil
+}
+
+func (ar *AppRepository) PurgeTrash(deletedBefore time.Time) (*[]model.MovieImage, []int64, error) {
+	images := make([]model.MovieImage, 0)
+	movies := make([]model.Movie, 0)
+	err := ar.DB.Transaction(func(tx *gorm.DB) error {
+		expired := tx.Model(&model.Movie{}).Select("id").Where("deleted_at is not null AND deleted_at < ?", deletedBefore)
+		if err := tx.Where("movie_id IN (?)", expired).Delete(&model.MovieRevision{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("movie_id IN (?)", expired).Delete(&model.MovieGenre{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("movie_id IN (?)", expired).Delete(&model.Credit{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.Returning{}).Where("movie_id IN (?)", expired).Delete(&images).Error; err != nil {
+			return err
+		}
+		return tx.Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}}}).
+			Where("deleted_at is not null AND deleted_at < ?", deletedBefore).Delete(&movies).Error
+	})
+	if err != nil {
+		return nil, nil, wrapDBError(err, "Cannot Perform DB Delete")
+	}
+	purged := make([]int64, 0, len(movies))
+	for _, movie := range movies {
+		purged = append(purged, movie.ID)
+	}
+	return &images, purged, nil
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/request"
+)
+
+func TestListTrash(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	movies := sqlmock.NewRows([]string{"id", "title", "description", "rating", "image", "created_at", "updated_at", "deleted_at"}).
+		AddRow(1, "beranakdalamkubur", "kubur dalam anak", 5, "ini.jpg", time.Now(), time.Now(), time.Now())
+
+	countSQL := "SELECT count\\(\\*\\) FROM \"movies\" WHERE deleted_at is not null"
+	expectedSQL := "SELECT (.+) FROM \"movies\" WHERE deleted_at is not null ORDER BY \"deleted_at\" DESC,\"id\" LIMIT .+"
+	mock.ExpectQuery(countSQL).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(expectedSQL).WillReturnRows(movies)
+	result, total, err := repo.ListTrash(request.ListMovie{Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.NotNil(t, (*result)[0].DeletedAt)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestRestoreMovie(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	expectedSQL := "UPDATE \"movies\" SET \"deleted_at\"=.+ WHERE id = .+ AND deleted_at is not null"
+	mock.ExpectBegin()
+	mock.ExpectExec(expectedSQL).WithArgs(nil, nil, sqlmock.AnyArg(), 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	err := repo.RestoreMovie(1)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestRestoreMovie_NotInTrash(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	expectedSQL := "UPDATE \"movies\" SET \"deleted_at\"=.+ WHERE id = .+ AND deleted_at is not null"
+	mock.ExpectBegin()
+	mock.ExpectExec(expectedSQL).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	err := repo.RestoreMovie(9)
+	assert.True(t, apperror.Is(err, apperror.KindNotFound))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestPurgeMovie(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	trashSQL := "SELECT (.+) FROM \"movies\" WHERE id = .+ AND deleted_at is not null ORDER BY .+ LIMIT .+ FOR UPDATE"
+	revisionSQL := "DELETE FROM \"movie_revisions\" WHERE movie_id = .+"
+	genreSQL := "DELETE FROM \"movie_genres\" WHERE movie_id = .+"
+	creditSQL := "DELETE FROM \"credits\" WHERE movie_id = .+"
+	imageSQL := "DELETE FROM \"movie_images\" WHERE movie_id = .+ RETURNING \\*"
+	expectedSQL := "DELETE FROM \"movies\" WHERE id = .+"
+	mock.ExpectBegin()
+	mock.ExpectQuery(trashSQL).WithArgs(1, sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"id", "version"}).AddRow(1, 4))
+	mock.ExpectExec(revisionSQL).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(genreSQL).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(creditSQL).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(imageSQL).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "movie_id", "url", "thumbnails"}).AddRow(4, 1, "/images/a.png", `{"original":"/images/a.png"}`))
+	mock.ExpectExec(expectedSQL).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	version := int64(4)
+	images, err := repo.PurgeMovie(1, &version)
+	assert.Nil(t, err)
+	assert.Equal(t, "/images/a.png", (*images)[0].Thumbnails["original"])
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(trashSQL).WithArgs(2, sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"id", "version"}))
+	mock.ExpectRollback()
+	_, err = repo.PurgeMovie(2, nil)
+	assert.True(t, apperror.Is(err, apperror.KindNotFound))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(trashSQL).WithArgs(3, sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"id", "version"}).AddRow(3, 5))
+	mock.ExpectRollback()
+	_, err = repo.PurgeMovie(3, &version)
+	assert.True(t, apperror.Is(err, apperror.KindPreconditionFailed))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestPurgeTrash(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	cutoff := time.Now().Add(-30 * 24 * time.Hour)
+	revisionSQL := "DELETE FROM \"movie_revisions\" WHERE movie_id IN \\(SELECT \"id\" FROM \"movies\" WHERE deleted_at is not null AND deleted_at < .+\\)"
+	genreSQL := "DELETE FROM \"movie_genres\" WHERE movie_id IN \\(SELECT \"id\" FROM \"movies\" WHERE deleted_at is not null AND deleted_at < .+\\)"
+	creditSQL := "DELETE FROM \"credits\" WHERE movie_id IN \\(SELECT \"id\" FROM \"movies\" WHERE deleted_at is not null AND deleted_at < .+\\)"
+	imageSQL := "DELETE FROM \"movie_images\" WHERE movie_id IN \\(SELECT \"id\" FROM \"movies\" WHERE deleted_at is not null AND deleted_at < .+\\) RETURNING \\*"
+	expectedSQL := "DELETE FROM \"movies\" WHERE deleted_at is not null AND deleted_at < .+ RETURNING \"id\""
+	mock.ExpectBegin()
+	mock.ExpectExec(revisionSQL).WithArgs(cutoff).WillReturnResult(sqlmock.NewResult(0, 9))
+	mock.ExpectExec(genreSQL).WithArgs(cutoff).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(creditSQL).WithArgs(cutoff).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(imageSQL).WithArgs(cutoff).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4).AddRow(5))
+	mock.ExpectQuery(expectedSQL).WithArgs(cutoff).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7).AddRow(8))
+	mock.ExpectCommit()
+	images, purged, err := repo.PurgeTrash(cutoff)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{7, 8}, purged)
+	assert.Len(t, *images, 2)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
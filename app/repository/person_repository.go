@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+	"xsis-code-test/models/request"
+)
+
+var errPersonNotFound = apperror.NotFound("Person Not Found")
+
+func (ar *AppRepository) CreatePerson(person *model.Person) error {
+	if err := ar.DB.Create(person).Error; err != nil {
+		return wrapDBError(err, "Cannot Perform DB Creation")
+	}
+	return nil
+}
+
+func (ar *AppRepository) ListPerson(req request.ListPerson) (*[]model.Person, int64, error) {
+	people := make([]model.Person, 0)
+	var total int64
+
+	query := ar.DB.Model(&model.Person{})
+	if req.Name != "" {
+		query = query.Where("name ILIKE ?", "%"+escapeLike(req.Name)+"%")
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, wrapDBError(err, "Cannot Perform DB Query")
+	}
+	if err := query.Order("name").Order("id").Limit(req.Limit).Offset(req.Offset).Find(&people).Error; err != nil {
+		return nil, 0, wrapDBError(err, "Cannot Perform DB Query")
+	}
+
+	return &people, total, nil
+}
+
+func (ar *AppRepository) GetPerson(id int64) (*model.Person, error) {
+	var person model.Person
+
+	if err := ar.DB.Where("id = ?", id).First(&person).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errPersonNotFound
+		}
+		return nil, wrapDBError(err, "Cannot Perform DB Query")
+	}
+
+	return &person, nil
+}
+
+func (ar *AppRepository) UpdatePerson(person model.Person) error {
+	result := ar.DB.Model(&model.Person{}).
+		Where("id = ?", person.ID).
+		Select("name", "bio", "birth_date", "photo", "updated_at").
+		Updates(&person)
+	if result.Error != nil {
+		return wrapDBError(result.Error, "Cannot Perform DB Update")
+	}
+	if result.RowsAffected == 0 {
+		return errPersonNotFound
+	}
+	return nil
+}
+
+func (ar *AppRepository) DeletePerson(id int64) error {
+	err := ar.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("person_id = ?", id).Delete(&model.Credit{}).Error; err != nil {
+			return err
+		}
+		result := tx.Where("id = ?", id).Delete(&model.Person{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errPersonNotFound
+		}
+		return nil
+	})
+	if errors.Is(err, errPersonNotFound) {
+		return errPersonNotFound
+	}
+	if err != nil {
+		return wrapDBError(err, "Cannot Perform DB Delete")
+	}
+	return nil
+}
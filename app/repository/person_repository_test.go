@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+	"xsis-code-test/models/request"
+)
+
+func TestCreatePerson(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"people\" (.+) VALUES (.+)").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	mock.ExpectCommit()
+	person := model.Person{Name: "Dans"}
+	assert.Nil(t, repo.CreatePerson(&person))
+	assert.Equal(t, int64(3), person.ID)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestListPerson(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM \"people\" WHERE name ILIKE .+").WithArgs("%dan%").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery("SELECT \\* FROM \"people\" WHERE name ILIKE .+ ORDER BY name,id LIMIT .+").WithArgs("%dan%", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(3, "Dans"))
+	people, total, err := repo.ListPerson(request.ListPerson{Name: "dan", Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, "Dans", (*people)[0].Name)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdatePerson_NotFound(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"people\" SET (.+) WHERE id = .+").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	err := repo.UpdatePerson(model.Person{ID: 9, Name: "Dans"})
+	assert.True(t, apperror.Is(err, apperror.KindNotFound))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestDeletePerson(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM \"credits\" WHERE person_id = .+").WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("DELETE FROM \"people\" WHERE id = .+").WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	assert.Nil(t, repo.DeletePerson(3))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteCredit_NotFound(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM \"credits\" WHERE id = .+ AND movie_id = .+").WithArgs(4, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	err := repo.DeleteCredit(1, 4)
+	assert.True(t, apperror.Is(err, apperror.KindNotFound))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestListMovieCredits(t *testing.T) {
+	sqlDB, db, mock := NewRepoMock(t)
+	defer sqlDB.Close()
+
+	repo := NewAppRepository(db)
+	rows := sqlmock.NewRows([]string{"id", "movie_id", "person_id", "role", "billing_order", "Person__id", "Person__name"}).
+		AddRow(1, 1, 3, "director", 0, 3, "Dans")
+	expectedSQL := "SELECT (.+) FROM \"credits\" LEFT JOIN \"people\" \"Person\" ON \"credits\".\"person_id\" = \"Person\".\"id\" WHERE credits.movie_id = .+ ORDER BY credits.billing_order,credits.id"
+	mock.ExpectQuery(expectedSQL).WithArgs(1).WillReturnRows(rows)
+	credits, err := repo.ListMovieCredits(1)
+	assert.Nil(t, err)
+	assert.Equal(t, "director", (*credits)[0].Role)
+	assert.Equal(t, "Dans", (*credits)[0].Person.Name)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+	"xsis-code-test/models/request"
+	"xsis-code-test/models/response"
+	"xsis-code-test/utils"
+)
+
+func (au *AppUsecase) CreateGenre(req request.CreateGenre) (*response.Genre, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	if err := utils.Validate(req); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	genre := model.Genre{Name: req.Name, CreatedAt: now, UpdatedAt: now}
+	if err := au.AppRepository.CreateGenre(&genre); err != nil {
+		return nil, err
+	}
+
+	resGenre := genreResponse(genre)
+	return &resGenre, nil
+}
+
+func (au *AppUsecase) ListGenre() (*[]response.Genre, error) {
+	genres, err := au.AppRepository.ListGenre()
+	if err != nil {
+		return nil, err
+	}
+
+	resGenres := genreResponses(*genres)
+	return &resGenres, nil
+}
+
+func (au *AppUsecase) GetGenre(id int64) (*response.Genre, error) {
+	genre, err := au.AppRepository.GetGenre(id)
+	if err != nil {
+		return nil, err
+	}
+
+	resGenre := genreResponse(*genre)
+	return &resGenre, nil
+}
+
+func (au *AppUsecase) UpdateGenre(id int64, req request.UpdateGenre) error {
+	req.Name = strings.TrimSpace(req.Name)
+	if err := utils.Validate(req); err != nil {
+		return err
+	}
+	return au.AppRepository.UpdateGenre(id, req.Name)
+}
+
+func (au *AppUsecase) DeleteGenre(id int64) error {
+	return au.AppRepository.DeleteGenre(id)
+}
+
+func (au *AppUsecase) ListGenreMovie(id int64, req request.ListMovie) (*[]response.ListMovie, *response.Pagination, error) {
+	if _, err := au.AppRepository.GetGenre(id); err != nil {
+		return nil, nil, err
+	}
+	req.GenreIDs = []int64{id}
+	return au.ListMovie(req)
+}
+
+func (au *AppUsecase) resolveGenres(ids []int64) ([]model.Genre, error) {
+	unique := make([]int64, 0, len(ids))
+	seen := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) == 0 {
+		return []model.Genre{}, nil
+	}
+
+	genres, err := au.AppRepository.GetGenres(unique)
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[int64]bool, len(*genres))
+	for _, genre := range *genres {
+		found[genre.ID] = true
+	}
+	for _, id := range unique {
+		if !found[id] {
+			return nil, apperror.Validation(fmt.Sprintf("Genre %d Does Not Exist", id))
+		}
+	}
+	return *genres, nil
+}
+
+func (au *AppUsecase) movieGenres(movies []model.Movie) (map[int64][]response.Genre, error) {
+	genres := make(map[int64][]response.Genre, len(movies))
+	if len(movies) == 0 {
+		return genres, nil
+	}
+
+	ids := make([]int64, 0, len(movies))
+	for _, movie := range movies {
+		ids = append(ids, movie.ID)
+	}
+	movieGenres, err := au.AppRepository.ListMovieGenres(ids)
+	if err != nil {
+		return nil, err
+	}
+	for id, list := range movieGenres {
+		genres[id] = genreResponses(list)
+	}
+	return genres, nil
+}
+
+func genreResponse(genre model.Genre) response.Genre {
+	return response.Genre{ID: genre.ID, Name: genre.Name}
+}
+
+func genreResponses(genres []model.Genre) []response.Genre {
+	resGenres := make([]response.Genre, 0, len(genres))
+	for _, genre := range genres {
+		resGenres = append(resGenres, genreResponse(genre))
+	}
+	return resGenres
+}
@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+	"xsis-code-test/models/request"
+	"xsis-code-test/utils"
+)
+
+func Test_CreateGenre(t *testing.T) {
+	appRepo.Mock.On("CreateGenre", mock.MatchedBy(func(genre *model.Genre) bool { return genre.Name == "Science Fiction" })).
+		Run(func(args mock.Arguments) { args.Get(0).(*model.Genre).ID = 21 }).
+		Return(nil)
+
+	genre, err := appUsecase.CreateGenre(request.CreateGenre{Name: "  Science Fiction "})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(21), genre.ID)
+	assert.Equal(t, "Science Fiction", genre.Name)
+
+	_, err = appUsecase.CreateGenre(request.CreateGenre{Name: "   "})
+	var validationErrors utils.ValidationErrors
+	assert.ErrorAs(t, err, &validationErrors)
+}
+
+func Test_CreateMovie_Genres(t *testing.T) {
+	appRepo.Mock.On("GetGenres", []int64{22, 23}).Return(&[]model.Genre{{ID: 22, Name: "Crime"}, {ID: 23, Name: "Drama"}}, nil)
+	appRepo.Mock.On("GetGenres", []int64{22, 29}).Return(&[]model.Genre{{ID: 22, Name: "Crime"}}, nil)
+	appRepo.Mock.On("ListDuplicateCandidates", "Dans Genre", mock.Anything).Return(&[]model.Movie{}, nil)
+	appRepo.Mock.On("CreateMovie", mock.MatchedBy(func(movie *model.Movie) bool {
+		return movie.Title == "Dans Genre" && len(movie.Genres) == 2
+	}), mock.Anything).Return(nil)
+
+	req := request.CreateMovie{Title: "Dans Genre", Description: "Dans Genre", Rating: 7, Image: "genre.jpg", GenreIDs: []int64{22, 23, 22}}
+	assert.Nil(t, appUsecase.CreateMovie(req))
+
+	req.GenreIDs = []int64{22, 29}
+	err := appUsecase.CreateMovie(req)
+	assert.True(t, apperror.Is(err, apperror.KindValidation))
+}
+
+func Test_UpdateMovie_Genres(t *testing.T) {
+	appRepo.Mock.On("GetMovie", int64(1201)).Return(&model.Movie{ID: 1201, Title: "Dans 1201", Description: "Dans 1201", Rating: 6, Image: "a.jpg", Version: 1}, nil)
+	appRepo.Mock.On("ListMovieGenres", []int64{1201}).Return(map[int64][]model.Genre{1201: {{ID: 5, Name: "Drama"}}}, nil)
+	appRepo.Mock.On("UpdateMovie", int64(1201), mock.MatchedBy(func(movie model.Movie) bool {
+		return movie.Genres != nil && len(movie.Genres) == 0
+	}), mock.MatchedBy(func(revision *model.MovieRevision) bool {
+		return revision.Diff == `{"genre_ids":{"from":[5],"to":[]}}`
+	})).Return(nil)
+
+	err := appUsecase.UpdateMovie(1201, request.UpdateMovie{GenreIDs: &[]int64{}})
+	assert.Nil(t, err)
+	appRepo.Mock.AssertNotCalled(t, "GetGenres", []int64{})
+}
+
+func Test_ListGenreMovie(t *testing.T) {
+	appRepo.Mock.On("GetGenre", int64(24)).Return(&model.Genre{ID: 24, Name: "Horror"}, nil)
+	appRepo.Mock.On("GetGenre", int64(25)).Return((*model.Genre)(nil), apperror.NotFound("Genre Not Found"))
+	appRepo.Mock.On("ListMovie", request.ListMovie{Page: 1, PageSize: 10, Limit: 10, GenreIDs: []int64{24}}).
+		Return(&[]model.Movie{{ID: 1202, Title: "Dans 1202"}}, int64(1), nil)
+	appRepo.Mock.On("ListMovieGenres", []int64{1202}).Return(map[int64][]model.Genre{1202: {{ID: 24, Name: "Horror"}}}, nil)
+
+	movies, pagination, err := appUsecase.ListGenreMovie(24, request.ListMovie{GenreIDs: []int64{99}})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), *pagination.Total)
+	assert.Equal(t, "Horror", (*movies)[0].Genres[0].Name)
+
+	_, _, err = appUsecase.ListGenreMovie(25, request.ListMovie{})
+	assert.True(t, apperror.Is(err, apperror.KindNotFound))
+}
@@ -3,9 +3,12 @@ package usecase
 import "xsis-code-test/app"
 
 type AppUsecase struct {
-	AppRepository app.IAppRepository
+	AppRepository        app.IAppRepository
+	AppSearchRepository  app.IAppSearchRepository
+	AppSuggestRepository app.IAppSuggestRepository
+	AppBlobRepository    app.IAppBlobRepository
 }
 
-func NewAppUsecase(appRepo app.IAppRepository) *AppUsecase {
-	return &AppUsecase{AppRepository: appRepo}
+func NewAppUsecase(appRepo app.IAppRepository, suggestRepo app.IAppSuggestRepository) *AppUsecase {
+	return &AppUsecase{AppRepository: appRepo, AppSearchRepository: appRepo, AppSuggestRepository: suggestRepo}
 }
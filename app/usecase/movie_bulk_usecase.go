@@ -0,0 +1,282 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+	"xsis-code-test/app"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+	"xsis-code-test/models/request"
+	"xsis-code-test/models/response"
+)
+
+const (
+	bulkModeAtomic     = "atomic"
+	bulkModeBestEffort = "best_effort"
+	maxBulkOperations  = 1000
+)
+
+var errBulkRolledBack = apperror.Conflict("Rolled Back Because Another Operation Failed")
+
+type bulkWrite struct {
+	result   int
+	movie    model.Movie
+	revision *model.MovieRevision
+}
+
+func (au *AppUsecase) BulkMovie(req request.BulkMovie) (*response.BulkMovie, error) {
+	if req.Mode == "" {
+		req.Mode = bulkModeBestEffort
+	}
+	if req.Mode != bulkModeAtomic && req.Mode != bulkModeBestEffort {
+		return nil, apperror.BadRequest("mode Must Be atomic or best_effort")
+	}
+	total := len(req.Create) + len(req.Update) + len(req.Delete)
+	if total == 0 {
+		return nil, apperror.BadRequest("Bulk Request Must Contain At Least One Operation")
+	}
+	if total > maxBulkOperations {
+		return nil, apperror.BadRequest(fmt.Sprintf("Bulk Request Cannot Exceed %d Operations", maxBulkOperations))
+	}
+
+	results := make([]response.BulkMovieResult, 0, total)
+	var creates, updates, deletes []bulkWrite
+
+	now := time.Now()
+	for i, item := range req.Create {
+		results = append(results, response.BulkMovieResult{Operation: "create", Index: i})
+		item.Actor = req.Actor
+		movie, revision, err := newMovie(item, now)
+		if err == nil && len(item.GenreIDs) > 0 {
+			movie.Genres, err = au.resolveGenres(item.GenreIDs)
+		}
+		if err != nil {
+			results[len(results)-1].Err = err
+			continue
+		}
+		creates = append(creates, bulkWrite{result: len(results) - 1, movie: movie, revision: revision})
+	}
+
+	existing, err := au.bulkExisting(req)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[int64]bool)
+
+	for i, item := range req.Update {
+		results = append(results, response.BulkMovieResult{Operation: "update", Index: i, ID: item.ID})
+		movie, err := bulkTarget(existing, seen, item.ID, item.Version)
+		if err != nil {
+			results[len(results)-1].Err = err
+			continue
+		}
+		before := *movie
+		update := request.UpdateMovie{
+			Title:               item.Title,
+			Description:         item.Description,
+			Rating:              item.Rating,
+			Image:               item.Image,
+			ReleaseDate:         item.ReleaseDate,
+			RuntimeMinutes:      item.RuntimeMinutes,
+			OriginalLanguage:    item.OriginalLanguage,
+			SpokenLanguages:     item.SpokenLanguages,
+			ProductionCountries: item.ProductionCountries,
+			Certifications:      item.Certifications,
+		}
+		if err := applyUpdateMovie(movie, update); err != nil {
+			results[len(results)-1].Err = err
+			continue
+		}
+		if err := validateMovie(movie); err != nil {
+			results[len(results)-1].Err = err
+			continue
+		}
+		revision, err := newMovieRevision(revisionUpdate, req.Actor, &before, movie)
+		if err != nil {
+			results[len(results)-1].Err = err
+			continue
+		}
+		updates = append(updates, bulkWrite{result: len(results) - 1, movie: *movie, revision: revision})
+	}
+
+	for i, item := range req.Delete {
+		results = append(results, response.BulkMovieResult{Operation: "delete", Index: i, ID: item.ID})
+		movie, err := bulkTarget(existing, seen, item.ID, item.Version)
+		if err != nil {
+			results[len(results)-1].Err = err
+			continue
+		}
+		revision, err := newMovieRevision(revisionDelete, req.Actor, movie, nil)
+		if err != nil {
+			results[len(results)-1].Err = err
+			continue
+		}
+		deletes = append(deletes, bulkWrite{result: len(results) - 1, movie: *movie, revision: revision})
+	}
+
+	if req.Mode == bulkModeAtomic {
+		if bulkFailed(results) {
+			rollBackBulk(results)
+			return summarizeBulk(req.Mode, results), nil
+		}
+		err := au.AppRepository.Transaction(func(repo app.IAppRepository) error {
+			return writeBulk(repo, results, creates, updates, deletes, true)
+		})
+		if err != nil {
+			rollBackBulk(results)
+			return summarizeBulk(req.Mode, results), nil
+		}
+	} else {
+		writeBulk(au.AppRepository, results, creates, updates, deletes, false)
+	}
+
+	for _, write := range append(creates, updates...) {
+		if results[write.result].Err == nil {
+			au.AppSuggestRepository.PutTitle(results[write.result].ID, write.movie.Title)
+		}
+	}
+	for _, write := range deletes {
+		if results[write.result].Err == nil {
+			au.AppSuggestRepository.RemoveTitle(write.movie.ID)
+		}
+	}
+
+	return summarizeBulk(req.Mode, results), nil
+}
+
+func (au *AppUsecase) bulkExisting(req request.BulkMovie) (map[int64]*model.Movie, error) {
+	ids := make([]int64, 0, len(req.Update)+len(req.Delete))
+	for _, item := range req.Update {
+		ids = append(ids, item.ID)
+	}
+	for _, item := range req.Delete {
+		ids = append(ids, item.ID)
+	}
+	existing := make(map[int64]*model.Movie, len(ids))
+	if len(ids) == 0 {
+		return existing, nil
+	}
+
+	movies, err := au.AppRepository.GetMovies(ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range *movies {
+		existing[(*movies)[i].ID] = &(*movies)[i]
+	}
+	return existing, nil
+}
+
+func bulkTarget(existing map[int64]*model.Movie, seen map[int64]bool, id int64, version *int64) (*model.Movie, error) {
+	if seen[id] {
+		return nil, apperror.BadRequest(fmt.Sprintf("Movie %d Appears More Than Once", id))
+	}
+	seen[id] = true
+
+	current, ok := existing[id]
+	if !ok {
+		return nil, apperror.NotFound("Movie Not Found")
+	}
+	if err := checkVersion(current, version); err != nil {
+		return nil, err
+	}
+	movie := *current
+	return &movie, nil
+}
+
+func writeBulk(repo app.IAppRepository, results []response.BulkMovieResult, creates, updates, deletes []bulkWrite, atomic bool) error {
+	if len(creates) > 0 {
+		movies := make([]model.Movie, 0, len(creates))
+		revisions := make([]*model.MovieRevision, 0, len(creates))
+		for _, write := range creates {
+			movies = append(movies, write.movie)
+			revisions = append(revisions, write.revision)
+		}
+		if err := repo.CreateMovies(&movies, revisions); err != nil {
+			if atomic {
+				for _, write := range creates {
+					results[write.result].Err = err
+				}
+				return err
+			}
+			for i := range creates {
+				if err := repo.CreateMovie(&creates[i].movie, creates[i].revision); err != nil {
+					results[creates[i].result].Err = err
+				}
+			}
+		} else {
+			for i := range creates {
+				creates[i].movie.ID = movies[i].ID
+			}
+		}
+		for _, write := range creates {
+			if results[write.result].Err == nil {
+				results[write.result].ID = write.movie.ID
+			}
+		}
+	}
+
+	for _, write := range updates {
+		if err := repo.UpdateMovie(write.movie.ID, write.movie, write.revision); err != nil {
+			results[write.result].Err = err
+			if atomic {
+				return err
+			}
+		}
+	}
+
+	if len(deletes) > 0 {
+		movies := make([]model.Movie, 0, len(deletes))
+		revisions := make([]*model.MovieRevision, 0, len(deletes))
+		for _, write := range deletes {
+			movies = append(movies, write.movie)
+			revisions = append(revisions, write.revision)
+		}
+		if err := repo.DeleteMovies(movies, revisions); err != nil {
+			if atomic {
+				for _, write := range deletes {
+					results[write.result].Err = err
+				}
+				return err
+			}
+			for _, write := range deletes {
+				if err := repo.DeleteMovie(write.movie.ID, write.movie.Version, write.revision); err != nil {
+					results[write.result].Err = err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func bulkFailed(results []response.BulkMovieResult) bool {
+	for _, result := range results {
+		if result.Err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func rollBackBulk(results []response.BulkMovieResult) {
+	for i := range results {
+		if results[i].Err == nil {
+			results[i].Err = errBulkRolledBack
+		}
+		if results[i].Operation == "create" {
+			results[i].ID = 0
+		}
+	}
+}
+
+func summarizeBulk(mode string, results []response.BulkMovieResult) *response.BulkMovie {
+	bulk := &response.BulkMovie{Mode: mode, Results: results}
+	for _, result := range results {
+		if result.Err != nil {
+			bulk.Failed++
+		} else {
+			bulk.Succeeded++
+		}
+	}
+	return bulk
+}
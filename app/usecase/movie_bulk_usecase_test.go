@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+	"xsis-code-test/models/request"
+)
+
+func Test_BulkMovie_BestEffort(t *testing.T) {
+	appRepo.Mock.On("GetMovies", []int64{501, 502}).Return(&[]model.Movie{{ID: 501, Title: "Bulk Old", Description: "Bulk", Rating: 3, Image: "bulk.jpg", Version: 2}}, nil)
+	appRepo.Mock.On("CreateMovies", mock.MatchedBy(func(movies *[]model.Movie) bool {
+		return len(*movies) == 1 && (*movies)[0].Title == "Bulk Fresh"
+	}), mock.Anything).Run(func(args mock.Arguments) {
+		(*args.Get(0).(*[]model.Movie))[0].ID = 601
+	}).Return(nil)
+	appRepo.Mock.On("UpdateMovie", int64(501), mock.MatchedBy(func(movie model.Movie) bool {
+		return movie.Title == "Bulk Renamed" && movie.Version == 2 && movie.RuntimeMinutes == 95 &&
+			movie.ReleaseDate != nil && movie.ReleaseDate.Year() == 2001
+	}), mock.Anything).Return(nil)
+
+	data, err := appUsecase.BulkMovie(request.BulkMovie{
+		Create: []request.CreateMovie{
+			{Title: "Bulk Fresh", Description: "Bulk", Rating: 4, Image: "bulk.jpg"},
+			{Title: "", Description: "Bulk", Rating: 4, Image: "bulk.jpg"},
+		},
+		Update: []request.BulkUpdateMovie{{ID: 501, Title: ptr("Bulk Renamed"), ReleaseDate: ptr("2001-05-04"), RuntimeMinutes: ptr(95), Version: ptr(int64(2))}},
+		Delete: []request.BulkDeleteMovie{{ID: 502}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "best_effort", data.Mode)
+	assert.Equal(t, 2, data.Succeeded)
+	assert.Equal(t, 2, data.Failed)
+	assert.Equal(t, int64(601), data.Results[0].ID)
+	assert.NotNil(t, data.Results[1].Err)
+	assert.Nil(t, data.Results[2].Err)
+	assert.True(t, apperror.Is(data.Results[3].Err, apperror.KindNotFound))
+	assert.Len(t, suggestRepo.SuggestTitle("bulk renamed", 5), 1)
+}
+
+func Test_BulkMovie_AtomicRollsBack(t *testing.T) {
+	appRepo.Mock.On("GetMovies", []int64{511}).Return(&[]model.Movie{{ID: 511, Title: "Stale", Version: 3}}, nil)
+
+	data, err := appUsecase.BulkMovie(request.BulkMovie{
+		Mode:   "atomic",
+		Create: []request.CreateMovie{{Title: "Atomic Skipped", Description: "Bulk", Rating: 4, Image: "bulk.jpg"}},
+		Delete: []request.BulkDeleteMovie{{ID: 511, Version: ptr(int64(2))}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, data.Succeeded)
+	assert.True(t, apperror.Is(data.Results[0].Err, apperror.KindConflict))
+	assert.True(t, apperror.Is(data.Results[1].Err, apperror.KindPreconditionFailed))
+	appRepo.Mock.AssertNotCalled(t, "Transaction", mock.Anything)
+
+	appRepo.Mock.On("GetMovies", []int64{521}).Return(&[]model.Movie{{ID: 521, Title: "Contested", Version: 1}}, nil)
+	appRepo.Mock.On("Transaction", mock.Anything).Return(nil)
+	appRepo.Mock.On("CreateMovies", mock.MatchedBy(func(movies *[]model.Movie) bool {
+		return len(*movies) == 1 && (*movies)[0].Title == "Atomic Written"
+	}), mock.Anything).Run(func(args mock.Arguments) {
+		(*args.Get(0).(*[]model.Movie))[0].ID = 621
+	}).Return(nil)
+	appRepo.Mock.On("DeleteMovies", mock.MatchedBy(func(movies []model.Movie) bool {
+		return len(movies) == 1 && movies[0].ID == 521
+	}), mock.Anything).Return(apperror.Conflict("Movies Changed During Bulk Delete"))
+
+	data, err = appUsecase.BulkMovie(request.BulkMovie{
+		Mode:   "atomic",
+		Create: []request.CreateMovie{{Title: "Atomic Written", Description: "Bulk", Rating: 4, Image: "bulk.jpg"}},
+		Delete: []request.BulkDeleteMovie{{ID: 521}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, data.Failed)
+	assert.Equal(t, int64(0), data.Results[0].ID)
+	assert.Empty(t, suggestRepo.SuggestTitle("atomic written", 5))
+}
+
+func Test_BulkMovie_InvalidRequest(t *testing.T) {
+	_, err := appUsecase.BulkMovie(request.BulkMovie{})
+	assert.True(t, apperror.Is(err, apperror.KindBadRequest))
+
+	_, err = appUsecase.BulkMovie(request.BulkMovie{Mode: "eventually", Delete: []request.BulkDeleteMovie{{ID: 1}}})
+	assert.True(t, apperror.Is(err, apperror.KindBadRequest))
+
+	appRepo.Mock.On("GetMovies", []int64{531, 531}).Return(&[]model.Movie{{ID: 531, Title: "Once", Description: "Bulk", Rating: 3, Image: "bulk.jpg", Version: 1}}, nil)
+	appRepo.Mock.On("UpdateMovie", int64(531), mock.Anything, mock.Anything).Return(nil)
+	data, err := appUsecase.BulkMovie(request.BulkMovie{
+		Update: []request.BulkUpdateMovie{{ID: 531, Title: ptr("Twice")}},
+		Delete: []request.BulkDeleteMovie{{ID: 531}},
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, data.Results[0].Err)
+	assert.True(t, apperror.Is(data.Results[1].Err, apperror.KindBadRequest))
+}
@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+	"xsis-code-test/models/response"
+	"xsis-code-test/utils"
+)
+
+const (
+	duplicateThreshold         = 0.75
+	duplicateTitleWeight       = 0.7
+	duplicateDescriptionWeight = 0.3
+	duplicateCandidateLimit    = 20
+	duplicatePairLimit         = 5000
+)
+
+var errDuplicateMovie = apperror.Conflict("Movie May Be A Duplicate, Use force=true To Create Anyway")
+
+var duplicateTitleArticles = []string{"the ", "a ", "an "}
+
+func (au *AppUsecase) findDuplicates(movie model.Movie) ([]response.DuplicateCandidate, error) {
+	movies, err := au.AppRepository.ListDuplicateCandidates(movie.Title, duplicateCandidateLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]response.DuplicateCandidate, 0)
+	for _, existing := range *movies {
+		if existing.ID == movie.ID {
+			continue
+		}
+		if score := duplicateScore(movie, existing); score >= duplicateThreshold {
+			candidates = append(candidates, response.DuplicateCandidate{
+				ID:          existing.ID,
+				Title:       existing.Title,
+				Description: existing.Description,
+				Score:       score,
+			})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].Score > candidates[j].Score })
+	return candidates, nil
+}
+
+func (au *AppUsecase) ListDuplicates() (*[]response.DuplicateCluster, error) {
+	pairs, err := au.AppRepository.ListDuplicatePairs(duplicatePairLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(*pairs)*2)
+	for _, pair := range *pairs {
+		ids = append(ids, pair.LeftID, pair.RightID)
+	}
+	movies, err := au.AppRepository.GetMovies(ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]model.Movie, len(*movies))
+	for _, movie := range *movies {
+		byID[movie.ID] = movie
+	}
+
+	parent := make(map[int64]int64)
+	var find func(int64) int64
+	find = func(id int64) int64 {
+		if parent[id] == id {
+			return id
+		}
+		parent[id] = find(parent[id])
+		return parent[id]
+	}
+	scores := make(map[int64]float64)
+	for _, pair := range *pairs {
+		left, leftOK := byID[pair.LeftID]
+		right, rightOK := byID[pair.RightID]
+		if !leftOK || !rightOK {
+			continue
+		}
+		score := duplicateScore(left, right)
+		if score < duplicateThreshold {
+			continue
+		}
+		for _, id := range []int64{left.ID, right.ID} {
+			if _, ok := parent[id]; !ok {
+				parent[id] = id
+			}
+		}
+		leftRoot, rightRoot := find(left.ID), find(right.ID)
+		if leftRoot != rightRoot {
+			parent[rightRoot] = leftRoot
+			scores[leftRoot] = math.Max(scores[leftRoot], scores[rightRoot])
+		}
+		scores[leftRoot] = math.Max(scores[leftRoot], score)
+	}
+
+	members := make(map[int64][]int64)
+	for id := range parent {
+		root := find(id)
+		members[root] = append(members[root], id)
+	}
+	clusters := make([]response.DuplicateCluster, 0, len(members))
+	for root, ids := range members {
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		cluster := response.DuplicateCluster{Score: scores[root], Movies: make([]response.ListMovie, 0, len(ids))}
+		for _, id := range ids {
+			cluster.Movies = append(cluster.Movies, listMovieResponse(byID[id]))
+		}
+		clusters = append(clusters, cluster)
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		if clusters[i].Score != clusters[j].Score {
+			return clusters[i].Score > clusters[j].Score
+		}
+		return clusters[i].Movies[0].ID < clusters[j].Movies[0].ID
+	})
+
+	return &clusters, nil
+}
+
+func duplicateScore(a, b model.Movie) float64 {
+	title := utils.TrigramSimilarity(duplicateTitle(a.Title), duplicateTitle(b.Title))
+	description := utils.TrigramSimilarity(a.Description, b.Description)
+	score := title*duplicateTitleWeight + description*duplicateDescriptionWeight
+	return math.Round(score*1000) / 1000
+}
+
+func duplicateTitle(title string) string {
+	normalized := utils.NormalizeText(title)
+	for _, article := range duplicateTitleArticles {
+		if trimmed := strings.TrimPrefix(normalized, article); trimmed != normalized && trimmed != "" {
+			return trimmed
+		}
+	}
+	return normalized
+}
@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+	"xsis-code-test/models/request"
+	"xsis-code-test/models/response"
+)
+
+func Test_CreateMovie_Duplicate(t *testing.T) {
+	existing := []model.Movie{
+		{ID: 1001, Title: "Inception", Description: "A thief who steals corporate secrets through dream-sharing technology."},
+		{ID: 1002, Title: "Inception Point", Description: "A documentary about the birth of the universe."},
+	}
+	appRepo.Mock.On("ListDuplicateCandidates", "The Inception", duplicateCandidateLimit).Return(&existing, nil)
+	appRepo.Mock.On("CreateMovie", mock.MatchedBy(func(movie *model.Movie) bool { return movie.Title == "The Inception" }), mock.Anything).Return(nil)
+
+	req := request.CreateMovie{
+		Title:       "The Inception",
+		Description: "A thief who steals corporate secrets through dream sharing technology",
+		Rating:      8.8,
+		Image:       "inception.jpg",
+	}
+	err := appUsecase.CreateMovie(req)
+	assert.True(t, apperror.Is(err, apperror.KindConflict))
+	candidates, ok := apperror.DetailsOf(err).([]response.DuplicateCandidate)
+	assert.True(t, ok)
+	assert.Len(t, candidates, 1)
+	assert.Equal(t, int64(1001), candidates[0].ID)
+	assert.GreaterOrEqual(t, candidates[0].Score, duplicateThreshold)
+	appRepo.Mock.AssertNotCalled(t, "CreateMovie", mock.MatchedBy(func(movie *model.Movie) bool { return movie.Title == "The Inception" }), mock.Anything)
+
+	req.Force = true
+	assert.Nil(t, appUsecase.CreateMovie(req))
+}
+
+func Test_ListDuplicates(t *testing.T) {
+	appRepo.Mock.On("ListDuplicatePairs", duplicatePairLimit).Return(&[]model.MoviePair{
+		{LeftID: 1011, RightID: 1012},
+		{LeftID: 1012, RightID: 1013},
+		{LeftID: 1014, RightID: 1015},
+	}, nil)
+	appRepo.Mock.On("GetMovies", []int64{1011, 1012, 1012, 1013, 1014, 1015}).Return(&[]model.Movie{
+		{ID: 1011, Title: "Dune", Description: "Paul Atreides leads nomadic tribes in a battle for Arrakis."},
+		{ID: 1012, Title: "Dune!", Description: "Paul Atreides leads nomadic tribes in the battle for Arrakis."},
+		{ID: 1013, Title: "DUNE", Description: "Paul Atreides leads the nomadic tribes in a battle for Arrakis"},
+		{ID: 1014, Title: "Frozen", Description: "A princess sets off on a journey."},
+		{ID: 1015, Title: "Frozen II", Description: "Elsa travels to an enchanted forest."},
+	}, nil)
+
+	clusters, err := appUsecase.ListDuplicates()
+	assert.Nil(t, err)
+	assert.Len(t, *clusters, 1)
+	ids := make([]int64, 0)
+	for _, movie := range (*clusters)[0].Movies {
+		ids = append(ids, movie.ID)
+	}
+	assert.Equal(t, []int64{1011, 1012, 1013}, ids)
+	assert.GreaterOrEqual(t, (*clusters)[0].Score, duplicateThreshold)
+}
@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+	"xsis-code-test/models/request"
+	"xsis-code-test/models/response"
+)
+
+func (au *AppUsecase) ExportMovie(req request.ListMovie, fn func(response.ListMovie) error) error {
+	if req.Cursor != "" || req.Page > 0 || req.PageSize > 0 || req.Limit > 0 || req.Offset > 0 {
+		return apperror.BadRequest("Export Does Not Support page, page_size, limit, offset or cursor")
+	}
+	if _, err := normalizeListMovie(&req); err != nil {
+		return err
+	}
+	req.Page, req.PageSize, req.Limit, req.Offset = 0, 0, 0, 0
+
+	return au.AppRepository.EachMovie(req, func(movie model.Movie) error {
+		return fn(listMovieResponse(movie))
+	})
+}
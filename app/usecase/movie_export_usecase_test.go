@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+	"xsis-code-test/models/request"
+	"xsis-code-test/models/response"
+)
+
+func Test_ExportMovie(t *testing.T) {
+	appRepo.Mock.On("EachMovie", request.ListMovie{TitleContains: "export"}).Return(&[]model.Movie{{ID: 801, Title: "Export One", Version: 2}}, nil)
+
+	exported := make([]response.ListMovie, 0)
+	err := appUsecase.ExportMovie(request.ListMovie{TitleContains: "export"}, func(movie response.ListMovie) error {
+		exported = append(exported, movie)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Len(t, exported, 1)
+	assert.Equal(t, int64(2), exported[0].Version)
+
+	err = appUsecase.ExportMovie(request.ListMovie{Page: 2}, func(response.ListMovie) error { return nil })
+	assert.True(t, apperror.Is(err, apperror.KindBadRequest))
+
+	err = appUsecase.ExportMovie(request.ListMovie{Sort: []string{"budget"}}, func(response.ListMovie) error { return nil })
+	assert.True(t, apperror.Is(err, apperror.KindBadRequest))
+}
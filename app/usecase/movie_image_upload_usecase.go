@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"mime"
+	"sort"
+	"strings"
+	"time"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+	"xsis-code-test/models/request"
+	"xsis-code-test/models/response"
+	"xsis-code-test/utils"
+)
+
+const (
+	imageURLPrefix     = "/images/"
+	maxImageDimension  = 8000
+	maxImagePixels     = 40_000_000
+	originalRendition  = "original"
+	defaultContentType = "application/octet-stream"
+)
+
+var thumbnailWidths = []int{92, 185, 500}
+
+func (au *AppUsecase) UploadMovieImage(movieID int64, req request.UploadMovieImage) (*response.MovieImage, error) {
+	req.Kind = strings.ToLower(strings.TrimSpace(req.Kind))
+	req.Language = strings.ToLower(strings.TrimSpace(req.Language))
+	if err := utils.Validate(req); err != nil {
+		return nil, err
+	}
+
+	contentType := utils.SniffImage(req.Data)
+	if contentType == "" {
+		return nil, apperror.UnsupportedMediaType("file Must Be A JPEG, PNG Or GIF Image")
+	}
+	if declared, _, err := mime.ParseMediaType(req.ContentType); err == nil && declared != defaultContentType && declared != contentType {
+		return nil, apperror.UnsupportedMediaType(fmt.Sprintf("file Is Declared As %s But Contains %s", declared, contentType))
+	}
+	config, err := utils.DecodeImageConfig(req.Data, contentType)
+	if err != nil {
+		return nil, apperror.Validation("file Is Not A Valid Image")
+	}
+	if config.Width > maxImageDimension || config.Height > maxImageDimension || config.Width*config.Height > maxImagePixels {
+		return nil, apperror.Validation(fmt.Sprintf("Image Cannot Be Larger Than %dx%d Pixels Or %d Megapixels", maxImageDimension, maxImageDimension, maxImagePixels/1_000_000))
+	}
+	if _, err := au.AppRepository.GetMovie(movieID); err != nil {
+		return nil, err
+	}
+
+	img, err := utils.DecodeImage(req.Data, contentType)
+	if err != nil {
+		return nil, apperror.Validation("file Is Not A Valid Image")
+	}
+	outputType, extension := utils.ImagePNG, ".png"
+	if contentType == utils.ImageJPEG {
+		outputType, extension = utils.ImageJPEG, ".jpg"
+	}
+
+	renditions := map[string][]byte{}
+	var original bytes.Buffer
+	if err := utils.EncodeImage(&original, img, outputType); err != nil {
+		return nil, apperror.Internal("Cannot Encode Image", err)
+	}
+	renditions[originalRendition] = original.Bytes()
+	for _, width := range thumbnailWidths {
+		if width >= img.Bounds().Dx() {
+			continue
+		}
+		var thumbnail bytes.Buffer
+		if err := utils.EncodeImage(&thumbnail, utils.ResizeImage(img, width), outputType); err != nil {
+			return nil, apperror.Internal("Cannot Encode Image", err)
+		}
+		renditions[fmt.Sprintf("w%d", width)] = thumbnail.Bytes()
+	}
+
+	blobID, err := newBlobID()
+	if err != nil {
+		return nil, apperror.Internal("Cannot Store Image", err)
+	}
+	thumbnails := model.StringMap{}
+	for name, data := range renditions {
+		key := fmt.Sprintf("movies/%d/%s/%s%s", movieID, blobID, name, extension)
+		if err := au.AppBlobRepository.PutBlob(key, data, outputType); err != nil {
+			au.deleteImageBlobs(thumbnails)
+			return nil, err
+		}
+		thumbnails[name] = imageURLPrefix + key
+	}
+
+	image := model.MovieImage{
+		MovieID:    movieID,
+		Kind:       req.Kind,
+		URL:        thumbnails[originalRendition],
+		Width:      img.Bounds().Dx(),
+		Height:     img.Bounds().Dy(),
+		Language:   req.Language,
+		Primary:    req.Primary,
+		Thumbnails: thumbnails,
+		CreatedAt:  time.Now(),
+	}
+	if err := au.AppRepository.CreateMovieImage(&image, req.Version, posterRevision(req.Actor)); err != nil {
+		au.deleteImageBlobs(thumbnails)
+		return nil, err
+	}
+
+	resImage := movieImageResponse(image)
+	return &resImage, nil
+}
+
+func (au *AppUsecase) GetImage(key string) (*response.ImageFile, error) {
+	blob, err := au.AppBlobRepository.GetBlob(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.ImageFile{
+		ContentType:  blob.ContentType,
+		Size:         blob.Size,
+		ETag:         blob.ETag,
+		LastModified: blob.LastModified,
+		Body:         blob.Body,
+	}, nil
+}
+
+func (au *AppUsecase) deleteImageBlobs(thumbnails model.StringMap) {
+	for _, url := range thumbnails {
+		key, ok := strings.CutPrefix(url, imageURLPrefix)
+		if !ok {
+			continue
+		}
+		if err := au.AppBlobRepository.DeleteBlob(key); err != nil {
+			log.Println("Cannot Delete Image", key, err)
+		}
+	}
+}
+
+func (au *AppUsecase) deleteUnreferencedImageBlobs(images []model.MovieImage) {
+	urls := make([]string, 0)
+	for _, image := range images {
+		for _, url := range image.Thumbnails {
+			if strings.HasPrefix(url, imageURLPrefix) {
+				urls = append(urls, url)
+			}
+		}
+	}
+	if len(urls) == 0 {
+		return
+	}
+	sort.Strings(urls)
+
+	referenced, err := au.AppRepository.ListReferencedImageURLs(urls)
+	if err != nil {
+		log.Println("Cannot Check Image References", err)
+		return
+	}
+	keep := make(map[string]bool, len(referenced))
+	for _, url := range referenced {
+		keep[url] = true
+	}
+	for _, image := range images {
+		thumbnails := model.StringMap{}
+		for name, url := range image.Thumbnails {
+			if !keep[url] {
+				thumbnails[name] = url
+			}
+		}
+		au.deleteImageBlobs(thumbnails)
+	}
+}
+
+func newBlobID() (string, error) {
+	id := make([]byte, 12)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"bytes"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"testing"
+	"xsis-code-test/app/repository"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+	"xsis-code-test/models/request"
+	"xsis-code-test/utils"
+)
+
+func testImage(t *testing.T, width, height int, contentType string) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+	var buffer bytes.Buffer
+	if contentType == utils.ImageJPEG {
+		assert.Nil(t, jpeg.Encode(&buffer, img, nil))
+	} else {
+		assert.Nil(t, png.Encode(&buffer, img))
+	}
+	return buffer.Bytes()
+}
+
+func storedFiles(t *testing.T, root string) []string {
+	files := make([]string, 0)
+	filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err == nil && !entry.IsDir() {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files
+}
+
+func Test_UploadMovieImage(t *testing.T) {
+	root := t.TempDir()
+	uploadUsecase := AppUsecase{AppRepository: appRepo, AppBlobRepository: repository.NewLocalBlobRepository(root)}
+	appRepo.Mock.On("GetMovie", int64(1404)).Return(&model.Movie{ID: 1404}, nil)
+	appRepo.Mock.On("CreateMovieImage", mock.MatchedBy(func(image *model.MovieImage) bool {
+		return image.MovieID == 1404 && image.Kind == "poster" && image.Width == 600 && image.Height == 900 && len(image.Thumbnails) == 4
+	}), (*int64)(nil)).Run(func(args mock.Arguments) { args.Get(0).(*model.MovieImage).ID = 11 }).Return(nil)
+
+	image, err := uploadUsecase.UploadMovieImage(1404, request.UploadMovieImage{Kind: "Poster", ContentType: "image/jpeg", Data: testImage(t, 600, 900, utils.ImageJPEG)})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(11), image.ID)
+	assert.Equal(t, image.URL, image.Thumbnails["original"])
+	assert.True(t, strings.HasPrefix(image.URL, "/images/movies/1404/"))
+	assert.True(t, strings.HasSuffix(image.Thumbnails["w185"], "/w185.jpg"))
+	assert.Len(t, storedFiles(t, root), 4)
+
+	thumbnail, err := uploadUsecase.GetImage(strings.TrimPrefix(image.Thumbnails["w92"], "/images/"))
+	assert.Nil(t, err)
+	data, _ := io.ReadAll(thumbnail.Body)
+	thumbnail.Body.Close()
+	assert.Equal(t, utils.ImageJPEG, thumbnail.ContentType)
+	config, err := jpeg.DecodeConfig(bytes.NewReader(data))
+	assert.Nil(t, err)
+	assert.Equal(t, 92, config.Width)
+	assert.Equal(t, 138, config.Height)
+}
+
+func Test_UploadMovieImage_Rejected(t *testing.T) {
+	root := t.TempDir()
+	uploadUsecase := AppUsecase{AppRepository: appRepo, AppBlobRepository: repository.NewLocalBlobRepository(root)}
+	appRepo.Mock.On("GetMovie", int64(1405)).Return(&model.Movie{ID: 1405}, nil)
+	appRepo.Mock.On("CreateMovieImage", mock.MatchedBy(func(image *model.MovieImage) bool {
+		return image.MovieID == 1405
+	}), (*int64)(nil)).Return(errors.New("db is down"))
+
+	_, err := uploadUsecase.UploadMovieImage(1405, request.UploadMovieImage{Kind: "poster", Data: []byte(`<svg xmlns="http://www.w3.org/2000/svg"/>`)})
+	assert.True(t, apperror.Is(err, apperror.KindUnsupportedMediaType))
+
+	_, err = uploadUsecase.UploadMovieImage(1405, request.UploadMovieImage{Kind: "poster", ContentType: "image/png", Data: testImage(t, 10, 10, utils.ImageJPEG)})
+	assert.True(t, apperror.Is(err, apperror.KindUnsupportedMediaType))
+
+	_, err = uploadUsecase.UploadMovieImage(1405, request.UploadMovieImage{Kind: "poster", Data: testImage(t, 9000, 1, utils.ImagePNG)})
+	assert.True(t, apperror.Is(err, apperror.KindValidation))
+
+	_, err = uploadUsecase.UploadMovieImage(1405, request.UploadMovieImage{Kind: "poster", Data: testImage(t, 10, 10, utils.ImagePNG)[:40]})
+	assert.True(t, apperror.Is(err, apperror.KindValidation))
+
+	_, err = uploadUsecase.UploadMovieImage(1405, request.UploadMovieImage{Kind: "banner", Language: "xx", Data: testImage(t, 10, 10, utils.ImagePNG)})
+	var validationErrors utils.ValidationErrors
+	assert.ErrorAs(t, err, &validationErrors)
+	assert.Len(t, validationErrors, 2)
+
+	_, err = uploadUsecase.UploadMovieImage(1405, request.UploadMovieImage{Kind: "still", Data: testImage(t, 200, 100, utils.ImagePNG)})
+	assert.NotNil(t, err)
+	assert.Empty(t, storedFiles(t, root))
+}
+
+func Test_DeleteMovieImage_RemovesBlobs(t *testing.T) {
+	root := t.TempDir()
+	blobRepo := repository.NewLocalBlobRepository(root)
+	deleteUsecase := AppUsecase{AppRepository: appRepo, AppBlobRepository: blobRepo}
+	assert.Nil(t, blobRepo.PutBlob("movies/1406/abc/original.png", []byte("png"), utils.ImagePNG))
+	appRepo.Mock.On("DeleteMovieImage", int64(1406), int64(3), (*int64)(nil)).Return(&model.MovieImage{
+		ID:         3,
+		URL:        "/images/movies/1406/abc/original.png",
+		Thumbnails: model.StringMap{"original": "/images/movies/1406/abc/original.png"},
+	}, nil)
+	appRepo.Mock.On("ListReferencedImageURLs", []string{"/images/movies/1406/abc/original.png"}).Return([]string{}, nil)
+	appRepo.Mock.On("DeleteMovieImage", int64(1406), int64(4), (*int64)(nil)).Return((*model.MovieImage)(nil), apperror.NotFound("Movie Image Not Found"))
+
+	assert.Nil(t, deleteUsecase.DeleteMovieImage(1406, 3, request.DeleteMovieImage{}))
+	assert.Empty(t, storedFiles(t, root))
+	assert.True(t, apperror.Is(deleteUsecase.DeleteMovieImage(1406, 4, request.DeleteMovieImage{}), apperror.KindNotFound))
+}
@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"strings"
+	"time"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+	"xsis-code-test/models/request"
+	"xsis-code-test/models/response"
+	"xsis-code-test/utils"
+)
+
+func (au *AppUsecase) ListMovieImages(movieID int64) (*[]response.MovieImage, error) {
+	if _, err := au.AppRepository.GetMovie(movieID); err != nil {
+		return nil, err
+	}
+	images, err := au.AppRepository.ListMovieImages(movieID)
+	if err != nil {
+		return nil, err
+	}
+
+	movieImages := make([]response.MovieImage, 0, len(*images))
+	for _, image := range *images {
+		movieImages = append(movieImages, movieImageResponse(image))
+	}
+
+	return &movieImages, nil
+}
+
+func (au *AppUsecase) CreateMovieImage(movieID int64, req request.CreateMovieImage) (*response.MovieImage, error) {
+	req.Kind = strings.ToLower(strings.TrimSpace(req.Kind))
+	req.Language = strings.ToLower(strings.TrimSpace(req.Language))
+	if err := utils.Validate(req); err != nil {
+		return nil, err
+	}
+	if _, err := au.AppRepository.GetMovie(movieID); err != nil {
+		return nil, err
+	}
+
+	image := model.MovieImage{
+		MovieID:   movieID,
+		Kind:      req.Kind,
+		URL:       req.URL,
+		Width:     req.Width,
+		Height:    req.Height,
+		Language:  req.Language,
+		Primary:   req.Primary,
+		CreatedAt: time.Now(),
+	}
+	if err := au.AppRepository.CreateMovieImage(&image, req.Version, posterRevision(req.Actor)); err != nil {
+		return nil, err
+	}
+
+	resImage := movieImageResponse(image)
+	return &resImage, nil
+}
+
+func (au *AppUsecase) ReorderMovieImages(movieID int64, req request.ReorderMovieImages) error {
+	if len(req.ImageIDs) == 0 {
+		return apperror.Validation("image_ids Cannot Be Empty")
+	}
+	seen := make(map[int64]bool, len(req.ImageIDs))
+	for _, id := range req.ImageIDs {
+		if seen[id] {
+			return apperror.Validation("image_ids Cannot Contain Duplicates")
+		}
+		seen[id] = true
+	}
+	if _, err := au.AppRepository.GetMovie(movieID); err != nil {
+		return err
+	}
+
+	return au.AppRepository.ReorderMovieImages(movieID, req.ImageIDs)
+}
+
+func (au *AppUsecase) DeleteMovieImage(movieID, imageID int64, req request.DeleteMovieImage) error {
+	image, err := au.AppRepository.DeleteMovieImage(movieID, imageID, req.Version, posterRevision(req.Actor))
+	if err != nil {
+		return err
+	}
+	au.deleteUnreferencedImageBlobs([]model.MovieImage{*image})
+	return nil
+}
+
+func posterRevision(actor string) func(*model.Movie, *model.Movie) (*model.MovieRevision, error) {
+	return func(before, after *model.Movie) (*model.MovieRevision, error) {
+		return newMovieRevision(revisionUpdate, actor, before, after)
+	}
+}
+
+func movieImageResponse(image model.MovieImage) response.MovieImage {
+	return response.MovieImage{
+		ID:         image.ID,
+		Kind:       image.Kind,
+		URL:        image.URL,
+		Width:      image.Width,
+		Height:     image.Height,
+		Language:   image.Language,
+		Primary:    image.Primary,
+		Position:   image.Position,
+		Thumbnails: image.Thumbnails,
+	}
+}
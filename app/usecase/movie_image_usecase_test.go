@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+	"xsis-code-test/models/request"
+	"xsis-code-test/utils"
+)
+
+func Test_ListMovieImages(t *testing.T) {
+	appRepo.Mock.On("GetMovie", int64(1401)).Return(&model.Movie{ID: 1401}, nil)
+	appRepo.Mock.On("ListMovieImages", int64(1401)).Return(&[]model.MovieImage{
+		{ID: 1, MovieID: 1401, Kind: "poster", URL: "poster.jpg", Primary: true},
+		{ID: 2, MovieID: 1401, Kind: "still", URL: "still.jpg", Position: 1},
+	}, nil)
+
+	images, err := appUsecase.ListMovieImages(1401)
+	assert.Nil(t, err)
+	assert.Len(t, *images, 2)
+	assert.True(t, (*images)[0].Primary)
+	assert.Equal(t, "still", (*images)[1].Kind)
+}
+
+func Test_CreateMovieImage(t *testing.T) {
+	appRepo.Mock.On("GetMovie", int64(1402)).Return(&model.Movie{ID: 1402}, nil)
+	appRepo.Mock.On("CreateMovieImage", mock.MatchedBy(func(image *model.MovieImage) bool {
+		return image.MovieID == 1402 && image.Kind == "backdrop" && image.Language == "en" && !image.CreatedAt.IsZero()
+	}), ptr(int64(4))).Run(func(args mock.Arguments) { args.Get(0).(*model.MovieImage).ID = 9 }).Return(nil)
+
+	image, err := appUsecase.CreateMovieImage(1402, request.CreateMovieImage{Kind: " Backdrop", URL: "https://cdn.example.com/b.jpg", Width: 1920, Height: 1080, Language: "EN", Actor: "editor", Version: ptr(int64(4))})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(9), image.ID)
+
+	_, err = appUsecase.CreateMovieImage(1402, request.CreateMovieImage{Kind: "banner", URL: "b.txt", Width: -1})
+	var validationErrors utils.ValidationErrors
+	assert.ErrorAs(t, err, &validationErrors)
+	assert.Len(t, validationErrors, 3)
+}
+
+func Test_ReorderMovieImages(t *testing.T) {
+	appRepo.Mock.On("GetMovie", int64(1403)).Return(&model.Movie{ID: 1403}, nil)
+	appRepo.Mock.On("ReorderMovieImages", int64(1403), []int64{3, 1, 2}).Return(nil)
+
+	assert.Nil(t, appUsecase.ReorderMovieImages(1403, request.ReorderMovieImages{ImageIDs: []int64{3, 1, 2}}))
+
+	err := appUsecase.ReorderMovieImages(1403, request.ReorderMovieImages{ImageIDs: []int64{3, 3}})
+	assert.True(t, apperror.Is(err, apperror.KindValidation))
+	err = appUsecase.ReorderMovieImages(1403, request.ReorderMovieImages{})
+	assert.True(t, apperror.Is(err, apperror.KindValidation))
+}
+
+func Test_PosterRevision(t *testing.T) {
+	revision, err := posterRevision("")(&model.Movie{Title: "Dans 1", Image: "old.jpg"}, &model.Movie{Title: "Dans 1", Image: "new.jpg"})
+	assert.Nil(t, err)
+	assert.Equal(t, revisionUpdate, revision.Action)
+	assert.Equal(t, anonymousActor, revision.Actor)
+	assert.JSONEq(t, `{"image":{"from":"old.jpg","to":"new.jpg"}}`, revision.Diff)
+
+	revision, err = posterRevision("editor")(&model.Movie{Image: "old.jpg"}, &model.Movie{Image: "new.jpg"})
+	assert.Nil(t, err)
+	assert.Equal(t, "editor", revision.Actor)
+}
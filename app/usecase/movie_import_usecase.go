@@ -0,0 +1,433 @@
+package usecase
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+	"xsis-code-test/models/request"
+	"xsis-code-test/models/response"
+	"xsis-code-test/utils"
+)
+
+const (
+	importFormatCSV    = "csv"
+	importFormatNDJSON = "ndjson"
+
+	importPending   = "pending"
+	importRunning   = "running"
+	importCompleted = "completed"
+	importFailed    = "failed"
+
+	importBatchSize    = 100
+	importMaxLineBytes = 1 << 20
+)
+
+var importFormats = map[string]string{
+	"csv":     importFormatCSV,
+	".csv":    importFormatCSV,
+	"ndjson":  importFormatNDJSON,
+	"jsonl":   importFormatNDJSON,
+	".jsonl":  importFormatNDJSON,
+	".ndjson": importFormatNDJSON,
+}
+
+var importableMovieFields = []string{"title", "description", "rating", "image"}
+
+type importRow struct {
+	number int64
+	values map[string]string
+	raw    string
+	err    error
+}
+
+type importReader interface {
+	Next() (*importRow, error)
+}
+
+type importMovie struct {
+	row      *importRow
+	movie    model.Movie
+	revision *model.MovieRevision
+}
+
+func (au *AppUsecase) StartImport(req request.ImportMovie) (*response.ImportJob, error) {
+	format, err := importFormat(req)
+	if err != nil {
+		return nil, err
+	}
+	mapping, err := importMapping(req.Mapping)
+	if err != nil {
+		return nil, err
+	}
+	encodedMapping, err := json.Marshal(mapping)
+	if err != nil {
+		return nil, apperror.Internal("Cannot Encode Import Mapping", err)
+	}
+
+	actor := strings.TrimSpace(req.Actor)
+	if actor == "" {
+		actor = anonymousActor
+	}
+	job := model.ImportJob{
+		Status:    importPending,
+		Format:    format,
+		Filename:  req.Filename,
+		Mapping:   string(encodedMapping),
+		Actor:     actor,
+		CreatedAt: time.Now(),
+	}
+	if err := au.AppRepository.CreateImportJob(&job); err != nil {
+		return nil, err
+	}
+
+	req.Format, req.Mapping, req.Actor = format, mapping, actor
+	go au.runImport(job, req)
+
+	return importJobResponse(job), nil
+}
+
+func (au *AppUsecase) GetImportJob(id int64) (*response.ImportJob, error) {
+	job, err := au.AppRepository.GetImportJob(id)
+	if err != nil {
+		return nil, err
+	}
+	return importJobResponse(*job), nil
+}
+
+func (au *AppUsecase) ListImportJobErrors(id int64) (*[]response.ImportJobError, error) {
+	if _, err := au.AppRepository.GetImportJob(id); err != nil {
+		return nil, err
+	}
+	jobErrors, err := au.AppRepository.ListImportJobErrors(id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.ImportJobError, 0, len(*jobErrors))
+	for _, jobError := range *jobErrors {
+		result = append(result, response.ImportJobError{
+			Row:     jobError.Row,
+			Message: jobError.Message,
+			Raw:     jobError.Raw,
+		})
+	}
+	return &result, nil
+}
+
+func (au *AppUsecase) runImport(job model.ImportJob, req request.ImportMovie) {
+	defer req.File.Close()
+
+	started := time.Now()
+	job.Status = importRunning
+	job.StartedAt = &started
+	au.saveImportJob(job)
+
+	err := au.importMovies(&job, req)
+
+	finished := time.Now()
+	job.FinishedAt = &finished
+	job.Status = importCompleted
+	if err != nil {
+		job.Status = importFailed
+		job.Error = err.Error()
+	}
+	au.saveImportJob(job)
+}
+
+func (au *AppUsecase) importMovies(job *model.ImportJob, req request.ImportMovie) error {
+	var (
+		rows importReader
+		err  error
+	)
+	if req.Format == importFormatCSV {
+		rows, err = newCSVImportReader(req.File)
+	} else {
+		rows = newNDJSONImportReader(req.File)
+	}
+	if err != nil {
+		return err
+	}
+
+	pending := make([]importMovie, 0, importBatchSize)
+	var failures []model.ImportJobError
+	now := time.Now()
+	for {
+		if len(pending) == importBatchSize || len(failures) == importBatchSize {
+			au.flushImport(job, pending, failures)
+			pending, failures = pending[:0], nil
+		}
+		row, err := rows.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			au.flushImport(job, pending, failures)
+			return apperror.BadRequest(fmt.Sprintf("Cannot Read Import File: %s", err.Error()))
+		}
+		job.Processed++
+
+		if row.err != nil {
+			failures = append(failures, importFailure(job.ID, row, row.err))
+			continue
+		}
+		createMovie, err := mapImportRow(row.values, req.Mapping)
+		if err != nil {
+			failures = append(failures, importFailure(job.ID, row, err))
+			continue
+		}
+		if createMovie == nil {
+			job.Skipped++
+			continue
+		}
+		createMovie.Actor = req.Actor
+		movie, revision, err := newMovie(*createMovie, now)
+		if err != nil {
+			failures = append(failures, importFailure(job.ID, row, err))
+			continue
+		}
+
+		pending = append(pending, importMovie{row: row, movie: movie, revision: revision})
+	}
+	au.flushImport(job, pending, failures)
+	return nil
+}
+
+func (au *AppUsecase) flushImport(job *model.ImportJob, pending []importMovie, failures []model.ImportJobError) {
+	if len(pending) > 0 {
+		movies := make([]model.Movie, 0, len(pending))
+		revisions := make([]*model.MovieRevision, 0, len(pending))
+		for _, item := range pending {
+			movies = append(movies, item.movie)
+			revisions = append(revisions, item.revision)
+		}
+		if err := au.AppRepository.CreateMovies(&movies, revisions); err == nil {
+			for _, movie := range movies {
+				job.Inserted++
+				au.AppSuggestRepository.PutTitle(movie.ID, movie.Title)
+			}
+		} else {
+			for i := range pending {
+				item := &pending[i]
+				if err := au.AppRepository.CreateMovie(&item.movie, item.revision); err != nil {
+					failures = append(failures, importFailure(job.ID, item.row, err))
+					continue
+				}
+				job.Inserted++
+				au.AppSuggestRepository.PutTitle(item.movie.ID, item.movie.Title)
+			}
+		}
+	}
+
+	job.Failed += int64(len(failures))
+	if err := au.AppRepository.CreateImportJobErrors(failures); err != nil {
+		log.Println("Cannot Record Import Job Errors", err)
+	}
+	au.saveImportJob(*job)
+}
+
+func (au *AppUsecase) saveImportJob(job model.ImportJob) {
+	if err := au.AppRepository.UpdateImportJob(job); err != nil {
+		log.Println("Cannot Update Import Job", err)
+	}
+}
+
+func importFormat(req request.ImportMovie) (string, error) {
+	key := strings.ToLower(strings.TrimSpace(req.Format))
+	if key == "" {
+		key = strings.ToLower(filepath.Ext(req.Filename))
+	}
+	format, ok := importFormats[key]
+	if !ok {
+		return "", apperror.BadRequest("format Must Be csv or ndjson")
+	}
+	return format, nil
+}
+
+func importMapping(custom map[string]string) (map[string]string, error) {
+	mapping := make(map[string]string, len(importableMovieFields))
+	for _, field := range importableMovieFields {
+		mapping[field] = field
+	}
+	for field, column := range custom {
+		if _, ok := mapping[field]; !ok {
+			return nil, apperror.BadRequest(fmt.Sprintf("Cannot Map Unknown Movie Field %s", field))
+		}
+		column = strings.ToLower(strings.TrimSpace(column))
+		if column == "" {
+			return nil, apperror.BadRequest(fmt.Sprintf("Mapping For %s Cannot Be Empty", field))
+		}
+		mapping[field] = column
+	}
+	return mapping, nil
+}
+
+func mapImportRow(values map[string]string, mapping map[string]string) (*request.CreateMovie, error) {
+	title := strings.TrimSpace(values[mapping["title"]])
+	description := strings.TrimSpace(values[mapping["description"]])
+	rating := strings.TrimSpace(values[mapping["rating"]])
+	image := strings.TrimSpace(values[mapping["image"]])
+	if title == "" && description == "" && rating == "" && image == "" {
+		return nil, nil
+	}
+
+	createMovie := request.CreateMovie{Title: title, Description: description, Image: image}
+	if rating != "" {
+		parsed, err := strconv.ParseFloat(rating, 32)
+		if err != nil {
+			return nil, utils.ValidationErrors{{Field: "rating", Code: "number", Message: "Rating Must Be A Number", Value: rating}}
+		}
+		createMovie.Rating = float32(parsed)
+	}
+	return &createMovie, nil
+}
+
+func importFailure(jobID int64, row *importRow, err error) model.ImportJobError {
+	var appErr *apperror.Error
+	var validationErrors utils.ValidationErrors
+	message := err.Error()
+	if !errors.As(err, &appErr) && !errors.As(err, &validationErrors) {
+		message = "Internal Server Error"
+	}
+	return model.ImportJobError{JobID: jobID, Row: row.number, Message: message, Raw: row.raw}
+}
+
+func importJobResponse(job model.ImportJob) *response.ImportJob {
+	mapping := make(map[string]string)
+	json.Unmarshal([]byte(job.Mapping), &mapping)
+
+	result := &response.ImportJob{
+		ID:        job.ID,
+		Status:    job.Status,
+		Format:    job.Format,
+		Filename:  job.Filename,
+		Mapping:   mapping,
+		Processed: job.Processed,
+		Inserted:  job.Inserted,
+		Skipped:   job.Skipped,
+		Failed:    job.Failed,
+		Error:     job.Error,
+		CreatedAt: job.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+	if job.Failed > 0 {
+		result.ErrorReport = fmt.Sprintf("/Movie/import/%d/errors", job.ID)
+	}
+	if job.StartedAt != nil {
+		result.StartedAt = job.StartedAt.Format("2006-01-02 15:04:05")
+	}
+	if job.FinishedAt != nil {
+		result.FinishedAt = job.FinishedAt.Format("2006-01-02 15:04:05")
+	}
+	return result
+}
+
+type csvImportReader struct {
+	reader *csv.Reader
+	header []string
+	number int64
+}
+
+func newCSVImportReader(r io.Reader) (*csvImportReader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, apperror.BadRequest("Import File Is Empty")
+	}
+	if err != nil {
+		return nil, apperror.BadRequest(fmt.Sprintf("Cannot Read Import Header: %s", err.Error()))
+	}
+	for i, column := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+	}
+	return &csvImportReader{reader: reader, header: header}, nil
+}
+
+func (cr *csvImportReader) Next() (*importRow, error) {
+	record, err := cr.reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, io.EOF
+	}
+	cr.number++
+	row := &importRow{number: cr.number, raw: strings.Join(record, ",")}
+
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		row.err = apperror.BadRequest(parseErr.Error())
+		return row, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(record) != len(cr.header) {
+		row.err = apperror.BadRequest(fmt.Sprintf("Row Has %d Columns But Header Has %d", len(record), len(cr.header)))
+		return row, nil
+	}
+
+	row.values = make(map[string]string, len(record))
+	for i, value := range record {
+		row.values[cr.header[i]] = value
+	}
+	return row, nil
+}
+
+type ndjsonImportReader struct {
+	scanner *bufio.Scanner
+	number  int64
+}
+
+func newNDJSONImportReader(r io.Reader) *ndjsonImportReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), importMaxLineBytes)
+	return &ndjsonImportReader{scanner: scanner}
+}
+
+func (nr *ndjsonImportReader) Next() (*importRow, error) {
+	if !nr.scanner.Scan() {
+		if err := nr.scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+	nr.number++
+	line := bytes.TrimSpace(nr.scanner.Bytes())
+	row := &importRow{number: nr.number, raw: string(line), values: make(map[string]string)}
+	if len(line) == 0 {
+		return row, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(line))
+	decoder.UseNumber()
+	var object map[string]any
+	if err := decoder.Decode(&object); err != nil || object == nil {
+		row.err = apperror.BadRequest("Row Must Be A JSON Object")
+		return row, nil
+	}
+	for key, value := range object {
+		switch value := value.(type) {
+		case nil:
+		case string:
+			row.values[strings.ToLower(key)] = value
+		case json.Number:
+			row.values[strings.ToLower(key)] = value.String()
+		case bool:
+			row.values[strings.ToLower(key)] = strconv.FormatBool(value)
+		default:
+			row.err = apperror.BadRequest(fmt.Sprintf("%s Must Be A Scalar Value", key))
+			return row, nil
+		}
+	}
+	return row, nil
+}
@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io"
+	"strings"
+	"testing"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+	"xsis-code-test/models/request"
+)
+
+func Test_ImportMovies_CSV(t *testing.T) {
+	mapping, err := importMapping(map[string]string{"title": "Name", "description": "Synopsis", "rating": "Score", "image": "Poster"})
+	assert.Nil(t, err)
+	file := "\ufeffName,Synopsis,Score,Poster\n" +
+		"Imported One,Imported,7.5,one.jpg\n" +
+		",,,\n" +
+		"Bad Rating,Imported,high,one.jpg\n" +
+		"Short,row\n" +
+		"No Image,Imported,5,\n"
+
+	appRepo.Mock.On("UpdateImportJob", mock.MatchedBy(func(job model.ImportJob) bool { return job.ID == 901 })).Return(nil)
+	appRepo.Mock.On("CreateMovies", mock.MatchedBy(func(movies *[]model.Movie) bool {
+		return len(*movies) == 1 && (*movies)[0].Title == "Imported One"
+	}), mock.Anything).Run(func(args mock.Arguments) {
+		(*args.Get(0).(*[]model.Movie))[0].ID = 701
+	}).Return(nil)
+	appRepo.Mock.On("CreateImportJobErrors", mock.MatchedBy(func(jobErrors []model.ImportJobError) bool {
+		return len(jobErrors) == 3 && jobErrors[0].JobID == 901 && jobErrors[0].Row == 3 &&
+			jobErrors[0].Message == "Rating Must Be A Number" && jobErrors[2].Raw == "No Image,Imported,5,"
+	})).Return(nil)
+
+	job := model.ImportJob{ID: 901}
+	err = appUsecase.importMovies(&job, request.ImportMovie{Format: "csv", Mapping: mapping, File: io.NopCloser(strings.NewReader(file))})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), job.Processed)
+	assert.Equal(t, int64(1), job.Inserted)
+	assert.Equal(t, int64(1), job.Skipped)
+	assert.Equal(t, int64(3), job.Failed)
+	assert.Len(t, suggestRepo.SuggestTitle("imported one", 5), 1)
+}
+
+func Test_RunImport_NDJSON(t *testing.T) {
+	mapping, _ := importMapping(nil)
+	file := `{"title":"Imported Two","description":"Imported","rating":8,"image":"two.jpg"}` + "\n\n[1]\n" +
+		`{"title":{"nested":true}}` + "\n"
+
+	appRepo.Mock.On("UpdateImportJob", mock.MatchedBy(func(job model.ImportJob) bool { return job.ID == 902 })).Return(nil)
+	appRepo.Mock.On("CreateMovies", mock.MatchedBy(func(movies *[]model.Movie) bool {
+		return len(*movies) == 1 && (*movies)[0].Title == "Imported Two"
+	}), mock.Anything).Return(apperror.Conflict("Record Already Exists"))
+	appRepo.Mock.On("CreateMovie", mock.MatchedBy(func(movie *model.Movie) bool { return movie.Title == "Imported Two" }), mock.Anything).Return(nil)
+	appRepo.Mock.On("CreateImportJobErrors", mock.MatchedBy(func(jobErrors []model.ImportJobError) bool {
+		return len(jobErrors) == 2 && jobErrors[0].JobID == 902 && jobErrors[1].Message == "title Must Be A Scalar Value"
+	})).Return(nil)
+
+	appUsecase.runImport(model.ImportJob{ID: 902}, request.ImportMovie{Format: "ndjson", Mapping: mapping, Actor: "importer", File: io.NopCloser(strings.NewReader(file))})
+
+	var job model.ImportJob
+	for _, call := range appRepo.Mock.Calls {
+		if call.Method == "UpdateImportJob" && call.Arguments.Get(0).(model.ImportJob).ID == 902 {
+			job = call.Arguments.Get(0).(model.ImportJob)
+		}
+	}
+	assert.Equal(t, "completed", job.Status)
+	assert.NotNil(t, job.FinishedAt)
+	assert.Equal(t, int64(4), job.Processed)
+	assert.Equal(t, int64(1), job.Inserted)
+	assert.Equal(t, int64(1), job.Skipped)
+	assert.Equal(t, int64(2), job.Failed)
+}
+
+func Test_ImportMovies_FlushesFailures(t *testing.T) {
+	mapping, _ := importMapping(nil)
+	file := strings.Repeat(`{"title":"Bad","rating":"high"}`+"\n", 2*importBatchSize+50)
+
+	failed := make([]int64, 0)
+	appRepo.Mock.On("UpdateImportJob", mock.MatchedBy(func(job model.ImportJob) bool { return job.ID == 904 })).
+		Run(func(args mock.Arguments) { failed = append(failed, args.Get(0).(model.ImportJob).Failed) }).Return(nil)
+	appRepo.Mock.On("CreateImportJobErrors", mock.MatchedBy(func(jobErrors []model.ImportJobError) bool {
+		return len(jobErrors) > 0 && jobErrors[0].JobID == 904
+	})).Return(nil)
+
+	job := model.ImportJob{ID: 904}
+	err := appUsecase.importMovies(&job, request.ImportMovie{Format: "ndjson", Mapping: mapping, File: io.NopCloser(strings.NewReader(file))})
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{importBatchSize, 2 * importBatchSize, 2*importBatchSize + 50}, failed)
+}
+
+func Test_StartImport_InvalidRequest(t *testing.T) {
+	_, err := appUsecase.StartImport(request.ImportMovie{Filename: "movies.xml"})
+	assert.True(t, apperror.Is(err, apperror.KindBadRequest))
+
+	_, err = appUsecase.StartImport(request.ImportMovie{Filename: "movies.csv", Mapping: map[string]string{"budget": "cost"}})
+	assert.True(t, apperror.Is(err, apperror.KindBadRequest))
+
+	_, err = appUsecase.StartImport(request.ImportMovie{Format: "jsonl", Mapping: map[string]string{"title": " "}})
+	assert.True(t, apperror.Is(err, apperror.KindBadRequest))
+}
+
+func Test_ListImportJobErrors(t *testing.T) {
+	appRepo.Mock.On("GetImportJob", int64(903)).Return(&model.ImportJob{ID: 903, Failed: 1}, nil)
+	appRepo.Mock.On("ListImportJobErrors", int64(903)).Return(&[]model.ImportJobError{{JobID: 903, Row: 2, Message: "Title Cannot Be Empty", Raw: ",x,1,a.jpg"}}, nil)
+
+	job, err := appUsecase.GetImportJob(903)
+	assert.Nil(t, err)
+	assert.Equal(t, "/Movie/import/903/errors", job.ErrorReport)
+
+	jobErrors, err := appUsecase.ListImportJobErrors(903)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), (*jobErrors)[0].Row)
+
+	appRepo.Mock.On("GetImportJob", int64(904)).Return((*model.ImportJob)(nil), apperror.NotFound("Import Job Not Found"))
+	_, err = appUsecase.ListImportJobErrors(904)
+	assert.True(t, apperror.Is(err, apperror.KindNotFound))
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"fmt"
+	"xsis-code-test/app"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+	"xsis-code-test/models/request"
+	"xsis-code-test/models/response"
+)
+
+const (
+	mergeKeepTarget = "target"
+	mergeKeepSource = "source"
+)
+
+var mergeFields = map[string]func(target, source *model.Movie){
+	"title":                func(target, source *model.Movie) { target.Title = source.Title },
+	"description":          func(target, source *model.Movie) { target.Description = source.Description },
+	"rating":               func(target, source *model.Movie) { target.Rating = source.Rating },
+	"image":                func(target, source *model.Movie) { target.Image = source.Image },
+	"release_date":         func(target, source *model.Movie) { target.ReleaseDate = source.ReleaseDate },
+	"runtime_minutes":      func(target, source *model.Movie) { target.RuntimeMinutes = source.RuntimeMinutes },
+	"original_language":    func(target, source *model.Movie) { target.OriginalLanguage = source.OriginalLanguage },
+	"spoken_languages":     func(target, source *model.Movie) { target.SpokenLanguages = source.SpokenLanguages },
+	"production_countries": func(target, source *model.Movie) { target.ProductionCountries = source.ProductionCountries },
+	"certifications":       func(target, source *model.Movie) { target.Certifications = source.Certifications },
+}
+
+func (au *AppUsecase) MergeMovie(id int64, req request.MergeMovie) (*response.GetMovie, error) {
+	if req.SourceID <= 0 {
+		return nil, apperror.BadRequest("source_id Is Required")
+	}
+	if req.SourceID == id {
+		return nil, apperror.BadRequest("Cannot Merge A Movie Into Itself")
+	}
+	for field, keep := range req.Fields {
+		if _, ok := mergeFields[field]; !ok {
+			return nil, apperror.BadRequest(fmt.Sprintf("Unknown Merge Field %s", field))
+		}
+		if keep != mergeKeepTarget && keep != mergeKeepSource {
+			return nil, apperror.BadRequest(fmt.Sprintf("Merge Field %s Must Be target or source", field))
+		}
+	}
+
+	var merged model.Movie
+	err := au.AppRepository.Transaction(func(repo app.IAppRepository) error {
+		target, err := repo.GetMovie(id)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion(target, req.Version); err != nil {
+			return err
+		}
+		source, err := repo.GetMovie(req.SourceID)
+		if apperror.Is(err, apperror.KindNotFound) {
+			return apperror.NotFound("Source Movie Not Found")
+		}
+		if err != nil {
+			return err
+		}
+
+		before := *target
+		for field, keep := range req.Fields {
+			if keep == mergeKeepSource {
+				mergeFields[field](target, source)
+			}
+		}
+		if err := validateMovie(target); err != nil {
+			return err
+		}
+
+		revision, err := newMovieRevision(revisionMerge, req.Actor, &before, target)
+		if err != nil {
+			return err
+		}
+		if err := repo.UpdateMovie(id, *target, revision); err != nil {
+			return err
+		}
+		sourceRevision, err := newMovieRevision(revisionMerge, req.Actor, source, nil)
+		if err != nil {
+			return err
+		}
+		if err := repo.DeleteMovie(source.ID, source.Version, sourceRevision); err != nil {
+			return err
+		}
+		merged = *target
+		return repo.MarkMovieMerged(source.ID, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	au.AppSuggestRepository.PutTitle(id, merged.Title)
+	au.AppSuggestRepository.RemoveTitle(req.SourceID)
+
+	return au.GetMovie(id)
+}
+
+func (au *AppUsecase) GetMergedMovieID(id int64) (int64, error) {
+	return au.AppRepository.GetMergedMovieID(id)
+}
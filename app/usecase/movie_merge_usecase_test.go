@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+	"xsis-code-test/models/request"
+)
+
+func Test_MergeMovie(t *testing.T) {
+	appRepo.Mock.On("Transaction", mock.Anything).Return(nil)
+	target := &model.Movie{ID: 1101, Title: "Heat", Description: "A heist", Rating: 8, Image: "heat.jpg", Version: 2}
+	source := &model.Movie{ID: 1102, Title: "Heat (1995)", Description: "A group of professional bank robbers", Rating: 8.3, Image: "heat95.jpg", Version: 1}
+	appRepo.Mock.On("GetMovie", int64(1101)).Return(target, nil)
+	appRepo.Mock.On("GetMovie", int64(1102)).Return(source, nil)
+	appRepo.Mock.On("UpdateMovie", int64(1101), mock.MatchedBy(func(movie model.Movie) bool {
+		return movie.Title == "Heat" && movie.Description == "A group of professional bank robbers" && movie.Rating == 8.3
+	}), mock.MatchedBy(func(revision *model.MovieRevision) bool { return revision.Action == revisionMerge })).Return(nil)
+	appRepo.Mock.On("DeleteMovie", int64(1102), int64(1), mock.MatchedBy(func(revision *model.MovieRevision) bool { return revision.Action == revisionMerge })).Return(nil)
+	appRepo.Mock.On("MarkMovieMerged", int64(1102), int64(1101)).Return(nil)
+	appRepo.Mock.On("ListMovieGenres", []int64{1101}).Return(map[int64][]model.Genre{}, nil)
+	suggestRepo.PutTitle(1102, "Heat (1995)")
+
+	merged, err := appUsecase.MergeMovie(1101, request.MergeMovie{
+		SourceID: 1102,
+		Fields:   map[string]string{"description": "source", "rating": "source", "title": "target"},
+		Version:  ptr(int64(2)),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1101), merged.ID)
+	assert.Equal(t, "A group of professional bank robbers", merged.Description)
+	for _, suggestion := range suggestRepo.SuggestTitle("heat", 10) {
+		assert.NotEqual(t, int64(1102), suggestion.ID)
+	}
+	appRepo.Mock.AssertCalled(t, "MarkMovieMerged", int64(1102), int64(1101))
+}
+
+func Test_MergeMovie_Invalid(t *testing.T) {
+	appRepo.Mock.On("Transaction", mock.Anything).Return(nil)
+	appRepo.Mock.On("GetMovie", int64(1103)).Return(&model.Movie{ID: 1103, Title: "Alien", Description: "Space", Rating: 8, Image: "alien.jpg", Version: 2}, nil)
+	appRepo.Mock.On("GetMovie", int64(1104)).Return(&model.Movie{}, apperror.NotFound("Movie Not Found"))
+
+	testcases := []struct {
+		name string
+		id   int64
+		req  request.MergeMovie
+		kind apperror.Kind
+	}{
+		{name: "missing source", id: 1103, req: request.MergeMovie{}, kind: apperror.KindBadRequest},
+		{name: "self merge", id: 1103, req: request.MergeMovie{SourceID: 1103}, kind: apperror.KindBadRequest},
+		{name: "unknown field", id: 1103, req: request.MergeMovie{SourceID: 1104, Fields: map[string]string{"budget": "source"}}, kind: apperror.KindBadRequest},
+		{name: "invalid choice", id: 1103, req: request.MergeMovie{SourceID: 1104, Fields: map[string]string{"title": "both"}}, kind: apperror.KindBadRequest},
+		{name: "stale version", id: 1103, req: request.MergeMovie{SourceID: 1104, Version: ptr(int64(1))}, kind: apperror.KindPreconditionFailed},
+		{name: "source not found", id: 1103, req: request.MergeMovie{SourceID: 1104}, kind: apperror.KindNotFound},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := appUsecase.MergeMovie(tc.id, tc.req)
+			assert.True(t, apperror.Is(err, tc.kind), err)
+		})
+	}
+	appRepo.Mock.AssertNotCalled(t, "MarkMovieMerged", mock.Anything, int64(1103))
+}
@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/request"
+)
+
+var patchableMovieFields = map[string]bool{
+	"title":                true,
+	"description":          true,
+	"rating":               true,
+	"image":                true,
+	"release_date":         true,
+	"runtime_minutes":      true,
+	"original_language":    true,
+	"spoken_languages":     true,
+	"production_countries": true,
+	"certifications":       true,
+	"genre_ids":            true,
+}
+
+func (au *AppUsecase) PatchMovie(id int64, req request.PatchMovie) error {
+	movie, err := au.AppRepository.GetMovie(id)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(movie, req.Version); err != nil {
+		return err
+	}
+	before := *movie
+
+	current, err := json.Marshal(movieSnapshot(movie))
+	if err != nil {
+		return apperror.Internal("Cannot Patch Movie", err)
+	}
+	document := make(map[string]any)
+	if err := json.Unmarshal(current, &document); err != nil {
+		return apperror.Internal("Cannot Patch Movie", err)
+	}
+	patchesGenres := patchTouches(req.Operations, "genre_ids")
+	if patchesGenres {
+		genres, err := au.AppRepository.ListMovieGenres([]int64{id})
+		if err != nil {
+			return err
+		}
+		genreIDs := make([]any, 0, len(genres[id]))
+		for _, genre := range genres[id] {
+			genreIDs = append(genreIDs, float64(genre.ID))
+		}
+		document["genre_ids"] = genreIDs
+	}
+	if err := applyMoviePatch(document, req.Operations); err != nil {
+		return err
+	}
+
+	patched, err := json.Marshal(document)
+	if err != nil {
+		return apperror.Internal("Cannot Patch Movie", err)
+	}
+	var result request.ReplaceMovie
+	if err := json.Unmarshal(patched, &result); err != nil {
+		return apperror.Validation("Patched Movie Has Invalid Field Type")
+	}
+	if err := setMovieFields(movie, result); err != nil {
+		return err
+	}
+	if patchesGenres {
+		if movie.Genres, err = au.resolveGenres(result.GenreIDs); err != nil {
+			return err
+		}
+	}
+
+	return au.saveMovie(id, before, movie, req.Actor)
+}
+
+func applyMoviePatch(document map[string]any, operations []request.PatchOperation) error {
+	if len(operations) == 0 {
+		return apperror.BadRequest("Patch Must Contain At Least One Operation")
+	}
+
+	for _, operation := range operations {
+		field, err := patchField(operation.Path)
+		if err != nil {
+			return err
+		}
+
+		switch operation.Op {
+		case "add", "replace", "test":
+			value, err := patchValue(operation)
+			if err != nil {
+				return err
+			}
+			current, exists := document[field]
+			if operation.Op == "test" {
+				if !exists || !reflect.DeepEqual(current, value) {
+					return apperror.Conflict(fmt.Sprintf("Patch Test Failed For %s", operation.Path))
+				}
+				continue
+			}
+			if operation.Op == "replace" && !exists {
+				return apperror.Conflict(fmt.Sprintf("Cannot Replace Missing %s", operation.Path))
+			}
+			document[field] = value
+		case "remove":
+			if _, exists := document[field]; !exists {
+				return apperror.Conflict(fmt.Sprintf("Cannot Remove Missing %s", operation.Path))
+			}
+			delete(document, field)
+		case "move", "copy":
+			from, err := patchField(operation.From)
+			if err != nil {
+				return err
+			}
+			value, exists := document[from]
+			if !exists {
+				return apperror.Conflict(fmt.Sprintf("Cannot Find %s", operation.From))
+			}
+			if operation.Op == "move" {
+				delete(document, from)
+			}
+			document[field] = value
+		default:
+			return apperror.BadRequest(fmt.Sprintf("Unsupported Patch Operation %q", operation.Op))
+		}
+	}
+	return nil
+}
+
+func patchTouches(operations []request.PatchOperation, field string) bool {
+	for _, operation := range operations {
+		for _, path := range []string{operation.Path, operation.From} {
+			if name, err := patchField(path); err == nil && name == field {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func patchField(path string) (string, error) {
+	if !strings.HasPrefix(path, "/") || strings.Count(path, "/") != 1 {
+		return "", apperror.BadRequest(fmt.Sprintf("Invalid Patch Path %q", path))
+	}
+	field := strings.NewReplacer("~1", "/", "~0", "~").Replace(path[1:])
+	if !patchableMovieFields[field] {
+		return "", apperror.BadRequest(fmt.Sprintf("Cannot Patch %s", path))
+	}
+	return field, nil
+}
+
+func patchValue(operation request.PatchOperation) (any, error) {
+	if len(operation.Value) == 0 {
+		return nil, apperror.BadRequest(fmt.Sprintf("Patch Operation %s Requires A Value", operation.Op))
+	}
+	var value any
+	if err := json.Unmarshal(operation.Value, &value); err != nil {
+		return nil, apperror.BadRequest(fmt.Sprintf("Invalid Patch Value For %s", operation.Path))
+	}
+	return value, nil
+}
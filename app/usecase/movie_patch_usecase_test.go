@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+	"xsis-code-test/models/request"
+	"xsis-code-test/utils"
+)
+
+func Test_UpdateMovie_Partial(t *testing.T) {
+	appRepo.Mock.On("GetMovie", int64(101)).Return(&model.Movie{ID: 101, Title: "Dans 101", Description: "Kept", Rating: 6, Image: "kept.jpg"}, nil)
+	appRepo.Mock.On("UpdateMovie", int64(101), mock.MatchedBy(func(movie model.Movie) bool {
+		return movie.Title == "Dans 101" && movie.Description == "Kept" && movie.Rating == 0 && movie.Image == "kept.jpg"
+	}), mock.Anything).Return(nil)
+
+	err := appUsecase.UpdateMovie(101, request.UpdateMovie{Rating: ptr(float32(0))})
+	assert.Nil(t, err)
+}
+
+func Test_UpdateMovie_ValidatesMergedResult(t *testing.T) {
+	appRepo.Mock.On("GetMovie", int64(102)).Return(&model.Movie{ID: 102, Title: "Dans 102", Description: "Kept", Rating: 6, Image: "kept.jpg"}, nil)
+
+	err := appUsecase.UpdateMovie(102, request.UpdateMovie{Title: ptr(""), Image: ptr("kept.exe")})
+	var validationErrors utils.ValidationErrors
+	assert.ErrorAs(t, err, &validationErrors)
+	assert.Len(t, validationErrors, 2)
+	appRepo.Mock.AssertNotCalled(t, "UpdateMovie", int64(102), mock.Anything, mock.Anything)
+}
+
+func Test_UpdateMovie_InvalidReleaseDate(t *testing.T) {
+	releaseDate, _ := utils.ParseDate("release_date", "2010-07-16")
+	appRepo.Mock.On("GetMovie", int64(105)).Return(&model.Movie{ID: 105, Title: "Dans 105", Description: "Kept", Rating: 6, Image: "kept.jpg", ReleaseDate: releaseDate}, nil)
+
+	err := appUsecase.UpdateMovie(105, request.UpdateMovie{ReleaseDate: ptr("2010-13-45")})
+	var validationErrors utils.ValidationErrors
+	assert.ErrorAs(t, err, &validationErrors)
+	assert.Equal(t, "release_date", validationErrors[0].Field)
+
+	err = appUsecase.PatchMovie(105, request.PatchMovie{Operations: []request.PatchOperation{{Op: "replace", Path: "/release_date", Value: json.RawMessage(`"2010-13-45"`)}}})
+	assert.ErrorAs(t, err, &validationErrors)
+	appRepo.Mock.AssertNotCalled(t, "UpdateMovie", int64(105), mock.Anything, mock.Anything)
+}
+
+func Test_ReplaceMovie(t *testing.T) {
+	appRepo.Mock.On("GetMovie", int64(103)).Return(&model.Movie{ID: 103, Title: "Dans 103", Description: "Old", Rating: 6, Image: "old.jpg"}, nil)
+	appRepo.Mock.On("ListMovieGenres", []int64{103}).Return(map[int64][]model.Genre{}, nil)
+	appRepo.Mock.On("UpdateMovie", int64(103), mock.MatchedBy(func(movie model.Movie) bool {
+		return movie.Title == "Replaced" && movie.Description == "New" && movie.Rating == 8 && movie.Image == "new.jpg" &&
+			movie.Genres != nil && len(movie.Genres) == 0
+	}), mock.Anything).Return(nil)
+
+	err := appUsecase.ReplaceMovie(103, request.ReplaceMovie{Title: "Replaced", Description: "New", Rating: 8, Image: "new.jpg"})
+	assert.Nil(t, err)
+
+	err = appUsecase.ReplaceMovie(103, request.ReplaceMovie{Title: "Replaced", Rating: 8, Image: "new.jpg"})
+	var validationErrors utils.ValidationErrors
+	assert.ErrorAs(t, err, &validationErrors)
+}
+
+func Test_ReplaceMovie_GenreIDs(t *testing.T) {
+	appRepo.Mock.On("GetMovie", int64(107)).Return(&model.Movie{ID: 107, Title: "Dans 107", Description: "Old", Rating: 6, Image: "old.jpg", Genres: []model.Genre{{ID: 3, Name: "Horror"}}}, nil)
+	appRepo.Mock.On("GetGenres", []int64{1, 2}).Return(&[]model.Genre{{ID: 1, Name: "Drama"}, {ID: 2, Name: "Comedy"}}, nil)
+	appRepo.Mock.On("GetGenres", []int64{9}).Return(&[]model.Genre{}, nil)
+	appRepo.Mock.On("UpdateMovie", int64(107), mock.MatchedBy(func(movie model.Movie) bool {
+		return len(movie.Genres) == 2 && movie.Genres[0].Name == "Drama" && movie.Genres[1].Name == "Comedy"
+	}), mock.Anything).Return(nil)
+
+	err := appUsecase.ReplaceMovie(107, request.ReplaceMovie{Title: "Replaced", Description: "New", Rating: 8, Image: "new.jpg", GenreIDs: []int64{1, 2, 1}})
+	assert.Nil(t, err)
+
+	err = appUsecase.ReplaceMovie(107, request.ReplaceMovie{Title: "Replaced", Description: "New", Rating: 8, Image: "new.jpg", GenreIDs: []int64{9}})
+	assert.True(t, apperror.Is(err, apperror.KindValidation), err)
+}
+
+func Test_PatchMovie(t *testing.T) {
+	appRepo.Mock.On("GetMovie", int64(104)).Return(&model.Movie{ID: 104, Title: "Dans 104", Description: "Plot", Rating: 6.5, Image: "poster.jpg"}, nil)
+	appRepo.Mock.On("UpdateMovie", int64(104), mock.MatchedBy(func(movie model.Movie) bool {
+		return movie.Title == "Plot" && movie.Description == "Patched" && movie.Rating == 7
+	}), mock.Anything).Return(nil)
+
+	operation := func(op, path, from, value string) request.PatchOperation {
+		operation := request.PatchOperation{Op: op, Path: path, From: from}
+		if value != "" {
+			operation.Value = json.RawMessage(value)
+		}
+		return operation
+	}
+	testcases := []struct {
+		name         string
+		operations   []request.PatchOperation
+		expectedKind apperror.Kind
+		isValidation bool
+	}{
+		{
+			name: "valid",
+			operations: []request.PatchOperation{
+				operation("test", "/rating", "", `6.5`),
+				operation("copy", "/title", "/description", ""),
+				operation("replace", "/description", "", `"Patched"`),
+				operation("add", "/rating", "", `7`),
+			},
+		},
+		{
+			name:         "failed test",
+			operations:   []request.PatchOperation{operation("test", "/title", "", `"Other"`)},
+			expectedKind: apperror.KindConflict,
+		},
+		{
+			name:         "unknown path",
+			operations:   []request.PatchOperation{operation("replace", "/id", "", `5`)},
+			expectedKind: apperror.KindBadRequest,
+		},
+		{
+			name:         "nested path",
+			operations:   []request.PatchOperation{operation("replace", "/title/0", "", `"x"`)},
+			expectedKind: apperror.KindBadRequest,
+		},
+		{
+			name:         "missing value",
+			operations:   []request.PatchOperation{operation("replace", "/title", "", "")},
+			expectedKind: apperror.KindBadRequest,
+		},
+		{
+			name:         "unsupported op",
+			operations:   []request.PatchOperation{operation("increment", "/rating", "", `1`)},
+			expectedKind: apperror.KindBadRequest,
+		},
+		{
+			name:         "replace after remove",
+			operations:   []request.PatchOperation{operation("remove", "/image", "", ""), operation("replace", "/image", "", `"a.jpg"`)},
+			expectedKind: apperror.KindConflict,
+		},
+		{
+			name:         "remove required field",
+			operations:   []request.PatchOperation{operation("move", "/image", "/title", "")},
+			isValidation: true,
+		},
+		{
+			name:         "wrong type",
+			operations:   []request.PatchOperation{operation("replace", "/rating", "", `"high"`)},
+			expectedKind: apperror.KindValidation,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := appUsecase.PatchMovie(104, request.PatchMovie{Operations: tc.operations})
+			switch {
+			case tc.isValidation:
+				var validationErrors utils.ValidationErrors
+				assert.ErrorAs(t, err, &validationErrors)
+			case tc.expectedKind != apperror.KindInternal:
+				assert.True(t, apperror.Is(err, tc.expectedKind), err)
+			default:
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func Test_PatchMovie_GenreIDs(t *testing.T) {
+	appRepo.Mock.On("GetMovie", int64(106)).Return(&model.Movie{ID: 106, Title: "Dans 106", Description: "Plot", Rating: 7, Image: "poster.jpg"}, nil)
+	appRepo.Mock.On("ListMovieGenres", []int64{106}).Return(map[int64][]model.Genre{106: {{ID: 1, Name: "Drama"}}}, nil)
+	appRepo.Mock.On("GetGenres", []int64{1, 2}).Return(&[]model.Genre{{ID: 1, Name: "Drama"}, {ID: 2, Name: "Comedy"}}, nil)
+	appRepo.Mock.On("GetGenres", []int64{9}).Return(&[]model.Genre{}, nil)
+	appRepo.Mock.On("UpdateMovie", int64(106), mock.MatchedBy(func(movie model.Movie) bool {
+		return len(movie.Genres) == 2 && movie.Genres[0].Name == "Drama" && movie.Genres[1].Name == "Comedy"
+	}), mock.Anything).Return(nil)
+
+	err := appUsecase.PatchMovie(106, request.PatchMovie{Operations: []request.PatchOperation{
+		{Op: "test", Path: "/genre_ids", Value: json.RawMessage(`[1]`)},
+		{Op: "add", Path: "/genre_ids", Value: json.RawMessage(`[1, 2]`)},
+	}})
+	assert.Nil(t, err)
+	appRepo.Mock.AssertCalled(t, "UpdateMovie", int64(106), mock.Anything, mock.Anything)
+
+	err = appUsecase.PatchMovie(106, request.PatchMovie{Operations: []request.PatchOperation{
+		{Op: "replace", Path: "/genre_ids", Value: json.RawMessage(`[9]`)},
+	}})
+	assert.True(t, apperror.Is(err, apperror.KindValidation), err)
+}
@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+	"xsis-code-test/models/request"
+	"xsis-code-test/models/response"
+	"xsis-code-test/utils"
+)
+
+const (
+	defaultPageSize = 10
+	maxPageSize     = 100
+)
+
+var sortableMovieFields = map[string]bool{
+	"id":                true,
+	"title":             true,
+	"rating":            true,
+	"created_at":        true,
+	"updated_at":        true,
+	"release_date":      true,
+	"runtime_minutes":   true,
+	"original_language": true,
+}
+
+func normalizeListMovie(req *request.ListMovie) (*response.Pagination, error) {
+	for _, field := range req.Sort {
+		if !sortableMovieFields[strings.TrimPrefix(field, "-")] {
+			return nil, apperror.BadRequest(fmt.Sprintf("Cannot Sort By %s", field))
+		}
+	}
+	if req.MinRating != nil && req.MaxRating != nil && *req.MinRating > *req.MaxRating {
+		return nil, apperror.BadRequest("min_rating Cannot Be Greater Than max_rating")
+	}
+	if req.CreatedAfter != nil && req.CreatedBefore != nil && !req.CreatedAfter.Before(*req.CreatedBefore) {
+		return nil, apperror.BadRequest("created_after Must Be Before created_before")
+	}
+	if req.ReleasedAfter != nil && req.ReleasedBefore != nil && !req.ReleasedAfter.Before(*req.ReleasedBefore) {
+		return nil, apperror.BadRequest("released_after Must Be Before released_before")
+	}
+	if req.MinRuntime != nil && req.MaxRuntime != nil && *req.MinRuntime > *req.MaxRuntime {
+		return nil, apperror.BadRequest("min_runtime Cannot Be Greater Than max_runtime")
+	}
+	if req.Language != "" && !utils.IsLanguageCode(req.Language) {
+		return nil, apperror.BadRequest("language Must Be An ISO 639-1 Language Code")
+	}
+	if req.SpokenLanguage != "" && !utils.IsLanguageCode(req.SpokenLanguage) {
+		return nil, apperror.BadRequest("spoken_language Must Be An ISO 639-1 Language Code")
+	}
+	if req.Country != "" && !utils.IsCountryCode(req.Country) {
+		return nil, apperror.BadRequest("country Must Be An ISO 3166-1 Country Code")
+	}
+	if req.Certification != "" {
+		country, rating, _ := strings.Cut(req.Certification, ":")
+		if !utils.IsCertification(strings.ToUpper(country), rating) {
+			return nil, apperror.BadRequest("certification Must Be A Known Rating Like US:PG-13")
+		}
+		req.Certification = strings.ToUpper(country) + ":" + rating
+	}
+
+	if req.Cursor != "" {
+		if req.Page > 0 || req.Offset > 0 {
+			return nil, apperror.BadRequest("cursor Cannot Be Combined With page or offset")
+		}
+		if req.Limit <= 0 {
+			req.Limit = req.PageSize
+		}
+		req.Limit = clampPageSize(req.Limit)
+		req.PageSize = 0
+		return &response.Pagination{Limit: req.Limit}, nil
+	}
+
+	if req.Limit > 0 || req.Offset > 0 {
+		if req.Offset < 0 {
+			return nil, apperror.BadRequest("offset Cannot Be Negative")
+		}
+		req.Limit = clampPageSize(req.Limit)
+		req.Page, req.PageSize = 0, 0
+		return &response.Pagination{Limit: req.Limit, Offset: req.Offset}, nil
+	}
+
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	req.PageSize = clampPageSize(req.PageSize)
+	req.Limit = req.PageSize
+	req.Offset = (req.Page - 1) * req.PageSize
+	return &response.Pagination{Page: req.Page, PageSize: req.PageSize, Limit: req.Limit, Offset: req.Offset}, nil
+}
+
+func clampPageSize(size int) int {
+	if size <= 0 {
+		return defaultPageSize
+	}
+	if size > maxPageSize {
+		return maxPageSize
+	}
+	return size
+}
+
+func encodeMovieCursor(keys []string, movie model.Movie) (string, error) {
+	return utils.EncodeCursor(request.NewMovieCursor(keys, movie))
+}
+
+func decodeMovieCursor(req request.ListMovie) (*request.MovieCursor, error) {
+	var cursor request.MovieCursor
+	if err := utils.DecodeCursor(req.Cursor, &cursor); err != nil {
+		return nil, err
+	}
+	if !reflect.DeepEqual(cursor.Sort, req.SortKeys()) || len(cursor.Values) != len(cursor.Sort) {
+		return nil, apperror.BadRequest("Cursor Does Not Match Sort Order")
+	}
+
+	for i, key := range cursor.Sort {
+		value, err := movieCursorValue(strings.TrimPrefix(key, "-"), cursor.Values[i])
+		if err != nil {
+			return nil, err
+		}
+		cursor.Values[i] = value
+	}
+	return &cursor, nil
+}
+
+func movieCursorValue(key string, value any) (any, error) {
+	switch key {
+	case "title", "original_language":
+		if text, ok := value.(string); ok {
+			return text, nil
+		}
+	case "rating":
+		if rating, ok := value.(float64); ok {
+			return float32(rating), nil
+		}
+	case "runtime_minutes":
+		if runtime, ok := value.(float64); ok {
+			return int(runtime), nil
+		}
+	case "created_at", "updated_at", "release_date":
+		if raw, ok := value.(string); ok {
+			if date, err := time.Parse(time.RFC3339Nano, raw); err == nil {
+				return date, nil
+			}
+		}
+	case "id":
+		if id, ok := value.(float64); ok {
+			return int64(id), nil
+		}
+	}
+	return nil, apperror.BadRequest("Invalid Cursor")
+}
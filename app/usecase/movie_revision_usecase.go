@@ -0,0 +1,225 @@
+package usecase
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+	"xsis-code-test/models/request"
+	"xsis-code-test/models/response"
+)
+
+const (
+	revisionCreate = "create"
+	revisionUpdate = "update"
+	revisionDelete = "delete"
+	revisionRevert = "revert"
+	revisionMerge  = "merge"
+	anonymousActor = "anonymous"
+)
+
+func (au *AppUsecase) ListMovieRevision(id int64) (*[]response.MovieRevision, error) {
+	if _, err := au.AppRepository.GetMovie(id); err != nil {
+		return nil, err
+	}
+	revisions, err := au.AppRepository.ListMovieRevision(id)
+	if err != nil {
+		return nil, err
+	}
+
+	movieRevisions := make([]response.MovieRevision, 0, len(*revisions))
+	for _, revision := range *revisions {
+		movieRevisions = append(movieRevisions, movieRevisionResponse(revision))
+	}
+	return &movieRevisions, nil
+}
+
+func (au *AppUsecase) GetMovieRevision(id int64, rev int) (*response.MovieRevision, error) {
+	if _, err := au.AppRepository.GetMovie(id); err != nil {
+		return nil, err
+	}
+	revision, err := au.AppRepository.GetMovieRevision(id, rev)
+	if err != nil {
+		return nil, err
+	}
+
+	movieRevision := movieRevisionResponse(*revision)
+	return &movieRevision, nil
+}
+
+func (au *AppUsecase) RevertMovie(id int64, rev int, actor string) error {
+	target, err := au.AppRepository.GetMovieRevision(id, rev)
+	if err != nil {
+		return err
+	}
+	var snapshot model.MovieSnapshot
+	if err := json.Unmarshal([]byte(target.Snapshot), &snapshot); err != nil {
+		return apperror.Internal("Cannot Read Movie Revision", err)
+	}
+
+	movie, err := au.AppRepository.GetMovie(id)
+	if err != nil {
+		return err
+	}
+	before := *movie
+	err = setMovieFields(movie, request.ReplaceMovie{
+		Title:               snapshot.Title,
+		Description:         snapshot.Description,
+		Rating:              snapshot.Rating,
+		Image:               snapshot.Image,
+		ReleaseDate:         snapshot.ReleaseDate,
+		RuntimeMinutes:      snapshot.RuntimeMinutes,
+		OriginalLanguage:    snapshot.OriginalLanguage,
+		SpokenLanguages:     snapshot.SpokenLanguages,
+		ProductionCountries: snapshot.ProductionCountries,
+		Certifications:      snapshot.Certifications,
+	})
+	if err != nil {
+		return err
+	}
+
+	revision, err := newMovieRevision(revisionRevert, actor, &before, movie)
+	if err != nil {
+		return err
+	}
+	err = au.AppRepository.UpdateMovie(id, *movie, revision)
+	if err != nil {
+		return err
+	}
+	au.AppSuggestRepository.PutTitle(id, movie.Title)
+
+	return nil
+}
+
+func newMovieRevision(action, actor string, before, after *model.Movie) (*model.MovieRevision, error) {
+	if actor = strings.TrimSpace(actor); actor == "" {
+		actor = anonymousActor
+	}
+
+	current := after
+	if current == nil {
+		current = before
+	}
+	snapshot, err := json.Marshal(movieSnapshot(current))
+	if err != nil {
+		return nil, apperror.Internal("Cannot Record Movie Revision", err)
+	}
+
+	changes := make(map[string]model.MovieChange)
+	if after != nil {
+		changes = diffMovie(before, after)
+	}
+	diff, err := json.Marshal(changes)
+	if err != nil {
+		return nil, apperror.Internal("Cannot Record Movie Revision", err)
+	}
+
+	return &model.MovieRevision{
+		Action:    action,
+		Snapshot:  string(snapshot),
+		Diff:      string(diff),
+		Actor:     actor,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+func movieSnapshot(movie *model.Movie) model.MovieSnapshot {
+	fields := movieFields(movie)
+	return model.MovieSnapshot{
+		Title:               fields.Title,
+		Description:         fields.Description,
+		Rating:              fields.Rating,
+		Image:               fields.Image,
+		ReleaseDate:         fields.ReleaseDate,
+		RuntimeMinutes:      fields.RuntimeMinutes,
+		OriginalLanguage:    fields.OriginalLanguage,
+		SpokenLanguages:     fields.SpokenLanguages,
+		ProductionCountries: fields.ProductionCountries,
+		Certifications:      fields.Certifications,
+		GenreIDs:            movieGenreIDs(movie),
+	}
+}
+
+func movieGenreIDs(movie *model.Movie) *[]int64 {
+	if movie.Genres == nil {
+		return nil
+	}
+	ids := make([]int64, 0, len(movie.Genres))
+	for _, genre := range movie.Genres {
+		ids = append(ids, genre.ID)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return &ids
+}
+
+func diffMovie(before, after *model.Movie) map[string]model.MovieChange {
+	changes := make(map[string]model.MovieChange)
+	if before == nil {
+		changes["title"] = model.MovieChange{To: after.Title}
+		changes["description"] = model.MovieChange{To: after.Description}
+		changes["rating"] = model.MovieChange{To: after.Rating}
+		changes["image"] = model.MovieChange{To: after.Image}
+		for field, value := range metadataValues(after) {
+			changes[field] = model.MovieChange{To: value}
+		}
+		if genreIDs := movieGenreIDs(after); genreIDs != nil {
+			changes["genre_ids"] = model.MovieChange{To: *genreIDs}
+		}
+		return changes
+	}
+	if before.Title != after.Title {
+		changes["title"] = model.MovieChange{From: before.Title, To: after.Title}
+	}
+	if before.Description != after.Description {
+		changes["description"] = model.MovieChange{From: before.Description, To: after.Description}
+	}
+	if before.Rating != after.Rating {
+		changes["rating"] = model.MovieChange{From: before.Rating, To: after.Rating}
+	}
+	if before.Image != after.Image {
+		changes["image"] = model.MovieChange{From: before.Image, To: after.Image}
+	}
+	beforeValues, afterValues := metadataValues(before), metadataValues(after)
+	for field, value := range afterValues {
+		if !reflect.DeepEqual(beforeValues[field], value) {
+			changes[field] = model.MovieChange{From: beforeValues[field], To: value}
+		}
+	}
+	if afterGenreIDs := movieGenreIDs(after); afterGenreIDs != nil {
+		beforeGenreIDs := movieGenreIDs(before)
+		if beforeGenreIDs == nil || !reflect.DeepEqual(*beforeGenreIDs, *afterGenreIDs) {
+			var from any
+			if beforeGenreIDs != nil {
+				from = *beforeGenreIDs
+			}
+			changes["genre_ids"] = model.MovieChange{From: from, To: *afterGenreIDs}
+		}
+	}
+	return changes
+}
+
+func metadataValues(movie *model.Movie) map[string]any {
+	fields := movieFields(movie)
+	return map[string]any{
+		"release_date":         fields.ReleaseDate,
+		"runtime_minutes":      fields.RuntimeMinutes,
+		"original_language":    fields.OriginalLanguage,
+		"spoken_languages":     fields.SpokenLanguages,
+		"production_countries": fields.ProductionCountries,
+		"certifications":       fields.Certifications,
+	}
+}
+
+func movieRevisionResponse(revision model.MovieRevision) response.MovieRevision {
+	return response.MovieRevision{
+		Revision:  revision.Revision,
+		Action:    revision.Action,
+		Actor:     revision.Actor,
+		Snapshot:  json.RawMessage(revision.Snapshot),
+		Diff:      json.RawMessage(revision.Diff),
+		CreatedAt: revision.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
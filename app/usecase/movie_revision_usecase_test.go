@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+	"xsis-code-test/models/request"
+)
+
+func Test_UpdateMovie_RecordsRevision(t *testing.T) {
+	existing := &model.Movie{ID: 51, Title: "Old Title", Description: "Same", Rating: 5, Image: "old.jpg"}
+	appRepo.Mock.On("GetMovie", int64(51)).Return(existing, nil)
+	appRepo.Mock.On("UpdateMovie", int64(51), mock.Anything, mock.MatchedBy(func(revision *model.MovieRevision) bool {
+		var diff map[string]model.MovieChange
+		if err := json.Unmarshal([]byte(revision.Diff), &diff); err != nil {
+			return false
+		}
+		var snapshot model.MovieSnapshot
+		if err := json.Unmarshal([]byte(revision.Snapshot), &snapshot); err != nil {
+			return false
+		}
+		return revision.Action == revisionUpdate &&
+			revision.Actor == "editor" &&
+			len(diff) == 2 &&
+			diff["title"] == model.MovieChange{From: "Old Title", To: "New Title"} &&
+			diff["image"] == model.MovieChange{From: "old.jpg", To: "new.jpg"} &&
+			snapshot.Title == "New Title"
+	})).Return(nil)
+
+	err := appUsecase.UpdateMovie(51, request.UpdateMovie{Title: ptr("New Title"), Image: ptr("new.jpg"), Actor: "editor"})
+	assert.Nil(t, err)
+}
+
+func Test_DeleteMovie_DefaultsActor(t *testing.T) {
+	appRepo.Mock.On("GetMovie", int64(52)).Return(&model.Movie{ID: 52, Title: "Gone"}, nil)
+	appRepo.Mock.On("DeleteMovie", int64(52), int64(0), mock.MatchedBy(func(revision *model.MovieRevision) bool {
+		return revision.Action == revisionDelete && revision.Actor == anonymousActor && revision.Diff == "{}"
+	})).Return(nil)
+
+	err := appUsecase.DeleteMovie(52, request.DeleteMovie{Actor: " "})
+	assert.Nil(t, err)
+}
+
+func Test_ListMovieRevision(t *testing.T) {
+	createdAt, _ := time.Parse("2006-01-02 15:04:05", "2024-01-03 00:00:00")
+	revisions := []model.MovieRevision{
+		{MovieID: 53, Revision: 2, Action: revisionUpdate, Snapshot: `{"title":"B"}`, Diff: `{"title":{"from":"A","to":"B"}}`, Actor: "editor", CreatedAt: createdAt},
+		{MovieID: 53, Revision: 1, Action: revisionCreate, Snapshot: `{"title":"A"}`, Diff: `{}`, Actor: "editor", CreatedAt: createdAt},
+	}
+	appRepo.Mock.On("GetMovie", int64(53)).Return(&model.Movie{ID: 53}, nil)
+	appRepo.Mock.On("ListMovieRevision", int64(53)).Return(&revisions, nil)
+	appRepo.Mock.On("GetMovie", int64(55)).Return((*model.Movie)(nil), apperror.NotFound("Movie Not Found"))
+
+	data, err := appUsecase.ListMovieRevision(53)
+	assert.Nil(t, err)
+	assert.Len(t, *data, 2)
+	assert.Equal(t, "2024-01-03 00:00:00", (*data)[0].CreatedAt)
+	assert.JSONEq(t, `{"title":{"from":"A","to":"B"}}`, string((*data)[0].Diff))
+
+	_, err = appUsecase.ListMovieRevision(55)
+	assert.True(t, apperror.Is(err, apperror.KindNotFound))
+	appRepo.Mock.AssertNotCalled(t, "ListMovieRevision", int64(55))
+}
+
+func Test_GetMovieRevision(t *testing.T) {
+	appRepo.Mock.On("GetMovie", int64(56)).Return(&model.Movie{ID: 56}, nil)
+	appRepo.Mock.On("GetMovieRevision", int64(56), 1).Return(&model.MovieRevision{MovieID: 56, Revision: 1, Snapshot: `{"title":"A"}`, Diff: `{}`}, nil)
+	appRepo.Mock.On("GetMovie", int64(57)).Return((*model.Movie)(nil), apperror.NotFound("Movie Not Found"))
+
+	data, err := appUsecase.GetMovieRevision(56, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, data.Revision)
+
+	_, err = appUsecase.GetMovieRevision(57, 1)
+	assert.True(t, apperror.Is(err, apperror.KindNotFound))
+	appRepo.Mock.AssertNotCalled(t, "GetMovieRevision", int64(57), 1)
+}
+
+func Test_PatchMovie_GenreOnlyRevision(t *testing.T) {
+	appRepo.Mock.On("GetMovie", int64(58)).Return(&model.Movie{ID: 58, Title: "Dans 58", Description: "Plot", Rating: 7, Image: "poster.jpg"}, nil)
+	appRepo.Mock.On("ListMovieGenres", []int64{58}).Return(map[int64][]model.Genre{58: {{ID: 3, Name: "Horror"}}}, nil)
+	appRepo.Mock.On("GetGenres", []int64{4, 3}).Return(&[]model.Genre{{ID: 3, Name: "Horror"}, {ID: 4, Name: "Thriller"}}, nil)
+	appRepo.Mock.On("UpdateMovie", int64(58), mock.Anything, mock.MatchedBy(func(revision *model.MovieRevision) bool {
+		var snapshot model.MovieSnapshot
+		if err := json.Unmarshal([]byte(revision.Snapshot), &snapshot); err != nil {
+			return false
+		}
+		return revision.Diff == `{"genre_ids":{"from":[3],"to":[3,4]}}` &&
+			snapshot.GenreIDs != nil && assert.ObjectsAreEqual([]int64{3, 4}, *snapshot.GenreIDs)
+	})).Return(nil)
+
+	err := appUsecase.PatchMovie(58, request.PatchMovie{Operations: []request.PatchOperation{
+		{Op: "replace", Path: "/genre_ids", Value: json.RawMessage(`[4, 3]`)},
+	}})
+	assert.Nil(t, err)
+}
+
+func Test_RevertMovie(t *testing.T) {
+	target := &model.MovieRevision{MovieID: 54, Revision: 1, Snapshot: `{"title":"Original","description":"First","rating":7,"image":"first.jpg"}`}
+	current := &model.Movie{ID: 54, Title: "Vandalised", Description: "First", Rating: 1, Image: "first.jpg"}
+	appRepo.Mock.On("GetMovieRevision", int64(54), 1).Return(target, nil)
+	appRepo.Mock.On("GetMovie", int64(54)).Return(current, nil)
+	appRepo.Mock.On("UpdateMovie", int64(54), mock.MatchedBy(func(movie model.Movie) bool {
+		return movie.Title == "Original" && movie.Rating == 7
+	}), mock.MatchedBy(func(revision *model.MovieRevision) bool {
+		return revision.Action == revisionRevert && revision.Actor == "admin"
+	})).Return(nil)
+
+	err := appUsecase.RevertMovie(54, 1, "admin")
+	assert.Nil(t, err)
+	assert.NotEmpty(t, suggestRepo.SuggestTitle("Original", 1))
+
+	appRepo.Mock.On("GetMovieRevision", int64(54), 9).Return((*model.MovieRevision)(nil), apperror.NotFound("Movie Revision Not Found"))
+	err = appUsecase.RevertMovie(54, 9, "admin")
+	assert.True(t, apperror.Is(err, apperror.KindNotFound))
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"strings"
+	"unicode/utf8"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/request"
+	"xsis-code-test/models/response"
+)
+
+const maxSearchQueryLength = 200
+
+func (au *AppUsecase) SearchMovie(req request.SearchMovie) (*[]response.SearchMovie, *response.Pagination, error) {
+	req.Query = strings.TrimSpace(req.Query)
+	if req.Query == "" {
+		return nil, nil, apperror.BadRequest("Search Query Cannot Be Empty")
+	}
+	if utf8.RuneCountInString(req.Query) > maxSearchQueryLength {
+		return nil, nil, apperror.BadRequest("Search Query Too Long")
+	}
+
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	req.PageSize = clampPageSize(req.PageSize)
+	req.Limit = req.PageSize
+	req.Offset = (req.Page - 1) * req.PageSize
+
+	results, total, err := au.AppSearchRepository.SearchMovie(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	searchMovies := make([]response.SearchMovie, 0, len(*results))
+	for _, result := range *results {
+		searchMovies = append(searchMovies, response.SearchMovie{
+			ID:             result.ID,
+			Title:          result.Title,
+			Description:    result.Description,
+			Rating:         result.Rating,
+			Image:          result.Image,
+			CreatedAt:      result.CreatedAt.Format("2006-01-02 15:04:05"),
+			UpdatedAt:      result.UpdatedAt.Format("2006-01-02 15:04:05"),
+			Score:          result.Rank,
+			TitleHighlight: result.TitleHighlight,
+			Snippet:        result.Snippet,
+		})
+	}
+
+	pagination := &response.Pagination{
+		Total:    &total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		Limit:    req.Limit,
+		Offset:   req.Offset,
+	}
+	return &searchMovies, pagination, nil
+}
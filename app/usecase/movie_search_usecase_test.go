@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"xsis-code-test/app/repository"
+	"xsis-code-test/models/model"
+	"xsis-code-test/models/request"
+)
+
+func Test_SearchMovie(t *testing.T) {
+	searchUsecase := AppUsecase{
+		AppRepository: appRepo,
+		AppSearchRepository: repository.NewMemorySearchRepository(
+			model.Movie{ID: 1, Title: "Beranak Dalam Kubur", Description: "Horror classic"},
+			model.Movie{ID: 2, Title: "Pengabdi Setan", Description: "A family haunted after their mother dies"},
+		),
+	}
+
+	testcases := []struct {
+		name          string
+		isResultNil   bool
+		input         request.SearchMovie
+		expectedCount int
+	}{
+		{
+			name:          "valid query",
+			isResultNil:   true,
+			input:         request.SearchMovie{Query: " kubur "},
+			expectedCount: 1,
+		},
+		{
+			name:          "no result",
+			isResultNil:   true,
+			input:         request.SearchMovie{Query: "comedy"},
+			expectedCount: 0,
+		},
+		{
+			name:        "empty query",
+			isResultNil: false,
+			input:       request.SearchMovie{Query: "   "},
+		},
+		{
+			name:        "query too long",
+			isResultNil: false,
+			input:       request.SearchMovie{Query: strings.Repeat("a", 201)},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			movies, pagination, err := searchUsecase.SearchMovie(tc.input)
+			if tc.isResultNil {
+				assert.Nil(t, err)
+				assert.Len(t, *movies, tc.expectedCount)
+				assert.Equal(t, int64(tc.expectedCount), *pagination.Total)
+				assert.Equal(t, 1, pagination.Page)
+			} else {
+				assert.NotNil(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"strings"
+	"unicode/utf8"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/response"
+)
+
+const (
+	defaultSuggestLimit = 5
+	maxSuggestLimit     = 20
+	maxSuggestPrefix    = 100
+)
+
+func (au *AppUsecase) LoadSuggestion() error {
+	movies, err := au.AppRepository.ListMovieTitle()
+	if err != nil {
+		return err
+	}
+	for _, movie := range *movies {
+		au.AppSuggestRepository.PutTitle(movie.ID, movie.Title)
+	}
+	return nil
+}
+
+func (au *AppUsecase) SuggestMovie(prefix string, limit int) (*[]response.SuggestMovie, error) {
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" {
+		return nil, apperror.BadRequest("Prefix Cannot Be Empty")
+	}
+	if utf8.RuneCountInString(prefix) > maxSuggestPrefix {
+		return nil, apperror.BadRequest("Prefix Too Long")
+	}
+	if limit <= 0 {
+		limit = defaultSuggestLimit
+	}
+	if limit > maxSuggestLimit {
+		limit = maxSuggestLimit
+	}
+
+	suggestions := au.AppSuggestRepository.SuggestTitle(prefix, limit)
+	suggestMovies := make([]response.SuggestMovie, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		suggestMovies = append(suggestMovies, response.SuggestMovie{ID: suggestion.ID, Title: suggestion.Title})
+	}
+	return &suggestMovies, nil
+}
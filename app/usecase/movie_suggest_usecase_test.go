@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"xsis-code-test/app/repository"
+	"xsis-code-test/models/model"
+)
+
+func Test_SuggestMovie(t *testing.T) {
+	titleRepo := &repository.AppRepositoryMock{Mock: mock.Mock{}}
+	titleRepo.Mock.On("ListMovieTitle").Return(&[]model.Movie{
+		{ID: 1, Title: "Pengabdi Setan"},
+		{ID: 2, Title: "Pengabdi Setan 2: Communion"},
+		{ID: 3, Title: "Perempuan Tanah Jahanam"},
+	}, nil)
+	suggestUsecase := NewAppUsecase(titleRepo, repository.NewMemorySuggestRepository())
+	assert.Nil(t, suggestUsecase.LoadSuggestion())
+
+	testcases := []struct {
+		name          string
+		isResultNil   bool
+		prefix        string
+		limit         int
+		expectedCount int
+	}{
+		{
+			name:          "prefix",
+			isResultNil:   true,
+			prefix:        "peng",
+			expectedCount: 2,
+		},
+		{
+			name:          "limit",
+			isResultNil:   true,
+			prefix:        "pe",
+			limit:         1,
+			expectedCount: 1,
+		},
+		{
+			name:          "typo",
+			isResultNil:   true,
+			prefix:        "pengadbi",
+			expectedCount: 2,
+		},
+		{
+			name:        "empty prefix",
+			isResultNil: false,
+			prefix:      " ",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			movies, err := suggestUsecase.SuggestMovie(tc.prefix, tc.limit)
+			if tc.isResultNil {
+				assert.Nil(t, err)
+				assert.Len(t, *movies, tc.expectedCount)
+			} else {
+				assert.NotNil(t, err)
+			}
+		})
+	}
+}
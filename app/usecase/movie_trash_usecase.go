@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"log"
+	"time"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/request"
+	"xsis-code-test/models/response"
+)
+
+func (au *AppUsecase) ListTrash(req request.ListMovie) (*[]response.TrashMovie, *response.Pagination, error) {
+	if req.Cursor != "" || len(req.Sort) > 0 {
+		return nil, nil, apperror.BadRequest("Trash Does Not Support cursor or sort")
+	}
+	pagination, err := normalizeListMovie(&req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	movies, total, err := au.AppRepository.ListTrash(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	pagination.Total = &total
+
+	trashMovies := make([]response.TrashMovie, 0)
+	for _, movie := range *movies {
+		trashMovie := response.TrashMovie{
+			ID:          movie.ID,
+			Title:       movie.Title,
+			Description: movie.Description,
+			Rating:      movie.Rating,
+			Image:       movie.Image,
+			Version:     movie.Version,
+			CreatedAt:   movie.CreatedAt.Format("2006-01-02 15:04:05"),
+			UpdatedAt:   movie.UpdatedAt.Format("2006-01-02 15:04:05"),
+		}
+		if movie.DeletedAt != nil {
+			trashMovie.DeletedAt = movie.DeletedAt.Format("2006-01-02 15:04:05")
+		}
+		trashMovies = append(trashMovies, trashMovie)
+	}
+
+	return &trashMovies, pagination, nil
+}
+
+func (au *AppUsecase) RestoreMovie(id int64) error {
+	err := au.AppRepository.RestoreMovie(id)
+	if err != nil {
+		return err
+	}
+
+	movie, err := au.AppRepository.GetMovie(id)
+	if err != nil {
+		return err
+	}
+	au.AppSuggestRepository.PutTitle(movie.ID, movie.Title)
+
+	return nil
+}
+
+func (au *AppUsecase) PurgeMovie(id int64, req request.DeleteMovie) error {
+	images, err := au.AppRepository.PurgeMovie(id, req.Version)
+	if err != nil {
+		return err
+	}
+	au.AppSuggestRepository.RemoveTitle(id)
+	au.deleteUnreferencedImageBlobs(*images)
+
+	return nil
+}
+
+func (au *AppUsecase) PurgeExpiredTrash(retention time.Duration) (int64, error) {
+	if retention <= 0 {
+		return 0, apperror.BadRequest("Retention Must Be Positive")
+	}
+	images, purged, err := au.AppRepository.PurgeTrash(time.Now().Add(-retention))
+	if err != nil {
+		return 0, err
+	}
+	for _, id := range purged {
+		au.AppSuggestRepository.RemoveTitle(id)
+	}
+	au.deleteUnreferencedImageBlobs(*images)
+	return int64(len(purged)), nil
+}
+
+func (au *AppUsecase) RunTrashRetention(retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		purged, err := au.PurgeExpiredTrash(retention)
+		if err != nil {
+			log.Println("Cannot Purge Expired Trash", err)
+		} else if purged > 0 {
+			log.Println("Purged Expired Trash Movies", purged)
+		}
+		<-ticker.C
+	}
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"strings"
+	"testing"
+	"time"
+	"xsis-code-test/app/repository"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+	"xsis-code-test/models/request"
+	"xsis-code-test/utils"
+)
+
+func Test_ListTrash(t *testing.T) {
+	deletedAt, _ := time.Parse("2006-01-02 15:04:05", "2024-01-05 00:00:00")
+	movies := []model.Movie{{ID: 41, Title: "Trashed", DeletedAt: &deletedAt}}
+	appRepo.Mock.On("ListTrash", request.ListMovie{Page: 2, PageSize: 5, Limit: 5, Offset: 5}).Return(&movies, int64(6), nil)
+
+	data, pagination, err := appUsecase.ListTrash(request.ListMovie{Page: 2, PageSize: 5})
+	assert.Nil(t, err)
+	assert.Equal(t, "2024-01-05 00:00:00", (*data)[0].DeletedAt)
+	assert.Equal(t, int64(6), *pagination.Total)
+
+	_, _, err = appUsecase.ListTrash(request.ListMovie{Cursor: "abc"})
+	assert.True(t, apperror.Is(err, apperror.KindBadRequest))
+}
+
+func Test_RestoreMovie(t *testing.T) {
+	appRepo.Mock.On("RestoreMovie", int64(42)).Return(nil)
+	appRepo.Mock.On("GetMovie", int64(42)).Return(&model.Movie{ID: 42, Title: "Resurrected"}, nil)
+
+	err := appUsecase.RestoreMovie(42)
+	assert.Nil(t, err)
+	suggestions := suggestRepo.SuggestTitle("resur", 5)
+	assert.Len(t, suggestions, 1)
+	assert.Equal(t, int64(42), suggestions[0].ID)
+
+	appRepo.Mock.On("RestoreMovie", int64(43)).Return(apperror.NotFound("Movie Not Found In Trash"))
+	err = appUsecase.RestoreMovie(43)
+	assert.True(t, apperror.Is(err, apperror.KindNotFound))
+}
+
+func Test_PurgeMovie(t *testing.T) {
+	root := t.TempDir()
+	blobRepo := repository.NewLocalBlobRepository(root)
+	purgeUsecase := AppUsecase{AppRepository: appRepo, AppSuggestRepository: suggestRepo, AppBlobRepository: blobRepo}
+	assert.Nil(t, blobRepo.PutBlob("movies/44/abc/original.png", []byte("png"), utils.ImagePNG))
+	suggestRepo.PutTitle(44, "Obliterated")
+	appRepo.Mock.On("PurgeMovie", int64(44), (*int64)(nil)).Return(&[]model.MovieImage{
+		{ID: 1, URL: "https://cdn.example.com/poster.jpg"},
+		{ID: 2, URL: "/images/movies/44/abc/original.png", Thumbnails: model.StringMap{"original": "/images/movies/44/abc/original.png"}},
+	}, nil)
+
+	appRepo.Mock.On("ListReferencedImageURLs", []string{"/images/movies/44/abc/original.png"}).Return([]string{}, nil)
+
+	err := purgeUsecase.PurgeMovie(44, request.DeleteMovie{})
+	assert.Nil(t, err)
+	assert.Empty(t, suggestRepo.SuggestTitle("oblit", 5))
+	assert.Empty(t, storedFiles(t, root))
+}
+
+func Test_PurgeMovie_KeepsPosterOfMergedMovie(t *testing.T) {
+	root := t.TempDir()
+	blobRepo := repository.NewLocalBlobRepository(root)
+	purgeUsecase := AppUsecase{AppRepository: appRepo, AppSuggestRepository: suggestRepo, AppBlobRepository: blobRepo}
+	assert.Nil(t, blobRepo.PutBlob("movies/45/abc/original.png", []byte("png"), utils.ImagePNG))
+	assert.Nil(t, blobRepo.PutBlob("movies/45/abc/w92.png", []byte("png"), utils.ImagePNG))
+	appRepo.Mock.On("PurgeMovie", int64(45), (*int64)(nil)).Return(&[]model.MovieImage{{
+		ID:         3,
+		URL:        "/images/movies/45/abc/original.png",
+		Thumbnails: model.StringMap{"original": "/images/movies/45/abc/original.png", "w92": "/images/movies/45/abc/w92.png"},
+	}}, nil)
+	appRepo.Mock.On("ListReferencedImageURLs", []string{"/images/movies/45/abc/original.png", "/images/movies/45/abc/w92.png"}).
+		Return([]string{"/images/movies/45/abc/original.png"}, nil)
+
+	assert.Nil(t, purgeUsecase.PurgeMovie(45, request.DeleteMovie{}))
+	files := storedFiles(t, root)
+	assert.Len(t, files, 1)
+	assert.True(t, strings.HasSuffix(files[0], "original.png"))
+}
+
+func Test_PurgeExpiredTrash(t *testing.T) {
+	before := time.Now().Add(-30 * 24 * time.Hour)
+	appRepo.Mock.On("PurgeTrash", mock.MatchedBy(func(cutoff time.Time) bool {
+		return !cutoff.Before(before) && cutoff.Before(time.Now().Add(-29*24*time.Hour))
+	})).Return(&[]model.MovieImage{}, []int64{1501, 1502, 1503}, nil)
+
+	purged, err := appUsecase.PurgeExpiredTrash(30 * 24 * time.Hour)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), purged)
+
+	_, err = appUsecase.PurgeExpiredTrash(0)
+	assert.True(t, apperror.Is(err, apperror.KindBadRequest))
+}
@@ -1,66 +1,125 @@
 package usecase
 
 import (
-	"errors"
+	"strings"
 	"time"
+	"xsis-code-test/apperror"
 	"xsis-code-test/models/model"
 	"xsis-code-test/models/request"
 	"xsis-code-test/models/response"
+	"xsis-code-test/utils"
 )
 
 func (au *AppUsecase) CreateMovie(req request.CreateMovie) error {
-	if req.Title == "" {
-		return errors.New("Movie Title Cannot Be Empty")
-	}
-	if req.Description == "" {
-		return errors.New("Movie Description Cannot Be Empty")
-	}
-	if req.Image == "" {
-		return errors.New("Image Cannot Be Empty")
+	movie, revision, err := newMovie(req, time.Now())
+	if err != nil {
+		return err
 	}
-	if req.Rating < 0 || req.Rating > 10 {
-		return errors.New("Rating between 0 to 10")
+	if len(req.GenreIDs) > 0 {
+		if movie.Genres, err = au.resolveGenres(req.GenreIDs); err != nil {
+			return err
+		}
 	}
-	movie := model.Movie{
-		Title:       req.Title,
-		Description: req.Description,
-		Image:       req.Image,
-		Rating:      req.Rating,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+	if !req.Force {
+		candidates, err := au.findDuplicates(movie)
+		if err != nil {
+			return err
+		}
+		if len(candidates) > 0 {
+			return apperror.WithDetails(errDuplicateMovie, candidates)
+		}
 	}
-
-	err := au.AppRepository.CreateMovie(movie)
+	err = au.AppRepository.CreateMovie(&movie, revision)
 	if err != nil {
 		return err
 	}
+	au.AppSuggestRepository.PutTitle(movie.ID, movie.Title)
 
 	return nil
 }
 
-func (au *AppUsecase) ListMovie() (*[]response.ListMovie, error) {
-	movies, err := au.AppRepository.ListMovie()
+func (au *AppUsecase) ListMovie(req request.ListMovie) (*[]response.ListMovie, *response.Pagination, error) {
+	pagination, err := normalizeListMovie(&req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	var (
+		movies  *[]model.Movie
+		hasMore bool
+	)
+	if req.Cursor != "" {
+		cursor, err := decodeMovieCursor(req)
+		if err != nil {
+			return nil, nil, err
+		}
+		lookahead := req
+		lookahead.Limit++
+		movies, err = au.AppRepository.ListMovieAfter(lookahead, *cursor)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(*movies) > req.Limit {
+			hasMore = true
+			*movies = (*movies)[:req.Limit]
+		}
+	} else {
+		var total int64
+		movies, total, err = au.AppRepository.ListMovie(req)
+		if err != nil {
+			return nil, nil, err
+		}
+		pagination.Total = &total
+		hasMore = int64(req.Offset+len(*movies)) < total
+	}
+	if hasMore && len(*movies) > 0 {
+		pagination.NextCursor, err = encodeMovieCursor(req.SortKeys(), (*movies)[len(*movies)-1])
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
+	genres, err := au.movieGenres(*movies)
+	if err != nil {
+		return nil, nil, err
+	}
 	listMovies := make([]response.ListMovie, 0)
 	for _, movie := range *movies {
-		getCreatedAt := movie.CreatedAt.Format("2006-01-02 15:04:05")
-		getUpdatedAt := movie.UpdatedAt.Format("2006-01-02 15:04:05")
-		listMovie := response.ListMovie{
-			ID:          movie.ID,
-			Title:       movie.Title,
-			Description: movie.Description,
-			Rating:      movie.Rating,
-			Image:       movie.Image,
-			CreatedAt:   getCreatedAt,
-			UpdatedAt:   getUpdatedAt,
-		}
+		listMovie := listMovieResponse(movie)
+		listMovie.Genres = genres[movie.ID]
 		listMovies = append(listMovies, listMovie)
 	}
 
-	return &listMovies, nil
+	return &listMovies, pagination, nil
+}
+
+func listMovieResponse(movie model.Movie) response.ListMovie {
+	return response.ListMovie{
+		ID:            movie.ID,
+		Title:         movie.Title,
+		Description:   movie.Description,
+		Rating:        movie.Rating,
+		Image:         movie.Image,
+		MovieMetadata: movieMetadata(movie),
+		CreatedAt:     movie.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:     movie.UpdatedAt.Format("2006-01-02 15:04:05"),
+		Version:       movie.Version,
+		Modified:      movie.UpdatedAt,
+	}
+}
+
+func movieMetadata(movie model.Movie) response.MovieMetadata {
+	metadata := response.MovieMetadata{
+		RuntimeMinutes:      movie.RuntimeMinutes,
+		OriginalLanguage:    movie.OriginalLanguage,
+		SpokenLanguages:     []string(movie.SpokenLanguages),
+		ProductionCountries: []string(movie.ProductionCountries),
+		Certifications:      map[string]string(movie.Certifications),
+	}
+	if movie.ReleaseDate != nil {
+		metadata.ReleaseDate = movie.ReleaseDate.Format(utils.DateLayout)
+	}
+	return metadata
 }
 
 func (au *AppUsecase) GetMovie(id int64) (*response.GetMovie, error) {
@@ -68,62 +127,264 @@ func (au *AppUsecase) GetMovie(id int64) (*response.GetMovie, error) {
 	if err != nil {
 		return nil, err
 	}
+	genres, err := au.movieGenres([]model.Movie{*movie})
+	if err != nil {
+		return nil, err
+	}
 
 	getCreatedAt := movie.CreatedAt.Format("2006-01-02 15:04:05")
 	getUpdatedAt := movie.UpdatedAt.Format("2006-01-02 15:04:05")
 
 	resGetMovie := &response.GetMovie{
-		ID:          movie.ID,
-		Title:       movie.Title,
-		Description: movie.Description,
-		Rating:      movie.Rating,
-		Image:       movie.Image,
-		CreatedAt:   getCreatedAt,
-		UpdatedAt:   getUpdatedAt,
+		ID:            movie.ID,
+		Title:         movie.Title,
+		Description:   movie.Description,
+		Rating:        movie.Rating,
+		Image:         movie.Image,
+		MovieMetadata: movieMetadata(*movie),
+		Genres:        genres[movie.ID],
+		CreatedAt:     getCreatedAt,
+		UpdatedAt:     getUpdatedAt,
+		Version:       movie.Version,
+		Modified:      movie.UpdatedAt,
 	}
 
 	return resGetMovie, nil
 }
 
 func (au *AppUsecase) UpdateMovie(id int64, req request.UpdateMovie) error {
-	if req.Title == "" {
-		return errors.New("Movie Title Cannot Be Empty")
+	movie, err := au.AppRepository.GetMovie(id)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(movie, req.Version); err != nil {
+		return err
 	}
-	if req.Description == "" {
-		return errors.New("Movie Description Cannot Be Empty")
+	before := *movie
+	if err := applyUpdateMovie(movie, req); err != nil {
+		return err
 	}
-	if req.Image == "" {
-		return errors.New("Image Cannot Be Empty")
+	if req.GenreIDs != nil {
+		if movie.Genres, err = au.resolveGenres(*req.GenreIDs); err != nil {
+			return err
+		}
 	}
-	if req.Rating < 0 || req.Rating > 10 {
-		return errors.New("Rating between 0 to 10")
+
+	return au.saveMovie(id, before, movie, req.Actor)
+}
+
+func (au *AppUsecase) ReplaceMovie(id int64, req request.ReplaceMovie) error {
+	normalizeMovieFields(&req)
+	if err := utils.Validate(req); err != nil {
+		return err
 	}
 	movie, err := au.AppRepository.GetMovie(id)
 	if err != nil {
 		return err
 	}
-	movie.Title = req.Title
-	movie.Description = req.Description
-	movie.Image = req.Image
-	movie.Rating = req.Rating
-	err = au.AppRepository.UpdateMovie(id, *movie)
+	if err := checkVersion(movie, req.Version); err != nil {
+		return err
+	}
+	before := *movie
+	if err := setMovieFields(movie, req); err != nil {
+		return err
+	}
+	if movie.Genres, err = au.resolveGenres(req.GenreIDs); err != nil {
+		return err
+	}
+
+	return au.saveMovie(id, before, movie, req.Actor)
+}
+
+func (au *AppUsecase) saveMovie(id int64, before model.Movie, movie *model.Movie, actor string) error {
+	if err := validateMovie(movie); err != nil {
+		return err
+	}
+	if movie.Genres != nil && before.Genres == nil {
+		genres, err := au.AppRepository.ListMovieGenres([]int64{id})
+		if err != nil {
+			return err
+		}
+		before.Genres = append([]model.Genre{}, genres[id]...)
+	}
+
+	revision, err := newMovieRevision(revisionUpdate, actor, &before, movie)
+	if err != nil {
+		return err
+	}
+	err = au.AppRepository.UpdateMovie(id, *movie, revision)
 	if err != nil {
 		return err
 	}
+	au.AppSuggestRepository.PutTitle(id, movie.Title)
 
 	return nil
 }
 
-func (au *AppUsecase) DeleteMovie(id int64) error {
-	_, err := au.AppRepository.GetMovie(id)
+func (au *AppUsecase) DeleteMovie(id int64, req request.DeleteMovie) error {
+	movie, err := au.AppRepository.GetMovie(id)
 	if err != nil {
 		return err
 	}
+	if err := checkVersion(movie, req.Version); err != nil {
+		return err
+	}
 
-	err = au.AppRepository.DeleteMovie(id)
+	revision, err := newMovieRevision(revisionDelete, req.Actor, movie, nil)
+	if err != nil {
+		return err
+	}
+	err = au.AppRepository.DeleteMovie(id, movie.Version, revision)
 	if err != nil {
 		return err
 	}
+	au.AppSuggestRepository.RemoveTitle(id)
+
+	return nil
+}
+
+func checkVersion(movie *model.Movie, version *int64) error {
+	if version != nil && *version != movie.Version {
+		return apperror.PreconditionFailed("Movie Has Been Modified")
+	}
+	return nil
+}
+
+func applyUpdateMovie(movie *model.Movie, req request.UpdateMovie) error {
+	if req.Title != nil {
+		movie.Title = *req.Title
+	}
+	if req.Description != nil {
+		movie.Description = *req.Description
+	}
+	if req.Image != nil {
+		movie.Image = *req.Image
+	}
+	if req.Rating != nil {
+		movie.Rating = *req.Rating
+	}
+	if req.ReleaseDate != nil {
+		releaseDate, err := utils.ParseDate("release_date", *req.ReleaseDate)
+		if err != nil {
+			return err
+		}
+		movie.ReleaseDate = releaseDate
+	}
+	if req.RuntimeMinutes != nil {
+		movie.RuntimeMinutes = *req.RuntimeMinutes
+	}
+	if req.OriginalLanguage != nil {
+		movie.OriginalLanguage = *req.OriginalLanguage
+	}
+	if req.SpokenLanguages != nil {
+		movie.SpokenLanguages = model.StringList(*req.SpokenLanguages)
+	}
+	if req.ProductionCountries != nil {
+		movie.ProductionCountries = model.StringList(*req.ProductionCountries)
+	}
+	if req.Certifications != nil {
+		movie.Certifications = model.StringMap(*req.Certifications)
+	}
+	return nil
+}
+
+func validateMovie(movie *model.Movie) error {
+	fields := movieFields(movie)
+	normalizeMovieFields(&fields)
+	if err := utils.Validate(fields); err != nil {
+		return err
+	}
+	return setMovieFields(movie, fields)
+}
+
+func movieFields(movie *model.Movie) request.ReplaceMovie {
+	fields := request.ReplaceMovie{
+		Title:               movie.Title,
+		Description:         movie.Description,
+		Rating:              movie.Rating,
+		Image:               movie.Image,
+		RuntimeMinutes:      movie.RuntimeMinutes,
+		OriginalLanguage:    movie.OriginalLanguage,
+		SpokenLanguages:     []string(movie.SpokenLanguages),
+		ProductionCountries: []string(movie.ProductionCountries),
+		Certifications:      map[string]string(movie.Certifications),
+	}
+	if movie.ReleaseDate != nil {
+		fields.ReleaseDate = movie.ReleaseDate.Format(utils.DateLayout)
+	}
+	return fields
+}
 
+func setMovieFields(movie *model.Movie, fields request.ReplaceMovie) error {
+	releaseDate, err := utils.ParseDate("release_date", fields.ReleaseDate)
+	if err != nil {
+		return err
+	}
+	movie.Title = fields.Title
+	movie.Description = fields.Description
+	movie.Rating = fields.Rating
+	movie.Image = fields.Image
+	movie.ReleaseDate = releaseDate
+	movie.RuntimeMinutes = fields.RuntimeMinutes
+	movie.OriginalLanguage = fields.OriginalLanguage
+	movie.SpokenLanguages = model.StringList(fields.SpokenLanguages)
+	movie.ProductionCountries = model.StringList(fields.ProductionCountries)
+	movie.Certifications = model.StringMap(fields.Certifications)
 	return nil
 }
+
+func normalizeMovieFields(fields *request.ReplaceMovie) {
+	fields.OriginalLanguage = strings.ToLower(strings.TrimSpace(fields.OriginalLanguage))
+	fields.SpokenLanguages = utils.NormalizeCodes(fields.SpokenLanguages, false)
+	fields.ProductionCountries = utils.NormalizeCodes(fields.ProductionCountries, true)
+	if fields.Certifications != nil {
+		certifications := make(map[string]string, len(fields.Certifications))
+		for country, rating := range fields.Certifications {
+			certifications[strings.ToUpper(strings.TrimSpace(country))] = strings.TrimSpace(rating)
+		}
+		fields.Certifications = certifications
+	}
+}
+
+func newMovie(req request.CreateMovie, now time.Time) (model.Movie, *model.MovieRevision, error) {
+	fields := request.ReplaceMovie{
+		ReleaseDate:         req.ReleaseDate,
+		OriginalLanguage:    req.OriginalLanguage,
+		SpokenLanguages:     req.SpokenLanguages,
+		ProductionCountries: req.ProductionCountries,
+		Certifications:      req.Certifications,
+	}
+	normalizeMovieFields(&fields)
+	req.OriginalLanguage = fields.OriginalLanguage
+	req.SpokenLanguages = fields.SpokenLanguages
+	req.ProductionCountries = fields.ProductionCountries
+	req.Certifications = fields.Certifications
+	if err := utils.Validate(req); err != nil {
+		return model.Movie{}, nil, err
+	}
+	releaseDate, err := utils.ParseDate("release_date", req.ReleaseDate)
+	if err != nil {
+		return model.Movie{}, nil, err
+	}
+	movie := model.Movie{
+		Title:               req.Title,
+		Description:         req.Description,
+		Image:               req.Image,
+		Rating:              req.Rating,
+		ReleaseDate:         releaseDate,
+		RuntimeMinutes:      req.RuntimeMinutes,
+		OriginalLanguage:    req.OriginalLanguage,
+		SpokenLanguages:     model.StringList(req.SpokenLanguages),
+		ProductionCountries: model.StringList(req.ProductionCountries),
+		Certifications:      model.StringMap(req.Certifications),
+		CreatedAt:           now,
+		UpdatedAt:           now,
+		Version:             1,
+	}
+
+	revision, err := newMovieRevision(revisionCreate, req.Actor, nil, &movie)
+	if err != nil {
+		return model.Movie{}, nil, err
+	}
+	return movie, revision, nil
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"github.com/stretchr/testify/mock"
+	"time"
 	"xsis-code-test/models/request"
 	"xsis-code-test/models/response"
 )
@@ -18,12 +19,12 @@ func (mau *MockAppUsecase) CreateMovie(req request.CreateMovie) error {
 	return args.Get(0).(error)
 }
 
-func (mau *MockAppUsecase) ListMovie() (*[]response.ListMovie, error) {
-	args := mau.Mock.Called()
-	if args.Get(1) == nil {
-		return args.Get(0).(*[]response.ListMovie), nil
+func (mau *MockAppUsecase) ListMovie(req request.ListMovie) (*[]response.ListMovie, *response.Pagination, error) {
+	args := mau.Mock.Called(req)
+	if args.Get(2) == nil {
+		return args.Get(0).(*[]response.ListMovie), args.Get(1).(*response.Pagination), nil
 	}
-	return args.Get(0).(*[]response.ListMovie), args.Get(1).(error)
+	return args.Get(0).(*[]response.ListMovie), args.Get(1).(*response.Pagination), args.Get(2).(error)
 }
 
 func (mau *MockAppUsecase) GetMovie(id int64) (*response.GetMovie, error) {
@@ -42,10 +43,337 @@ func (mau *MockAppUsecase) UpdateMovie(id int64, req request.UpdateMovie) error
 	return args.Get(0).(error)
 }
 
-func (mau *MockAppUsecase) DeleteMovie(id int64) error {
+func (mau *MockAppUsecase) ReplaceMovie(id int64, req request.ReplaceMovie) error {
+	args := mau.Mock.Called(id, req)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mau *MockAppUsecase) PatchMovie(id int64, req request.PatchMovie) error {
+	args := mau.Mock.Called(id, req)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mau *MockAppUsecase) DeleteMovie(id int64, req request.DeleteMovie) error {
+	args := mau.Mock.Called(id, req)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mau *MockAppUsecase) SearchMovie(req request.SearchMovie) (*[]response.SearchMovie, *response.Pagination, error) {
+	args := mau.Mock.Called(req)
+	if args.Get(2) == nil {
+		return args.Get(0).(*[]response.SearchMovie), args.Get(1).(*response.Pagination), nil
+	}
+	return args.Get(0).(*[]response.SearchMovie), args.Get(1).(*response.Pagination), args.Get(2).(error)
+}
+
+func (mau *MockAppUsecase) SuggestMovie(prefix string, limit int) (*[]response.SuggestMovie, error) {
+	args := mau.Mock.Called(prefix, limit)
+	if args.Get(1) == nil {
+		return args.Get(0).(*[]response.SuggestMovie), nil
+	}
+	return args.Get(0).(*[]response.SuggestMovie), args.Get(1).(error)
+}
+
+func (mau *MockAppUsecase) ListTrash(req request.ListMovie) (*[]response.TrashMovie, *response.Pagination, error) {
+	args := mau.Mock.Called(req)
+	if args.Get(2) == nil {
+		return args.Get(0).(*[]response.TrashMovie), args.Get(1).(*response.Pagination), nil
+	}
+	return args.Get(0).(*[]response.TrashMovie), args.Get(1).(*response.Pagination), args.Get(2).(error)
+}
+
+func (mau *MockAppUsecase) RestoreMovie(id int64) error {
+	args := mau.Mock.Called(id)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mau *MockAppUsecase) PurgeMovie(id int64, req request.DeleteMovie) error {
+	args := mau.Mock.Called(id, req)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mau *MockAppUsecase) PurgeExpiredTrash(retention time.Duration) (int64, error) {
+	args := mau.Mock.Called(retention)
+	if args.Get(1) == nil {
+		return args.Get(0).(int64), nil
+	}
+	return args.Get(0).(int64), args.Get(1).(error)
+}
+
+func (mau *MockAppUsecase) ListMovieRevision(id int64) (*[]response.MovieRevision, error) {
+	args := mau.Mock.Called(id)
+	if args.Get(1) == nil {
+		return args.Get(0).(*[]response.MovieRevision), nil
+	}
+	return args.Get(0).(*[]response.MovieRevision), args.Get(1).(error)
+}
+
+func (mau *MockAppUsecase) GetMovieRevision(id int64, rev int) (*response.MovieRevision, error) {
+	args := mau.Mock.Called(id, rev)
+	if args.Get(1) == nil {
+		return args.Get(0).(*response.MovieRevision), nil
+	}
+	return args.Get(0).(*response.MovieRevision), args.Get(1).(error)
+}
+
+func (mau *MockAppUsecase) RevertMovie(id int64, rev int, actor string) error {
+	args := mau.Mock.Called(id, rev, actor)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mau *MockAppUsecase) BulkMovie(req request.BulkMovie) (*response.BulkMovie, error) {
+	args := mau.Mock.Called(req)
+	if args.Get(1) == nil {
+		return args.Get(0).(*response.BulkMovie), nil
+	}
+	return args.Get(0).(*response.BulkMovie), args.Get(1).(error)
+}
+
+func (mau *MockAppUsecase) StartImport(req request.ImportMovie) (*response.ImportJob, error) {
+	args := mau.Mock.Called(req)
+	if args.Get(1) == nil {
+		return args.Get(0).(*response.ImportJob), nil
+	}
+	return args.Get(0).(*response.ImportJob), args.Get(1).(error)
+}
+
+func (mau *MockAppUsecase) GetImportJob(id int64) (*response.ImportJob, error) {
+	args := mau.Mock.Called(id)
+	if args.Get(1) == nil {
+		return args.Get(0).(*response.ImportJob), nil
+	}
+	return args.Get(0).(*response.ImportJob), args.Get(1).(error)
+}
+
+func (mau *MockAppUsecase) ListImportJobErrors(id int64) (*[]response.ImportJobError, error) {
+	args := mau.Mock.Called(id)
+	if args.Get(1) == nil {
+		return args.Get(0).(*[]response.ImportJobError), nil
+	}
+	return args.Get(0).(*[]response.ImportJobError), args.Get(1).(error)
+}
+
+func (mau *MockAppUsecase) ExportMovie(req request.ListMovie, fn func(response.ListMovie) error) error {
+	args := mau.Mock.Called(req)
+	if movies, ok := args.Get(0).(*[]response.ListMovie); ok {
+		for _, movie := range *movies {
+			if err := fn(movie); err != nil {
+				return err
+			}
+		}
+	}
+	if args.Get(1) == nil {
+		return nil
+	}
+	return args.Get(1).(error)
+}
+
+func (mau *MockAppUsecase) ListDuplicates() (*[]response.DuplicateCluster, error) {
+	args := mau.Mock.Called()
+	if args.Get(1) == nil {
+		return args.Get(0).(*[]response.DuplicateCluster), nil
+	}
+	return args.Get(0).(*[]response.DuplicateCluster), args.Get(1).(error)
+}
+
+func (mau *MockAppUsecase) MergeMovie(id int64, req request.MergeMovie) (*response.GetMovie, error) {
+	args := mau.Mock.Called(id, req)
+	if args.Get(1) == nil {
+		return args.Get(0).(*response.GetMovie), nil
+	}
+	return args.Get(0).(*response.GetMovie), args.Get(1).(error)
+}
+
+func (mau *MockAppUsecase) GetMergedMovieID(id int64) (int64, error) {
+	args := mau.Mock.Called(id)
+	if args.Get(1) == nil {
+		return args.Get(0).(int64), nil
+	}
+	return args.Get(0).(int64), args.Get(1).(error)
+}
+
+func (mau *MockAppUsecase) CreateGenre(req request.CreateGenre) (*response.Genre, error) {
+	args := mau.Mock.Called(req)
+	if args.Get(1) == nil {
+		return args.Get(0).(*response.Genre), nil
+	}
+	return args.Get(0).(*response.Genre), args.Get(1).(error)
+}
+
+func (mau *MockAppUsecase) ListGenre() (*[]response.Genre, error) {
+	args := mau.Mock.Called()
+	if args.Get(1) == nil {
+		return args.Get(0).(*[]response.Genre), nil
+	}
+	return args.Get(0).(*[]response.Genre), args.Get(1).(error)
+}
+
+func (mau *MockAppUsecase) GetGenre(id int64) (*response.Genre, error) {
+	args := mau.Mock.Called(id)
+	if args.Get(1) == nil {
+		return args.Get(0).(*response.Genre), nil
+	}
+	return args.Get(0).(*response.Genre), args.Get(1).(error)
+}
+
+func (mau *MockAppUsecase) UpdateGenre(id int64, req request.UpdateGenre) error {
+	args := mau.Mock.Called(id, req)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mau *MockAppUsecase) DeleteGenre(id int64) error {
+	args := mau.Mock.Called(id)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mau *MockAppUsecase) ListGenreMovie(id int64, req request.ListMovie) (*[]response.ListMovie, *response.Pagination, error) {
+	args := mau.Mock.Called(id, req)
+	if args.Get(2) == nil {
+		return args.Get(0).(*[]response.ListMovie), args.Get(1).(*response.Pagination), nil
+	}
+	return args.Get(0).(*[]response.ListMovie), args.Get(1).(*response.Pagination), args.Get(2).(error)
+}
+
+func (mau *MockAppUsecase) CreatePerson(req request.CreatePerson) (*response.Person, error) {
+	args := mau.Mock.Called(req)
+	if args.Get(1) == nil {
+		return args.Get(0).(*response.Person), nil
+	}
+	return args.Get(0).(*response.Person), args.Get(1).(error)
+}
+
+func (mau *MockAppUsecase) ListPerson(req request.ListPerson) (*[]response.Person, *response.Pagination, error) {
+	args := mau.Mock.Called(req)
+	if args.Get(2) == nil {
+		return args.Get(0).(*[]response.Person), args.Get(1).(*response.Pagination), nil
+	}
+	return args.Get(0).(*[]response.Person), args.Get(1).(*response.Pagination), args.Get(2).(error)
+}
+
+func (mau *MockAppUsecase) GetPerson(id int64) (*response.Person, error) {
+	args := mau.Mock.Called(id)
+	if args.Get(1) == nil {
+		return args.Get(0).(*response.Person), nil
+	}
+	return args.Get(0).(*response.Person), args.Get(1).(error)
+}
+
+func (mau *MockAppUsecase) UpdatePerson(id int64, req request.UpdatePerson) error {
+	args := mau.Mock.Called(id, req)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mau *MockAppUsecase) DeletePerson(id int64) error {
+	args := mau.Mock.Called(id)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mau *MockAppUsecase) ListPersonFilmography(id int64) (*[]response.PersonCredit, error) {
+	args := mau.Mock.Called(id)
+	if args.Get(1) == nil {
+		return args.Get(0).(*[]response.PersonCredit), nil
+	}
+	return args.Get(0).(*[]response.PersonCredit), args.Get(1).(error)
+}
+
+func (mau *MockAppUsecase) ListMovieCredits(id int64) (*[]response.MovieCredit, error) {
 	args := mau.Mock.Called(id)
+	if args.Get(1) == nil {
+		return args.Get(0).(*[]response.MovieCredit), nil
+	}
+	return args.Get(0).(*[]response.MovieCredit), args.Get(1).(error)
+}
+
+func (mau *MockAppUsecase) CreateCredit(id int64, req request.CreateCredit) (*response.MovieCredit, error) {
+	args := mau.Mock.Called(id, req)
+	if args.Get(1) == nil {
+		return args.Get(0).(*response.MovieCredit), nil
+	}
+	return args.Get(0).(*response.MovieCredit), args.Get(1).(error)
+}
+
+func (mau *MockAppUsecase) DeleteCredit(movieID, creditID int64) error {
+	args := mau.Mock.Called(movieID, creditID)
 	if args.Get(0) == nil {
 		return nil
 	}
 	return args.Get(0).(error)
 }
+
+func (mau *MockAppUsecase) ListMovieImages(id int64) (*[]response.MovieImage, error) {
+	args := mau.Mock.Called(id)
+	if args.Get(1) == nil {
+		return args.Get(0).(*[]response.MovieImage), nil
+	}
+	return args.Get(0).(*[]response.MovieImage), args.Get(1).(error)
+}
+
+func (mau *MockAppUsecase) CreateMovieImage(id int64, req request.CreateMovieImage) (*response.MovieImage, error) {
+	args := mau.Mock.Called(id, req)
+	if args.Get(1) == nil {
+		return args.Get(0).(*response.MovieImage), nil
+	}
+	return args.Get(0).(*response.MovieImage), args.Get(1).(error)
+}
+
+func (mau *MockAppUsecase) ReorderMovieImages(id int64, req request.ReorderMovieImages) error {
+	args := mau.Mock.Called(id, req)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mau *MockAppUsecase) DeleteMovieImage(movieID, imageID int64, req request.DeleteMovieImage) error {
+	args := mau.Mock.Called(movieID, imageID, req)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mau *MockAppUsecase) UploadMovieImage(id int64, req request.UploadMovieImage) (*response.MovieImage, error) {
+	args := mau.Mock.Called(id, req)
+	if args.Get(1) == nil {
+		return args.Get(0).(*response.MovieImage), nil
+	}
+	return args.Get(0).(*response.MovieImage), args.Get(1).(error)
+}
+
+func (mau *MockAppUsecase) GetImage(key string) (*response.ImageFile, error) {
+	args := mau.Mock.Called(key)
+	if args.Get(1) == nil {
+		return args.Get(0).(*response.ImageFile), nil
+	}
+	return args.Get(0).(*response.ImageFile), args.Get(1).(error)
+}
@@ -1,17 +1,22 @@
 package usecase
 
 import (
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"testing"
 	"time"
 	"xsis-code-test/app/repository"
+	"xsis-code-test/apperror"
 	"xsis-code-test/models/model"
 	"xsis-code-test/models/request"
+	"xsis-code-test/models/response"
+	"xsis-code-test/utils"
 )
 
 var appRepo = &repository.AppRepositoryMock{Mock: mock.Mock{}}
-var appUsecase = AppUsecase{AppRepository: appRepo}
+var suggestRepo = repository.NewMemorySuggestRepository()
+var appUsecase = AppUsecase{AppRepository: appRepo, AppSuggestRepository: suggestRepo}
 
 func Test_CreateMovie(t *testing.T) {
 	testcases := []struct {
@@ -80,17 +85,22 @@ func Test_CreateMovie(t *testing.T) {
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
 			if tc.isBasicValidationError == false {
-				movie := model.Movie{
-					Title:       tc.input.Title,
-					Description: tc.input.Description,
-					Rating:      tc.input.Rating,
-					Image:       tc.input.Image,
-					CreatedAt:   time.Now(),
-					UpdatedAt:   time.Now(),
-				}
-				appRepo.Mock.On("CreateMovie", movie).Return(nil)
+				input := tc.input
+				movie := mock.MatchedBy(func(movie *model.Movie) bool {
+					return movie.Title == input.Title &&
+						movie.Description == input.Description &&
+						movie.Rating == input.Rating &&
+						movie.Image == input.Image &&
+						!movie.CreatedAt.IsZero() &&
+						!movie.UpdatedAt.IsZero()
+				})
+				appRepo.Mock.On("ListDuplicateCandidates", input.Title, mock.Anything).Return(&[]model.Movie{}, nil)
+				appRepo.Mock.On("CreateMovie", movie, mock.Anything).Return(nil)
 			}
 			err := appUsecase.CreateMovie(tc.input)
+			if tc.isBasicValidationError == false {
+				assert.NotEmpty(t, suggestRepo.SuggestTitle(tc.input.Title, 1))
+			}
 			if tc.isResultNil {
 				assert.Nil(t, err)
 			} else {
@@ -100,6 +110,64 @@ func Test_CreateMovie(t *testing.T) {
 	}
 }
 
+func Test_CreateMovie_CollectsValidationErrors(t *testing.T) {
+	err := appUsecase.CreateMovie(request.CreateMovie{Rating: 10.55, Image: "poster.pdf"})
+
+	var validationErrors utils.ValidationErrors
+	assert.True(t, errors.As(err, &validationErrors))
+	fields := make([]string, 0)
+	for _, fieldError := range validationErrors {
+		fields = append(fields, fieldError.Field)
+	}
+	assert.Equal(t, []string{"title", "description", "rating", "image"}, fields)
+}
+
+func Test_CreateMovie_Metadata(t *testing.T) {
+	appRepo.Mock.On("ListDuplicateCandidates", "Amelie", mock.Anything).Return(&[]model.Movie{}, nil)
+	appRepo.Mock.On("CreateMovie", mock.MatchedBy(func(movie *model.Movie) bool {
+		return movie.Title == "Amelie" &&
+			movie.ReleaseDate != nil && movie.ReleaseDate.Format(utils.DateLayout) == "2001-04-25" &&
+			movie.RuntimeMinutes == 122 &&
+			movie.OriginalLanguage == "fr" &&
+			assert.ObjectsAreEqual(model.StringList{"fr", "en"}, movie.SpokenLanguages) &&
+			assert.ObjectsAreEqual(model.StringList{"FR", "DE"}, movie.ProductionCountries) &&
+			assert.ObjectsAreEqual(model.StringMap{"US": "R", "GB": "15"}, movie.Certifications)
+	}), mock.Anything).Return(nil)
+
+	err := appUsecase.CreateMovie(request.CreateMovie{
+		Title:               "Amelie",
+		Description:         "Paris",
+		Rating:              8,
+		Image:               "amelie.jpg",
+		ReleaseDate:         "2001-04-25",
+		RuntimeMinutes:      122,
+		OriginalLanguage:    "FR",
+		SpokenLanguages:     []string{"fr", " EN", "fr"},
+		ProductionCountries: []string{"fr", "de"},
+		Certifications:      map[string]string{"us": "R", "GB": "15"},
+	})
+	assert.Nil(t, err)
+
+	err = appUsecase.CreateMovie(request.CreateMovie{
+		Title:               "Amelie",
+		Description:         "Paris",
+		Image:               "amelie.jpg",
+		ReleaseDate:         "25/04/2001",
+		RuntimeMinutes:      -1,
+		OriginalLanguage:    "french",
+		SpokenLanguages:     []string{"xx"},
+		ProductionCountries: []string{"FRA"},
+		Certifications:      map[string]string{"US": "15"},
+	})
+	var validationErrors utils.ValidationErrors
+	assert.True(t, errors.As(err, &validationErrors))
+	fields := make([]string, 0)
+	for _, fieldError := range validationErrors {
+		fields = append(fields, fieldError.Field)
+	}
+	assert.Equal(t, []string{"release_date", "runtime_minutes", "original_language", "spoken_languages", "production_countries", "certifications"}, fields)
+}
+
 func Test_UpdateMovie(t *testing.T) {
 	createDateTime, _ := time.Parse("2006-01-02 15:04:05", "2024-01-03 00:00:00")
 	testcases := []struct {
@@ -114,10 +182,10 @@ func Test_UpdateMovie(t *testing.T) {
 			name:        "valid data",
 			isResultNil: true,
 			input: request.UpdateMovie{
-				Title:       "Dans 4",
-				Description: "Dans 4",
-				Rating:      4,
-				Image:       "fafa2.jpg",
+				Title:       ptr("Dans 4"),
+				Description: ptr("Dans 4"),
+				Rating:      ptr(float32(4)),
+				Image:       ptr("fafa2.jpg"),
 			},
 			existingMovieData: &model.Movie{
 				ID:          1,
@@ -134,10 +202,10 @@ func Test_UpdateMovie(t *testing.T) {
 			name:        "title empty",
 			isResultNil: false,
 			input: request.UpdateMovie{
-				Title:       "",
-				Description: "Dans 2",
-				Rating:      4,
-				Image:       "fafa2.jpg",
+				Title:       ptr(""),
+				Description: ptr("Dans 2"),
+				Rating:      ptr(float32(4)),
+				Image:       ptr("fafa2.jpg"),
 			},
 			existingMovieData: &model.Movie{
 				ID:          1,
@@ -155,10 +223,10 @@ func Test_UpdateMovie(t *testing.T) {
 			name:        "description empty",
 			isResultNil: false,
 			input: request.UpdateMovie{
-				Title:       "Dans 2",
-				Description: "",
-				Rating:      4,
-				Image:       "fafa2.jpg",
+				Title:       ptr("Dans 2"),
+				Description: ptr(""),
+				Rating:      ptr(float32(4)),
+				Image:       ptr("fafa2.jpg"),
 			},
 			existingMovieData: &model.Movie{
 				ID:          1,
@@ -176,10 +244,10 @@ func Test_UpdateMovie(t *testing.T) {
 			name:        "rating not valid",
 			isResultNil: false,
 			input: request.UpdateMovie{
-				Title:       "Dans 2",
-				Description: "Dans 2",
-				Rating:      11,
-				Image:       "fafa2.jpg",
+				Title:       ptr("Dans 2"),
+				Description: ptr("Dans 2"),
+				Rating:      ptr(float32(11)),
+				Image:       ptr("fafa2.jpg"),
 			},
 			existingMovieData: &model.Movie{
 				ID:          1,
@@ -197,10 +265,10 @@ func Test_UpdateMovie(t *testing.T) {
 			name:        "image empty",
 			isResultNil: false,
 			input: request.UpdateMovie{
-				Title:       "Dans 2",
-				Description: "Dans 2",
-				Rating:      4,
-				Image:       "",
+				Title:       ptr("Dans 2"),
+				Description: ptr("Dans 2"),
+				Rating:      ptr(float32(4)),
+				Image:       ptr(""),
 			},
 			existingMovieData: &model.Movie{
 				ID:          1,
@@ -221,15 +289,15 @@ func Test_UpdateMovie(t *testing.T) {
 			if tc.isBasicValidationError == false {
 				movie := model.Movie{
 					ID:          tc.existingMovieData.ID,
-					Title:       tc.input.Title,
-					Description: tc.input.Description,
-					Rating:      tc.input.Rating,
-					Image:       tc.input.Image,
+					Title:       *tc.input.Title,
+					Description: *tc.input.Description,
+					Rating:      *tc.input.Rating,
+					Image:       *tc.input.Image,
 					CreatedAt:   tc.existingMovieData.CreatedAt,
 					UpdatedAt:   tc.existingMovieData.UpdatedAt,
 				}
 				appRepo.Mock.On("GetMovie", tc.id).Return(tc.existingMovieData, nil)
-				appRepo.Mock.On("UpdateMovie", tc.id, movie).Return(nil)
+				appRepo.Mock.On("UpdateMovie", tc.id, movie, mock.Anything).Return(nil)
 			}
 			err := appUsecase.UpdateMovie(tc.id, tc.input)
 			if tc.isResultNil {
@@ -268,8 +336,8 @@ func Test_DeleteMovie(t *testing.T) {
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
 			appRepo.Mock.On("GetMovie", tc.id).Return(tc.existingMovieData, nil)
-			appRepo.Mock.On("DeleteMovie", tc.id).Return(nil)
-			err := appUsecase.DeleteMovie(tc.id)
+			appRepo.Mock.On("DeleteMovie", tc.id, mock.Anything, mock.Anything).Return(nil)
+			err := appUsecase.DeleteMovie(tc.id, request.DeleteMovie{Actor: "editor"})
 			if tc.isResultNil {
 				assert.Nil(t, err)
 			} else {
@@ -306,9 +374,11 @@ func Test_GetMovie(t *testing.T) {
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
 			appRepo.Mock.On("GetMovie", tc.id).Return(tc.existingMovieData, nil)
-			_, err := appUsecase.GetMovie(tc.id)
+			appRepo.Mock.On("ListMovieGenres", []int64{tc.id}).Return(map[int64][]model.Genre{tc.id: {{ID: 3, Name: "Drama"}}}, nil)
+			movie, err := appUsecase.GetMovie(tc.id)
 			if tc.isResultNil {
 				assert.Nil(t, err)
+				assert.Equal(t, []response.Genre{{ID: 3, Name: "Drama"}}, movie.Genres)
 			} else {
 				assert.NotNil(t, err)
 			}
@@ -318,14 +388,22 @@ func Test_GetMovie(t *testing.T) {
 
 func Test_ListMovie(t *testing.T) {
 	createDateTime, _ := time.Parse("2006-01-02 15:04:05", "2024-01-03 00:00:00")
+	minRating := float32(8)
+	maxRating := float32(2)
 	testcases := []struct {
 		name              string
 		isResultNil       bool
+		input             request.ListMovie
+		expectedRequest   request.ListMovie
+		expectedTotal     int64
 		existingMovieData *[]model.Movie
 	}{
 		{
-			name:        "valid data",
-			isResultNil: true,
+			name:            "valid data",
+			isResultNil:     true,
+			input:           request.ListMovie{},
+			expectedRequest: request.ListMovie{Page: 1, PageSize: 10, Limit: 10},
+			expectedTotal:   2,
 			existingMovieData: &[]model.Movie{
 				{
 					ID:          1,
@@ -347,17 +425,148 @@ func Test_ListMovie(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:              "page size capped",
+			isResultNil:       true,
+			input:             request.ListMovie{Page: 3, PageSize: 500, Sort: []string{"-rating"}},
+			expectedRequest:   request.ListMovie{Page: 3, PageSize: 100, Limit: 100, Offset: 200, Sort: []string{"-rating"}},
+			expectedTotal:     0,
+			existingMovieData: &[]model.Movie{},
+		},
+		{
+			name:              "limit offset",
+			isResultNil:       true,
+			input:             request.ListMovie{Offset: 5},
+			expectedRequest:   request.ListMovie{Limit: 10, Offset: 5},
+			expectedTotal:     0,
+			existingMovieData: &[]model.Movie{},
+		},
+		{
+			name:        "invalid sort field",
+			isResultNil: false,
+			input:       request.ListMovie{Sort: []string{"-description"}},
+		},
+		{
+			name:        "invalid rating range",
+			isResultNil: false,
+			input:       request.ListMovie{MinRating: &minRating, MaxRating: &maxRating},
+		},
+		{
+			name:        "invalid runtime range",
+			isResultNil: false,
+			input:       request.ListMovie{MinRuntime: ptr(120), MaxRuntime: ptr(90)},
+		},
+		{
+			name:        "unknown language",
+			isResultNil: false,
+			input:       request.ListMovie{Language: "xx"},
+		},
+		{
+			name:        "unknown certification",
+			isResultNil: false,
+			input:       request.ListMovie{Certification: "US:15"},
+		},
 	}
 
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
-			appRepo.Mock.On("ListMovie").Return(tc.existingMovieData, nil)
-			_, err := appUsecase.ListMovie()
+			if tc.isResultNil {
+				appRepo.Mock.On("ListMovie", tc.expectedRequest).Return(tc.existingMovieData, tc.expectedTotal, nil)
+			}
+			appRepo.Mock.On("ListMovieGenres", []int64{1, 2}).Return(map[int64][]model.Genre{}, nil)
+			movies, pagination, err := appUsecase.ListMovie(tc.input)
 			if tc.isResultNil {
 				assert.Nil(t, err)
+				assert.Len(t, *movies, len(*tc.existingMovieData))
+				assert.Equal(t, tc.expectedTotal, *pagination.Total)
 			} else {
 				assert.NotNil(t, err)
 			}
 		})
 	}
 }
+
+func Test_ListMovieCursor(t *testing.T) {
+	createDateTime, _ := time.Parse("2006-01-02 15:04:05", "2024-01-04 00:00:00")
+	firstPage := &[]model.Movie{
+		{ID: 11, Title: "Dans 11", Description: "Dans 11", Rating: 9.5, Image: "a.jpg", CreatedAt: createDateTime, UpdatedAt: createDateTime},
+		{ID: 12, Title: "Dans 12", Description: "Dans 12", Rating: 8.7, Image: "b.jpg", CreatedAt: createDateTime, UpdatedAt: createDateTime},
+	}
+	appRepo.Mock.On("ListMovie", request.ListMovie{Limit: 2, Sort: []string{"-rating"}}).Return(firstPage, int64(5), nil)
+	appRepo.Mock.On("ListMovieGenres", []int64{11, 12}).Return(map[int64][]model.Genre{}, nil)
+	appRepo.Mock.On("ListMovieGenres", []int64{13, 14}).Return(map[int64][]model.Genre{}, nil)
+
+	_, pagination, err := appUsecase.ListMovie(request.ListMovie{Limit: 2, Sort: []string{"-rating"}})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, pagination.NextCursor)
+
+	secondPage := &[]model.Movie{
+		{ID: 13, Title: "Dans 13", Description: "Dans 13", Rating: 8.7, Image: "c.jpg", CreatedAt: createDateTime, UpdatedAt: createDateTime},
+		{ID: 14, Title: "Dans 14", Description: "Dans 14", Rating: 7, Image: "d.jpg", CreatedAt: createDateTime, UpdatedAt: createDateTime},
+		{ID: 15, Title: "Dans 15", Description: "Dans 15", Rating: 6, Image: "e.jpg", CreatedAt: createDateTime, UpdatedAt: createDateTime},
+	}
+	expectedCursor := request.MovieCursor{Sort: []string{"-rating", "id"}, Values: []any{float32(8.7), int64(12)}}
+	expectedRequest := request.ListMovie{Limit: 3, Sort: []string{"-rating"}, Cursor: pagination.NextCursor}
+	appRepo.Mock.On("ListMovieAfter", expectedRequest, expectedCursor).Return(secondPage, nil)
+
+	movies, nextPagination, err := appUsecase.ListMovie(request.ListMovie{Limit: 2, Sort: []string{"-rating"}, Cursor: pagination.NextCursor})
+	assert.Nil(t, err)
+	assert.Len(t, *movies, 2)
+	assert.Nil(t, nextPagination.Total)
+	assert.NotEmpty(t, nextPagination.NextCursor)
+
+	_, _, err = appUsecase.ListMovie(request.ListMovie{Limit: 2, Sort: []string{"title"}, Cursor: pagination.NextCursor})
+	assert.NotNil(t, err)
+
+	_, _, err = appUsecase.ListMovie(request.ListMovie{Limit: 2, Sort: []string{"-rating"}, Cursor: pagination.NextCursor + "x"})
+	assert.NotNil(t, err)
+
+	_, _, err = appUsecase.ListMovie(request.ListMovie{Page: 2, Sort: []string{"-rating"}, Cursor: pagination.NextCursor})
+	assert.NotNil(t, err)
+}
+
+func Test_MovieNotFound(t *testing.T) {
+	notFound := apperror.NotFound("Movie Not Found")
+	appRepo.Mock.On("GetMovie", int64(404)).Return((*model.Movie)(nil), notFound)
+
+	_, err := appUsecase.GetMovie(404)
+	assert.True(t, apperror.Is(err, apperror.KindNotFound))
+
+	err = appUsecase.UpdateMovie(404, request.UpdateMovie{Title: ptr("Dans 1"), Description: ptr("Dans 1"), Rating: ptr(float32(4)), Image: ptr("fafa.jpg")})
+	assert.True(t, apperror.Is(err, apperror.KindNotFound))
+
+	err = appUsecase.DeleteMovie(404, request.DeleteMovie{})
+	assert.True(t, apperror.Is(err, apperror.KindNotFound))
+
+	appRepo.Mock.AssertNotCalled(t, "UpdateMovie", int64(404), mock.Anything, mock.Anything)
+	appRepo.Mock.AssertNotCalled(t, "DeleteMovie", int64(404), mock.Anything, mock.Anything)
+}
+
+func Test_VersionMismatch(t *testing.T) {
+	stale := int64(1)
+	appRepo.Mock.On("GetMovie", int64(81)).Return(&model.Movie{ID: 81, Title: "Dans 81", Version: 2}, nil)
+
+	err := appUsecase.UpdateMovie(81, request.UpdateMovie{Title: ptr("Dans 1"), Description: ptr("Dans 1"), Rating: ptr(float32(4)), Image: ptr("fafa.jpg"), Version: &stale})
+	assert.True(t, apperror.Is(err, apperror.KindPreconditionFailed))
+
+	err = appUsecase.DeleteMovie(81, request.DeleteMovie{Version: &stale})
+	assert.True(t, apperror.Is(err, apperror.KindPreconditionFailed))
+
+	appRepo.Mock.AssertNotCalled(t, "UpdateMovie", int64(81), mock.Anything, mock.Anything)
+	appRepo.Mock.AssertNotCalled(t, "DeleteMovie", int64(81), mock.Anything, mock.Anything)
+}
+
+func Test_UpdateMovie_PassesReadVersion(t *testing.T) {
+	current := int64(5)
+	appRepo.Mock.On("GetMovie", int64(82)).Return(&model.Movie{ID: 82, Title: "Dans 82", Version: 5}, nil)
+	appRepo.Mock.On("UpdateMovie", int64(82), mock.MatchedBy(func(movie model.Movie) bool {
+		return movie.Version == 5
+	}), mock.Anything).Return(nil)
+
+	err := appUsecase.UpdateMovie(82, request.UpdateMovie{Title: ptr("Dans 1"), Description: ptr("Dans 1"), Rating: ptr(float32(4)), Image: ptr("fafa.jpg"), Version: &current})
+	assert.Nil(t, err)
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
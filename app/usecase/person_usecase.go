@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+	"xsis-code-test/models/request"
+	"xsis-code-test/models/response"
+	"xsis-code-test/utils"
+)
+
+func (au *AppUsecase) CreatePerson(req request.CreatePerson) (*response.Person, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	if err := utils.Validate(req); err != nil {
+		return nil, err
+	}
+
+	birthDate, err := utils.ParseDate("birth_date", req.BirthDate)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	person := model.Person{
+		Name:      req.Name,
+		Bio:       req.Bio,
+		BirthDate: birthDate,
+		Photo:     req.Photo,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := au.AppRepository.CreatePerson(&person); err != nil {
+		return nil, err
+	}
+
+	resPerson := personResponse(person)
+	return &resPerson, nil
+}
+
+func (au *AppUsecase) ListPerson(req request.ListPerson) (*[]response.Person, *response.Pagination, error) {
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	req.PageSize = clampPageSize(req.PageSize)
+	req.Limit = req.PageSize
+	req.Offset = (req.Page - 1) * req.PageSize
+
+	people, total, err := au.AppRepository.ListPerson(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resPeople := make([]response.Person, 0, len(*people))
+	for _, person := range *people {
+		resPeople = append(resPeople, personResponse(person))
+	}
+
+	pagination := &response.Pagination{Total: &total, Page: req.Page, PageSize: req.PageSize, Limit: req.Limit, Offset: req.Offset}
+	return &resPeople, pagination, nil
+}
+
+func (au *AppUsecase) GetPerson(id int64) (*response.Person, error) {
+	person, err := au.AppRepository.GetPerson(id)
+	if err != nil {
+		return nil, err
+	}
+
+	resPerson := personResponse(*person)
+	return &resPerson, nil
+}
+
+func (au *AppUsecase) UpdatePerson(id int64, req request.UpdatePerson) error {
+	req.Name = strings.TrimSpace(req.Name)
+	if err := utils.Validate(req); err != nil {
+		return err
+	}
+
+	birthDate, err := utils.ParseDate("birth_date", req.BirthDate)
+	if err != nil {
+		return err
+	}
+
+	return au.AppRepository.UpdatePerson(model.Person{
+		ID:        id,
+		Name:      req.Name,
+		Bio:       req.Bio,
+		BirthDate: birthDate,
+		Photo:     req.Photo,
+		UpdatedAt: time.Now(),
+	})
+}
+
+func (au *AppUsecase) DeletePerson(id int64) error {
+	return au.AppRepository.DeletePerson(id)
+}
+
+func (au *AppUsecase) ListPersonFilmography(id int64) (*[]response.PersonCredit, error) {
+	if _, err := au.AppRepository.GetPerson(id); err != nil {
+		return nil, err
+	}
+	credits, err := au.AppRepository.ListPersonCredits(id)
+	if err != nil {
+		return nil, err
+	}
+
+	filmography := make([]response.PersonCredit, 0, len(*credits))
+	for _, credit := range *credits {
+		personCredit := response.PersonCredit{
+			ID:           credit.ID,
+			MovieID:      credit.MovieID,
+			Role:         credit.Role,
+			Character:    credit.Character,
+			BillingOrder: credit.BillingOrder,
+		}
+		if credit.Movie != nil {
+			personCredit.Title = credit.Movie.Title
+			personCredit.Image = credit.Movie.Image
+			personCredit.Rating = credit.Movie.Rating
+		}
+		filmography = append(filmography, personCredit)
+	}
+
+	return &filmography, nil
+}
+
+func (au *AppUsecase) ListMovieCredits(movieID int64) (*[]response.MovieCredit, error) {
+	if _, err := au.AppRepository.GetMovie(movieID); err != nil {
+		return nil, err
+	}
+	credits, err := au.AppRepository.ListMovieCredits(movieID)
+	if err != nil {
+		return nil, err
+	}
+
+	movieCredits := make([]response.MovieCredit, 0, len(*credits))
+	for _, credit := range *credits {
+		movieCredits = append(movieCredits, movieCreditResponse(credit))
+	}
+
+	return &movieCredits, nil
+}
+
+func (au *AppUsecase) CreateCredit(movieID int64, req request.CreateCredit) (*response.MovieCredit, error) {
+	req.Role = strings.ToLower(strings.TrimSpace(req.Role))
+	if err := utils.Validate(req); err != nil {
+		return nil, err
+	}
+	if _, err := au.AppRepository.GetMovie(movieID); err != nil {
+		return nil, err
+	}
+	person, err := au.AppRepository.GetPerson(req.PersonID)
+	if err != nil {
+		if apperror.Is(err, apperror.KindNotFound) {
+			return nil, apperror.Validation(fmt.Sprintf("Person %d Does Not Exist", req.PersonID))
+		}
+		return nil, err
+	}
+
+	credit := model.Credit{
+		MovieID:      movieID,
+		PersonID:     person.ID,
+		Role:         req.Role,
+		Character:    req.Character,
+		BillingOrder: req.BillingOrder,
+		CreatedAt:    time.Now(),
+	}
+	if err := au.AppRepository.CreateCredit(&credit); err != nil {
+		return nil, err
+	}
+	credit.Person = person
+
+	resCredit := movieCreditResponse(credit)
+	return &resCredit, nil
+}
+
+func (au *AppUsecase) DeleteCredit(movieID, creditID int64) error {
+	return au.AppRepository.DeleteCredit(movieID, creditID)
+}
+
+func personResponse(person model.Person) response.Person {
+	resPerson := response.Person{
+		ID:        person.ID,
+		Name:      person.Name,
+		Bio:       person.Bio,
+		Photo:     person.Photo,
+		CreatedAt: person.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt: person.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+	if person.BirthDate != nil {
+		resPerson.BirthDate = person.BirthDate.Format(utils.DateLayout)
+	}
+	return resPerson
+}
+
+func movieCreditResponse(credit model.Credit) response.MovieCredit {
+	movieCredit := response.MovieCredit{
+		ID:           credit.ID,
+		PersonID:     credit.PersonID,
+		Role:         credit.Role,
+		Character:    credit.Character,
+		BillingOrder: credit.BillingOrder,
+	}
+	if credit.Person != nil {
+		movieCredit.Name = credit.Person.Name
+		movieCredit.Photo = credit.Person.Photo
+	}
+	return movieCredit
+}
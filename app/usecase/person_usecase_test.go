@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+	"xsis-code-test/apperror"
+	"xsis-code-test/models/model"
+	"xsis-code-test/models/request"
+	"xsis-code-test/utils"
+)
+
+func Test_CreatePerson(t *testing.T) {
+	appRepo.Mock.On("CreatePerson", mock.MatchedBy(func(person *model.Person) bool {
+		return person.Name == "Dans Person" && person.BirthDate != nil && person.BirthDate.Format(utils.DateLayout) == "1970-01-31"
+	})).Run(func(args mock.Arguments) { args.Get(0).(*model.Person).ID = 31 }).Return(nil)
+
+	person, err := appUsecase.CreatePerson(request.CreatePerson{Name: " Dans Person ", BirthDate: "1970-01-31"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(31), person.ID)
+	assert.Equal(t, "1970-01-31", person.BirthDate)
+
+	_, err = appUsecase.CreatePerson(request.CreatePerson{Name: "Dans", BirthDate: "31-01-1970"})
+	var validationErrors utils.ValidationErrors
+	assert.ErrorAs(t, err, &validationErrors)
+}
+
+func Test_ListPerson(t *testing.T) {
+	appRepo.Mock.On("ListPerson", request.ListPerson{Page: 2, PageSize: 10, Limit: 10, Offset: 10, Name: "dans"}).
+		Return(&[]model.Person{{ID: 32, Name: "Dans"}}, int64(11), nil)
+
+	people, pagination, err := appUsecase.ListPerson(request.ListPerson{Page: 2, Name: "dans"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(11), *pagination.Total)
+	assert.Equal(t, "Dans", (*people)[0].Name)
+}
+
+func Test_ListPersonFilmography(t *testing.T) {
+	appRepo.Mock.On("GetPerson", int64(33)).Return(&model.Person{ID: 33, Name: "Dans"}, nil)
+	appRepo.Mock.On("GetPerson", int64(34)).Return((*model.Person)(nil), apperror.NotFound("Person Not Found"))
+	appRepo.Mock.On("ListPersonCredits", int64(33)).Return(&[]model.Credit{
+		{ID: 1, MovieID: 1301, PersonID: 33, Role: "actor", Character: "Hero", Movie: &model.Movie{ID: 1301, Title: "Dans 1301", Rating: 8}},
+	}, nil)
+
+	filmography, err := appUsecase.ListPersonFilmography(33)
+	assert.Nil(t, err)
+	assert.Equal(t, "Dans 1301", (*filmography)[0].Title)
+	assert.Equal(t, "Hero", (*filmography)[0].Character)
+
+	_, err = appUsecase.ListPersonFilmography(34)
+	assert.True(t, apperror.Is(err, apperror.KindNotFound))
+}
+
+func Test_CreateCredit(t *testing.T) {
+	appRepo.Mock.On("GetMovie", int64(1302)).Return(&model.Movie{ID: 1302, Title: "Dans 1302", CreatedAt: time.Now()}, nil)
+	appRepo.Mock.On("GetPerson", int64(35)).Return(&model.Person{ID: 35, Name: "Dans Director"}, nil)
+	appRepo.Mock.On("GetPerson", int64(36)).Return((*model.Person)(nil), apperror.NotFound("Person Not Found"))
+	appRepo.Mock.On("CreateCredit", mock.MatchedBy(func(credit *model.Credit) bool {
+		return credit.MovieID == 1302 && credit.PersonID == 35 && credit.Role == "director"
+	})).Run(func(args mock.Arguments) { args.Get(0).(*model.Credit).ID = 7 }).Return(nil)
+
+	credit, err := appUsecase.CreateCredit(1302, request.CreateCredit{PersonID: 35, Role: "Director"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(7), credit.ID)
+	assert.Equal(t, "Dans Director", credit.Name)
+
+	_, err = appUsecase.CreateCredit(1302, request.CreateCredit{PersonID: 36, Role: "writer"})
+	assert.True(t, apperror.Is(err, apperror.KindValidation))
+
+	_, err = appUsecase.CreateCredit(1302, request.CreateCredit{PersonID: 35, Role: "producer"})
+	var validationErrors utils.ValidationErrors
+	assert.ErrorAs(t, err, &validationErrors)
+}
+
+func Test_ListMovieCredits(t *testing.T) {
+	appRepo.Mock.On("GetMovie", int64(1303)).Return(&model.Movie{ID: 1303, Title: "Dans 1303"}, nil)
+	appRepo.Mock.On("ListMovieCredits", int64(1303)).Return(&[]model.Credit{
+		{ID: 8, MovieID: 1303, PersonID: 37, Role: "writer", Person: &model.Person{ID: 37, Name: "Dans Writer"}},
+	}, nil)
+
+	credits, err := appUsecase.ListMovieCredits(1303)
+	assert.Nil(t, err)
+	assert.Equal(t, "Dans Writer", (*credits)[0].Name)
+	assert.Equal(t, "writer", (*credits)[0].Role)
+}
@@ -0,0 +1,103 @@
+package apperror
+
+import "errors"
+
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindBadRequest
+	KindNotFound
+	KindConflict
+	KindValidation
+	KindUnavailable
+	KindPreconditionFailed
+	KindUnsupportedMediaType
+	KindPayloadTooLarge
+)
+
+type Error struct {
+	Kind    Kind
+	Message string
+	Err     error
+	Details any
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func New(kind Kind, message string, err error) error {
+	return &Error{Kind: kind, Message: message, Err: err}
+}
+
+func BadRequest(message string) error {
+	return New(KindBadRequest, message, nil)
+}
+
+func NotFound(message string) error {
+	return New(KindNotFound, message, nil)
+}
+
+func Conflict(message string) error {
+	return New(KindConflict, message, nil)
+}
+
+func Validation(message string) error {
+	return New(KindValidation, message, nil)
+}
+
+func PreconditionFailed(message string) error {
+	return New(KindPreconditionFailed, message, nil)
+}
+
+func UnsupportedMediaType(message string) error {
+	return New(KindUnsupportedMediaType, message, nil)
+}
+
+func PayloadTooLarge(message string) error {
+	return New(KindPayloadTooLarge, message, nil)
+}
+
+func Unavailable(message string, err error) error {
+	return New(KindUnavailable, message, err)
+}
+
+func Internal(message string, err error) error {
+	return New(KindInternal, message, err)
+}
+
+func WithDetails(err error, details any) error {
+	var appErr *Error
+	if !errors.As(err, &appErr) {
+		return err
+	}
+	detailed := *appErr
+	detailed.Details = details
+	return &detailed
+}
+
+func DetailsOf(err error) any {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Details
+	}
+	return nil
+}
+
+func KindOf(err error) Kind {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Kind
+	}
+	return KindInternal
+}
+
+func Is(err error, kind Kind) bool {
+	var appErr *Error
+	return errors.As(err, &appErr) && appErr.Kind == kind
+}
@@ -0,0 +1,58 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestKindOf(t *testing.T) {
+	cause := errors.New("connection refused")
+	testCases := []struct {
+		name         string
+		err          error
+		expectedKind Kind
+	}{
+		{name: "Not found", err: NotFound("Movie Not Found"), expectedKind: KindNotFound},
+		{name: "Conflict", err: Conflict("Movie Already Exists"), expectedKind: KindConflict},
+		{name: "Validation", err: Validation("Title Cannot Be Empty"), expectedKind: KindValidation},
+		{name: "Precondition failed", err: PreconditionFailed("Movie Has Been Modified"), expectedKind: KindPreconditionFailed},
+		{name: "Unsupported media type", err: UnsupportedMediaType("Unsupported Content-Type text/plain"), expectedKind: KindUnsupportedMediaType},
+		{name: "Payload too large", err: PayloadTooLarge("file Cannot Be Larger Than 10 MB"), expectedKind: KindPayloadTooLarge},
+		{name: "Unavailable", err: Unavailable("Database Unavailable", cause), expectedKind: KindUnavailable},
+		{name: "Wrapped", err: fmt.Errorf("update: %w", NotFound("Movie Not Found")), expectedKind: KindNotFound},
+		{name: "Plain error", err: errors.New("boom"), expectedKind: KindInternal},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if kind := KindOf(tc.err); kind != tc.expectedKind {
+				t.Errorf("expected kind %d, got %d", tc.expectedKind, kind)
+			}
+			if !Is(tc.err, tc.expectedKind) && tc.expectedKind != KindInternal {
+				t.Errorf("expected Is to match kind %d", tc.expectedKind)
+			}
+		})
+	}
+
+	if err := Unavailable("Database Unavailable", cause); !errors.Is(err, cause) || err.Error() != "Database Unavailable" {
+		t.Errorf("expected wrapped cause and public message, got %v", err)
+	}
+}
+
+func TestWithDetails(t *testing.T) {
+	base := Conflict("Movie May Be A Duplicate")
+	detailed := WithDetails(base, []int{1, 2})
+	if !Is(detailed, KindConflict) || detailed.Error() != "Movie May Be A Duplicate" {
+		t.Errorf("expected conflict with same message, got %v", detailed)
+	}
+	if details, ok := DetailsOf(detailed).([]int); !ok || len(details) != 2 {
+		t.Errorf("expected details to be kept, got %v", DetailsOf(detailed))
+	}
+	if DetailsOf(base) != nil {
+		t.Errorf("expected original error to stay without details")
+	}
+	if plain := errors.New("boom"); WithDetails(plain, 1) != plain {
+		t.Errorf("expected plain error to be returned unchanged")
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	_ "github.com/joho/godotenv/autoload"
@@ -9,11 +10,45 @@ import (
 	"log"
 	"net/http"
 	"os"
-	"xsis-code-test/models/model"
+	"strconv"
+	"time"
+	"xsis-code-test/migrations"
 	"xsis-code-test/routes"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	db := openDB()
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Panic("Cannot Connect to DB")
+	}
+	migrator, err := migrations.NewMigrator(sqlDB)
+	if err != nil {
+		log.Panic("Cannot Load Migrations ", err)
+	}
+	if _, err := migrator.Up(context.Background(), 0); err != nil {
+		log.Panic("Cannot Migrate DB ", err)
+	}
+	appUsecase := routes.NewAppUsecase(db)
+	if days, _ := strconv.Atoi(os.Getenv("TRASH_RETENTION_DAYS")); days > 0 {
+		retention := time.Duration(days) * 24 * time.Hour
+		go appUsecase.RunTrashRetention(retention, time.Hour)
+	}
+	srv := routes.AppRoutes(db, appUsecase)
+	log.Println("Listening Application on Port ", os.Getenv("APP_PORT"))
+	if err := http.ListenAndServe(fmt.Sprintf(":%s", os.Getenv("APP_PORT")), srv); err != nil {
+		log.Panic("App cannot start")
+	}
+}
+
+func openDB() *gorm.DB {
 	var (
 		db  *gorm.DB
 		err error
@@ -46,10 +81,5 @@ func main() {
 	if err != nil {
 		log.Panic("Cannot Connect to DB")
 	}
-	db.AutoMigrate(model.Movie{})
-	srv := routes.AppRoutes(db)
-	log.Println("Listening Application on Port ", os.Getenv("APP_PORT"))
-	if err := http.ListenAndServe(fmt.Sprintf(":%s", os.Getenv("APP_PORT")), srv); err != nil {
-		log.Panic("App cannot start")
-	}
+	return db
 }
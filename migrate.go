@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"xsis-code-test/migrations"
+)
+
+const migrateUsage = "usage: migrate up [n] | down [n] | status | create <name>"
+
+func runMigrate(args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	if args[0] == "create" {
+		if len(args) < 2 {
+			return errors.New(migrateUsage)
+		}
+		dir := os.Getenv("MIGRATIONS_DIR")
+		if dir == "" {
+			dir = "migrations/sql"
+		}
+		paths, err := migrations.Create(dir, args[1])
+		if err != nil {
+			return err
+		}
+		for _, path := range paths {
+			fmt.Println("created", path)
+		}
+		return nil
+	}
+
+	steps := 0
+	if len(args) > 1 {
+		var err error
+		if steps, err = strconv.Atoi(args[1]); err != nil || steps <= 0 {
+			return fmt.Errorf("%s is not a valid step count", args[1])
+		}
+	}
+
+	sqlDB, err := openDB().DB()
+	if err != nil {
+		return err
+	}
+	defer sqlDB.Close()
+	migrator, err := migrations.NewMigrator(sqlDB)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx, steps)
+		for _, migration := range applied {
+			fmt.Printf("applied %06d_%s\n", migration.Version, migration.Name)
+		}
+		return err
+	case "down":
+		reverted, err := migrator.Down(ctx, steps)
+		for _, migration := range reverted {
+			fmt.Printf("reverted %06d_%s\n", migration.Version, migration.Name)
+		}
+		return err
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%06d_%-40s %s\n", status.Version, status.Name, appliedAt)
+		}
+		return nil
+	}
+	return errors.New(migrateUsage)
+}
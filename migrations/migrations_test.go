@@ -0,0 +1,137 @@
+package migrations
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func newMigratorMock(t *testing.T) (*Migrator, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return &Migrator{DB: db, Migrations: []Migration{
+		{Version: 1, Name: "first", Up: "CREATE TABLE first (id bigint)", Down: "DROP TABLE first"},
+		{Version: 2, Name: "second", Up: "CREATE TABLE second (id bigint)", Down: "DROP TABLE second"},
+	}}, mock
+}
+
+func TestLoad(t *testing.T) {
+	migrations, err := Load(fstest.MapFS{
+		"000002_second.up.sql":   {Data: []byte("CREATE TABLE second (id bigint)")},
+		"000002_second.down.sql": {Data: []byte("DROP TABLE second")},
+		"000001_first.up.sql":    {Data: []byte("CREATE TABLE first (id bigint)")},
+		"000001_first.down.sql":  {Data: []byte("DROP TABLE first")},
+		"README.md":              {Data: []byte("ignored")},
+	})
+	assert.Nil(t, err)
+	assert.Len(t, migrations, 2)
+	assert.Equal(t, int64(1), migrations[0].Version)
+	assert.Equal(t, "second", migrations[1].Name)
+	assert.Equal(t, "DROP TABLE second", migrations[1].Down)
+
+	_, err = Load(fstest.MapFS{"000001_first.up.sql": {Data: []byte("CREATE TABLE first (id bigint)")}})
+	assert.NotNil(t, err)
+}
+
+func TestNewMigrator_Embedded(t *testing.T) {
+	migrator, err := NewMigrator(nil)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, migrator.Migrations)
+	for i, migration := range migrator.Migrations {
+		assert.Equal(t, int64(i+1), migration.Version)
+	}
+}
+
+func TestUp(t *testing.T) {
+	migrator, mock := newMigratorMock(t)
+	mock.ExpectExec("SELECT pg_advisory_lock").WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT version, applied_at FROM schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"version", "applied_at"}).AddRow(1, time.Now()))
+	mock.ExpectBegin()
+	mock.ExpectExec("CREATE TABLE second").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO schema_migrations").WithArgs(int64(2), "second").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectExec("SELECT pg_advisory_unlock").WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	applied, err := migrator.Up(context.Background(), 0)
+	assert.Nil(t, err)
+	assert.Len(t, applied, 1)
+	assert.Equal(t, "second", applied[0].Name)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestUp_RollsBackFailedMigration(t *testing.T) {
+	migrator, mock := newMigratorMock(t)
+	mock.ExpectExec("SELECT pg_advisory_lock").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT version, applied_at FROM schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"version", "applied_at"}))
+	mock.ExpectBegin()
+	mock.ExpectExec("CREATE TABLE first").WillReturnError(assert.AnError)
+	mock.ExpectRollback()
+	mock.ExpectExec("SELECT pg_advisory_unlock").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	applied, err := migrator.Up(context.Background(), 0)
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Empty(t, applied)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestDown(t *testing.T) {
+	migrator, mock := newMigratorMock(t)
+	mock.ExpectExec("SELECT pg_advisory_lock").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT version, applied_at FROM schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"version", "applied_at"}).AddRow(1, time.Now()).AddRow(2, time.Now()))
+	mock.ExpectBegin()
+	mock.ExpectExec("DROP TABLE second").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM schema_migrations").WithArgs(int64(2)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectExec("SELECT pg_advisory_unlock").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	reverted, err := migrator.Down(context.Background(), 0)
+	assert.Nil(t, err)
+	assert.Len(t, reverted, 1)
+	assert.Equal(t, int64(2), reverted[0].Version)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestStatus(t *testing.T) {
+	migrator, mock := newMigratorMock(t)
+	appliedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT version, applied_at FROM schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"version", "applied_at"}).AddRow(1, appliedAt))
+
+	statuses, err := migrator.Status(context.Background())
+	assert.Nil(t, err)
+	assert.Len(t, statuses, 2)
+	assert.Equal(t, appliedAt, *statuses[0].AppliedAt)
+	assert.Nil(t, statuses[1].AppliedAt)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestCreate(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "000004_existing.up.sql"), []byte("SELECT 1"), 0o644)
+	os.WriteFile(filepath.Join(dir, "000004_existing.down.sql"), []byte("SELECT 1"), 0o644)
+
+	paths, err := Create(dir, "Add Movie Images!")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "000005_add_movie_images.up.sql"),
+		filepath.Join(dir, "000005_add_movie_images.down.sql"),
+	}, paths)
+
+	_, err = Create(dir, "!!!")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS credits;
+DROP TABLE IF EXISTS people;
+DROP TABLE IF EXISTS movie_genres;
+DROP TABLE IF EXISTS genres;
+DROP TABLE IF EXISTS idempotency_records;
+DROP TABLE IF EXISTS import_job_errors;
+DROP TABLE IF EXISTS import_jobs;
+DROP TABLE IF EXISTS movie_revisions;
+DROP TABLE IF EXISTS movies;
@@ -0,0 +1,117 @@
+-- Tables that were previously created by AutoMigrate. CREATE TABLE only runs
+-- on fresh databases, so columns added to movies after its first release are
+-- also added explicitly for databases created by an older AutoMigrate.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE TABLE IF NOT EXISTS movies (
+	id bigserial PRIMARY KEY,
+	title text NOT NULL,
+	description text NOT NULL,
+	rating decimal NOT NULL DEFAULT 0,
+	image text NOT NULL,
+	created_at timestamptz NOT NULL,
+	updated_at timestamptz NOT NULL,
+	deleted_at timestamptz,
+	merged_into bigint,
+	version bigint NOT NULL DEFAULT 1,
+	search_vector tsvector
+);
+ALTER TABLE movies
+	ADD COLUMN IF NOT EXISTS merged_into bigint,
+	ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1,
+	ADD COLUMN IF NOT EXISTS search_vector tsvector;
+CREATE INDEX IF NOT EXISTS idx_movies_merged_into ON movies (merged_into);
+CREATE INDEX IF NOT EXISTS idx_movies_search_vector ON movies USING gin (search_vector);
+CREATE INDEX IF NOT EXISTS idx_movies_title_trgm ON movies USING gin (title gin_trgm_ops);
+UPDATE movies
+SET search_vector = setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+	setweight(to_tsvector('english', coalesce(description, '')), 'B')
+WHERE search_vector IS NULL;
+
+CREATE TABLE IF NOT EXISTS movie_revisions (
+	id bigserial PRIMARY KEY,
+	movie_id bigint NOT NULL,
+	revision bigint NOT NULL,
+	action text NOT NULL,
+	snapshot jsonb NOT NULL,
+	diff jsonb NOT NULL,
+	actor text NOT NULL,
+	created_at timestamptz NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_movie_revision ON movie_revisions (movie_id, revision);
+
+CREATE TABLE IF NOT EXISTS import_jobs (
+	id bigserial PRIMARY KEY,
+	status text NOT NULL,
+	format text NOT NULL,
+	filename text NOT NULL,
+	mapping jsonb NOT NULL,
+	actor text NOT NULL,
+	processed bigint NOT NULL DEFAULT 0,
+	inserted bigint NOT NULL DEFAULT 0,
+	skipped bigint NOT NULL DEFAULT 0,
+	failed bigint NOT NULL DEFAULT 0,
+	error text NOT NULL DEFAULT '',
+	created_at timestamptz NOT NULL,
+	started_at timestamptz,
+	finished_at timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS import_job_errors (
+	id bigserial PRIMARY KEY,
+	job_id bigint NOT NULL,
+	"row" bigint NOT NULL,
+	message text NOT NULL,
+	raw text NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_import_job_errors_job_id ON import_job_errors (job_id);
+
+CREATE TABLE IF NOT EXISTS idempotency_records (
+	key text PRIMARY KEY,
+	fingerprint text NOT NULL,
+	completed boolean NOT NULL DEFAULT false,
+	status bigint NOT NULL DEFAULT 0,
+	header jsonb NOT NULL DEFAULT '{}',
+	body bytea,
+	created_at timestamptz NOT NULL,
+	expires_at timestamptz NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_idempotency_records_expires_at ON idempotency_records (expires_at);
+
+CREATE TABLE IF NOT EXISTS genres (
+	id bigserial PRIMARY KEY,
+	name text NOT NULL,
+	created_at timestamptz NOT NULL,
+	updated_at timestamptz NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_genres_name ON genres (name);
+
+CREATE TABLE IF NOT EXISTS movie_genres (
+	movie_id bigint NOT NULL,
+	genre_id bigint NOT NULL,
+	PRIMARY KEY (movie_id, genre_id)
+);
+CREATE INDEX IF NOT EXISTS idx_movie_genres_genre_id ON movie_genres (genre_id);
+
+CREATE TABLE IF NOT EXISTS people (
+	id bigserial PRIMARY KEY,
+	name text NOT NULL,
+	bio text NOT NULL DEFAULT '',
+	birth_date date,
+	photo text NOT NULL DEFAULT '',
+	created_at timestamptz NOT NULL,
+	updated_at timestamptz NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_people_name ON people (name);
+
+CREATE TABLE IF NOT EXISTS credits (
+	id bigserial PRIMARY KEY,
+	movie_id bigint NOT NULL,
+	person_id bigint NOT NULL,
+	role text NOT NULL,
+	"character" text NOT NULL DEFAULT '',
+	billing_order bigint NOT NULL DEFAULT 0,
+	created_at timestamptz NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_credits_movie_id ON credits (movie_id);
+CREATE INDEX IF NOT EXISTS idx_credits_person_id ON credits (person_id);
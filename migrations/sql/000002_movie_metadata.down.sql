@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_movies_production_countries;
+DROP INDEX IF EXISTS idx_movies_spoken_languages;
+DROP INDEX IF EXISTS idx_movies_original_language;
+DROP INDEX IF EXISTS idx_movies_release_date;
+ALTER TABLE movies
+	DROP COLUMN IF EXISTS certifications,
+	DROP COLUMN IF EXISTS production_countries,
+	DROP COLUMN IF EXISTS spoken_languages,
+	DROP COLUMN IF EXISTS original_language,
+	DROP COLUMN IF EXISTS runtime_minutes,
+	DROP COLUMN IF EXISTS release_date;
@@ -0,0 +1,11 @@
+ALTER TABLE movies
+	ADD COLUMN IF NOT EXISTS release_date date,
+	ADD COLUMN IF NOT EXISTS runtime_minutes bigint NOT NULL DEFAULT 0,
+	ADD COLUMN IF NOT EXISTS original_language text NOT NULL DEFAULT '',
+	ADD COLUMN IF NOT EXISTS spoken_languages jsonb NOT NULL DEFAULT '[]',
+	ADD COLUMN IF NOT EXISTS production_countries jsonb NOT NULL DEFAULT '[]',
+	ADD COLUMN IF NOT EXISTS certifications jsonb NOT NULL DEFAULT '{}';
+CREATE INDEX IF NOT EXISTS idx_movies_release_date ON movies (release_date);
+CREATE INDEX IF NOT EXISTS idx_movies_original_language ON movies (original_language);
+CREATE INDEX IF NOT EXISTS idx_movies_spoken_languages ON movies USING gin (spoken_languages);
+CREATE INDEX IF NOT EXISTS idx_movies_production_countries ON movies USING gin (production_countries);
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS movie_images (
+	id bigserial PRIMARY KEY,
+	movie_id bigint NOT NULL,
+	kind text NOT NULL,
+	url text NOT NULL,
+	width bigint NOT NULL DEFAULT 0,
+	height bigint NOT NULL DEFAULT 0,
+	language text NOT NULL DEFAULT '',
+	is_primary boolean NOT NULL DEFAULT false,
+	position bigint NOT NULL DEFAULT 0,
+	created_at timestamptz NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_movie_images_movie_id ON movie_images (movie_id, position);
+CREATE UNIQUE INDEX IF NOT EXISTS ux_movie_images_primary ON movie_images (movie_id, kind) WHERE is_primary;
+
+INSERT INTO movie_images (movie_id, kind, url, is_primary, position, created_at)
+SELECT id, 'poster', image, true, 0, created_at
+FROM movies
+WHERE image <> ''
+	AND NOT EXISTS (SELECT 1 FROM movie_images WHERE movie_images.movie_id = movies.id AND kind = 'poster' AND is_primary);
@@ -0,0 +1 @@
+ALTER TABLE movie_images DROP COLUMN IF EXISTS thumbnails;
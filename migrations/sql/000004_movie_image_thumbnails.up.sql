@@ -0,0 +1 @@
+ALTER TABLE movie_images ADD COLUMN IF NOT EXISTS thumbnails jsonb NOT NULL DEFAULT '{}';
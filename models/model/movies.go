@@ -1,14 +1,168 @@
 package model
 
-import "time"
+import (
+	"io"
+	"time"
+)
 
 type Movie struct {
-	ID          int64      `json:"id" gorm:"id,primaryKey,autoIncrement"`
-	Title       string     `json:"title" gorm:"title,not null"`
-	Description string     `json:"description" gorm:"description,not null"`
-	Rating      float32    `json:"rating" gorm:"rating, not null"`
-	Image       string     `json:"image" gorm:"image, not null"`
-	CreatedAt   time.Time  `json:"created_at" gorm:"created_at,not null"`
-	UpdatedAt   time.Time  `json:"updated_at" gorm:"updated_at,not null"`
-	DeletedAt   *time.Time `json:"deleted_at" gorm:"deleted_at"`
+	ID                  int64      `json:"id" gorm:"id,primaryKey,autoIncrement"`
+	Title               string     `json:"title" gorm:"title,not null"`
+	Description         string     `json:"description" gorm:"description,not null"`
+	Rating              float32    `json:"rating" gorm:"rating, not null"`
+	Image               string     `json:"image" gorm:"image, not null"`
+	ReleaseDate         *time.Time `json:"release_date" gorm:"type:date;index"`
+	RuntimeMinutes      int        `json:"runtime_minutes" gorm:"not null;default:0"`
+	OriginalLanguage    string     `json:"original_language" gorm:"not null;default:'';index"`
+	SpokenLanguages     StringList `json:"spoken_languages" gorm:"type:jsonb;not null;default:'[]'"`
+	ProductionCountries StringList `json:"production_countries" gorm:"type:jsonb;not null;default:'[]'"`
+	Certifications      StringMap  `json:"certifications" gorm:"type:jsonb;not null;default:'{}'"`
+	CreatedAt           time.Time  `json:"created_at" gorm:"created_at,not null"`
+	UpdatedAt           time.Time  `json:"updated_at" gorm:"updated_at,not null"`
+	DeletedAt           *time.Time `json:"deleted_at" gorm:"deleted_at"`
+	MergedInto          *int64     `json:"merged_into" gorm:"index"`
+	Version             int64      `json:"version" gorm:"not null;default:1"`
+	SearchVector        string     `json:"-" gorm:"column:search_vector;type:tsvector;index:,type:gin;->:false;<-:false"`
+	Genres              []Genre    `json:"genres" gorm:"-"`
+}
+
+type MovieSuggestion struct {
+	ID    int64
+	Title string
+	Score float32
+}
+
+type MovieSearchResult struct {
+	Movie          `gorm:"embedded"`
+	Rank           float32 `json:"rank"`
+	TitleHighlight string  `json:"title_highlight"`
+	Snippet        string  `json:"snippet"`
+}
+
+type MovieRevision struct {
+	ID        int64     `json:"id" gorm:"primaryKey,autoIncrement"`
+	MovieID   int64     `json:"movie_id" gorm:"not null;uniqueIndex:idx_movie_revision"`
+	Revision  int       `json:"revision" gorm:"not null;uniqueIndex:idx_movie_revision"`
+	Action    string    `json:"action" gorm:"not null"`
+	Snapshot  string    `json:"snapshot" gorm:"type:jsonb;not null"`
+	Diff      string    `json:"diff" gorm:"type:jsonb;not null"`
+	Actor     string    `json:"actor" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"not null"`
+}
+
+type MovieSnapshot struct {
+	Title               string            `json:"title"`
+	Description         string            `json:"description"`
+	Rating              float32           `json:"rating"`
+	Image               string            `json:"image"`
+	ReleaseDate         string            `json:"release_date"`
+	RuntimeMinutes      int               `json:"runtime_minutes"`
+	OriginalLanguage    string            `json:"original_language"`
+	SpokenLanguages     []string          `json:"spoken_languages"`
+	ProductionCountries []string          `json:"production_countries"`
+	Certifications      map[string]string `json:"certifications"`
+	GenreIDs            *[]int64          `json:"genre_ids,omitempty"`
+}
+
+type MovieChange struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+type ImportJob struct {
+	ID         int64      `json:"id" gorm:"primaryKey,autoIncrement"`
+	Status     string     `json:"status" gorm:"not null"`
+	Format     string     `json:"format" gorm:"not null"`
+	Filename   string     `json:"filename" gorm:"not null"`
+	Mapping    string     `json:"mapping" gorm:"type:jsonb;not null"`
+	Actor      string     `json:"actor" gorm:"not null"`
+	Processed  int64      `json:"processed" gorm:"not null;default:0"`
+	Inserted   int64      `json:"inserted" gorm:"not null;default:0"`
+	Skipped    int64      `json:"skipped" gorm:"not null;default:0"`
+	Failed     int64      `json:"failed" gorm:"not null;default:0"`
+	Error      string     `json:"error" gorm:"not null;default:''"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"not null"`
+	StartedAt  *time.Time `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+}
+
+type ImportJobError struct {
+	ID      int64  `json:"id" gorm:"primaryKey,autoIncrement"`
+	JobID   int64  `json:"job_id" gorm:"not null;index"`
+	Row     int64  `json:"row" gorm:"not null"`
+	Message string `json:"message" gorm:"not null"`
+	Raw     string `json:"raw" gorm:"not null"`
+}
+
+type IdempotencyRecord struct {
+	Key         string    `json:"key" gorm:"primaryKey"`
+	Fingerprint string    `json:"fingerprint" gorm:"not null"`
+	Completed   bool      `json:"completed" gorm:"not null;default:false"`
+	Status      int       `json:"status" gorm:"not null;default:0"`
+	Header      string    `json:"header" gorm:"type:jsonb;not null;default:'{}'"`
+	Body        []byte    `json:"body"`
+	CreatedAt   time.Time `json:"created_at" gorm:"not null"`
+	ExpiresAt   time.Time `json:"expires_at" gorm:"not null;index"`
+}
+
+type MoviePair struct {
+	LeftID  int64
+	RightID int64
+}
+
+type Genre struct {
+	ID        int64     `json:"id" gorm:"primaryKey,autoIncrement"`
+	Name      string    `json:"name" gorm:"not null;uniqueIndex"`
+	CreatedAt time.Time `json:"created_at" gorm:"not null"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"not null"`
+}
+
+type MovieGenre struct {
+	MovieID int64 `json:"movie_id" gorm:"primaryKey"`
+	GenreID int64 `json:"genre_id" gorm:"primaryKey;index"`
+}
+
+type Person struct {
+	ID        int64      `json:"id" gorm:"primaryKey,autoIncrement"`
+	Name      string     `json:"name" gorm:"not null;index"`
+	Bio       string     `json:"bio" gorm:"not null;default:''"`
+	BirthDate *time.Time `json:"birth_date" gorm:"type:date"`
+	Photo     string     `json:"photo" gorm:"not null;default:''"`
+	CreatedAt time.Time  `json:"created_at" gorm:"not null"`
+	UpdatedAt time.Time  `json:"updated_at" gorm:"not null"`
+}
+
+type Credit struct {
+	ID           int64     `json:"id" gorm:"primaryKey,autoIncrement"`
+	MovieID      int64     `json:"movie_id" gorm:"not null;index"`
+	PersonID     int64     `json:"person_id" gorm:"not null;index"`
+	Role         string    `json:"role" gorm:"not null"`
+	Character    string    `json:"character" gorm:"not null;default:''"`
+	BillingOrder int       `json:"billing_order" gorm:"not null;default:0"`
+	CreatedAt    time.Time `json:"created_at" gorm:"not null"`
+	Movie        *Movie    `json:"movie,omitempty"`
+	Person       *Person   `json:"person,omitempty"`
+}
+
+type MovieImage struct {
+	ID         int64     `json:"id" gorm:"primaryKey,autoIncrement"`
+	MovieID    int64     `json:"movie_id" gorm:"not null;index"`
+	Kind       string    `json:"kind" gorm:"not null"`
+	URL        string    `json:"url" gorm:"column:url;not null"`
+	Width      int       `json:"width" gorm:"not null;default:0"`
+	Height     int       `json:"height" gorm:"not null;default:0"`
+	Language   string    `json:"language" gorm:"not null;default:''"`
+	Primary    bool      `json:"primary" gorm:"column:is_primary;not null;default:false"`
+	Position   int       `json:"position" gorm:"not null;default:0"`
+	Thumbnails StringMap `json:"thumbnails" gorm:"type:jsonb;not null"`
+	CreatedAt  time.Time `json:"created_at" gorm:"not null"`
+}
+
+type Blob struct {
+	Key          string
+	ContentType  string
+	Size         int64
+	ETag         string
+	LastModified time.Time
+	Body         io.ReadCloser
 }
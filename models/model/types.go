@@ -0,0 +1,47 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+type StringList []string
+
+func (sl StringList) Value() (driver.Value, error) {
+	if sl == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal([]string(sl))
+	return string(data), err
+}
+
+func (sl *StringList) Scan(src any) error {
+	return scanJSON(src, sl)
+}
+
+type StringMap map[string]string
+
+func (sm StringMap) Value() (driver.Value, error) {
+	if sm == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(map[string]string(sm))
+	return string(data), err
+}
+
+func (sm *StringMap) Scan(src any) error {
+	return scanJSON(src, sm)
+}
+
+func scanJSON(src any, dest any) error {
+	switch data := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(data, dest)
+	case string:
+		return json.Unmarshal([]byte(data), dest)
+	}
+	return fmt.Errorf("cannot scan %T into %T", src, dest)
+}
@@ -1,15 +1,264 @@
 package request
 
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+	"xsis-code-test/models/model"
+)
+
 type CreateMovie struct {
-	Title       string  `json:"title"`
-	Description string  `json:"description"`
-	Rating      float32 `json:"rating"`
-	Image       string  `json:"image"`
+	Title               string            `json:"title" validate:"required,max=255"`
+	Description         string            `json:"description" validate:"required,max=5000"`
+	Rating              float32           `json:"rating" validate:"gte=0,lte=10,decimals=1"`
+	Image               string            `json:"image" validate:"required,max=2048,imageurl"`
+	ReleaseDate         string            `json:"release_date" validate:"date"`
+	RuntimeMinutes      int               `json:"runtime_minutes" validate:"gte=0,lte=1440"`
+	OriginalLanguage    string            `json:"original_language" validate:"language"`
+	SpokenLanguages     []string          `json:"spoken_languages" validate:"languages"`
+	ProductionCountries []string          `json:"production_countries" validate:"countries"`
+	Certifications      map[string]string `json:"certifications" validate:"certifications"`
+	GenreIDs            []int64           `json:"genre_ids"`
+	Actor               string            `json:"-"`
+	Force               bool              `json:"-"`
 }
 
 type UpdateMovie struct {
-	Title       string  `json:"title"`
-	Description string  `json:"description"`
-	Rating      float32 `json:"rating"`
-	Image       string  `json:"image"`
+	Title               *string            `json:"title,omitempty"`
+	Description         *string            `json:"description,omitempty"`
+	Rating              *float32           `json:"rating,omitempty"`
+	Image               *string            `json:"image,omitempty"`
+	ReleaseDate         *string            `json:"release_date,omitempty"`
+	RuntimeMinutes      *int               `json:"runtime_minutes,omitempty"`
+	OriginalLanguage    *string            `json:"original_language,omitempty"`
+	SpokenLanguages     *[]string          `json:"spoken_languages,omitempty"`
+	ProductionCountries *[]string          `json:"production_countries,omitempty"`
+	Certifications      *map[string]string `json:"certifications,omitempty"`
+	GenreIDs            *[]int64           `json:"genre_ids,omitempty"`
+	Actor               string             `json:"-"`
+	Version             *int64             `json:"-"`
+}
+
+type ReplaceMovie struct {
+	Title               string            `json:"title" validate:"required,max=255"`
+	Description         string            `json:"description" validate:"required,max=5000"`
+	Rating              float32           `json:"rating" validate:"gte=0,lte=10,decimals=1"`
+	Image               string            `json:"image" validate:"required,max=2048,imageurl"`
+	ReleaseDate         string            `json:"release_date" validate:"date"`
+	RuntimeMinutes      int               `json:"runtime_minutes" validate:"gte=0,lte=1440"`
+	OriginalLanguage    string            `json:"original_language" validate:"language"`
+	SpokenLanguages     []string          `json:"spoken_languages" validate:"languages"`
+	ProductionCountries []string          `json:"production_countries" validate:"countries"`
+	Certifications      map[string]string `json:"certifications" validate:"certifications"`
+	GenreIDs            []int64           `json:"genre_ids"`
+	Actor               string            `json:"-"`
+	Version             *int64            `json:"-"`
+}
+
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+type PatchMovie struct {
+	Operations []PatchOperation
+	Actor      string
+	Version    *int64
+}
+
+type BulkMovie struct {
+	Mode   string            `json:"mode"`
+	Create []CreateMovie     `json:"create"`
+	Update []BulkUpdateMovie `json:"update"`
+	Delete []BulkDeleteMovie `json:"delete"`
+	Actor  string            `json:"-"`
+}
+
+type BulkUpdateMovie struct {
+	ID                  int64              `json:"id"`
+	Title               *string            `json:"title,omitempty"`
+	Description         *string            `json:"description,omitempty"`
+	Rating              *float32           `json:"rating,omitempty"`
+	Image               *string            `json:"image,omitempty"`
+	ReleaseDate         *string            `json:"release_date,omitempty"`
+	RuntimeMinutes      *int               `json:"runtime_minutes,omitempty"`
+	OriginalLanguage    *string            `json:"original_language,omitempty"`
+	SpokenLanguages     *[]string          `json:"spoken_languages,omitempty"`
+	ProductionCountries *[]string          `json:"production_countries,omitempty"`
+	Certifications      *map[string]string `json:"certifications,omitempty"`
+	Version             *int64             `json:"version"`
+}
+
+type BulkDeleteMovie struct {
+	ID      int64  `json:"id"`
+	Version *int64 `json:"version"`
+}
+
+type MergeMovie struct {
+	SourceID int64             `json:"source_id"`
+	Fields   map[string]string `json:"fields"`
+	Actor    string            `json:"-"`
+	Version  *int64            `json:"-"`
+}
+
+type DeleteMovie struct {
+	Actor   string
+	Version *int64
+}
+
+type ListMovie struct {
+	Page           int
+	PageSize       int
+	Limit          int
+	Offset         int
+	Sort           []string
+	MinRating      *float32
+	MaxRating      *float32
+	TitleContains  string
+	GenreIDs       []int64
+	CreatedAfter   *time.Time
+	CreatedBefore  *time.Time
+	ReleasedAfter  *time.Time
+	ReleasedBefore *time.Time
+	MinRuntime     *int
+	MaxRuntime     *int
+	Language       string
+	SpokenLanguage string
+	Country        string
+	Certification  string
+	Cursor         string
+}
+
+type SearchMovie struct {
+	Query    string
+	Page     int
+	PageSize int
+	Limit    int
+	Offset   int
+}
+
+type MovieCursor struct {
+	Sort   []string `json:"s"`
+	Values []any    `json:"v"`
+}
+
+func (lm ListMovie) SortKeys() []string {
+	keys := append([]string{}, lm.Sort...)
+	for _, key := range keys {
+		if strings.TrimPrefix(key, "-") == "id" {
+			return keys
+		}
+	}
+	return append(keys, "id")
+}
+
+func NewMovieCursor(keys []string, movie model.Movie) MovieCursor {
+	values := make([]any, len(keys))
+	for i, key := range keys {
+		values[i] = movieSortValue(movie, strings.TrimPrefix(key, "-"))
+	}
+	return MovieCursor{Sort: keys, Values: values}
+}
+
+func movieSortValue(movie model.Movie, key string) any {
+	switch key {
+	case "title":
+		return movie.Title
+	case "rating":
+		return movie.Rating
+	case "created_at":
+		return movie.CreatedAt
+	case "updated_at":
+		return movie.UpdatedAt
+	case "release_date":
+		if movie.ReleaseDate == nil {
+			return time.Time{}
+		}
+		return *movie.ReleaseDate
+	case "runtime_minutes":
+		return movie.RuntimeMinutes
+	case "original_language":
+		return movie.OriginalLanguage
+	default:
+		return movie.ID
+	}
+}
+
+type ImportMovie struct {
+	Format   string
+	Filename string
+	Mapping  map[string]string
+	Actor    string
+	File     io.ReadCloser
+}
+
+type CreateGenre struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+type UpdateGenre struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+type CreatePerson struct {
+	Name      string `json:"name" validate:"required,max=255"`
+	Bio       string `json:"bio" validate:"max=5000"`
+	BirthDate string `json:"birth_date" validate:"date"`
+	Photo     string `json:"photo" validate:"max=2048,imageurl"`
+}
+
+type UpdatePerson struct {
+	Name      string `json:"name" validate:"required,max=255"`
+	Bio       string `json:"bio" validate:"max=5000"`
+	BirthDate string `json:"birth_date" validate:"date"`
+	Photo     string `json:"photo" validate:"max=2048,imageurl"`
+}
+
+type ListPerson struct {
+	Page     int
+	PageSize int
+	Limit    int
+	Offset   int
+	Name     string
+}
+
+type CreateCredit struct {
+	PersonID     int64  `json:"person_id" validate:"required"`
+	Role         string `json:"role" validate:"required,oneof=director writer actor"`
+	Character    string `json:"character" validate:"max=255"`
+	BillingOrder int    `json:"billing_order" validate:"gte=0"`
+}
+
+type CreateMovieImage struct {
+	Kind     string `json:"kind" validate:"required,oneof=poster backdrop still logo"`
+	URL      string `json:"url" validate:"required,max=2048,imageurl"`
+	Width    int    `json:"width" validate:"gte=0,lte=20000"`
+	Height   int    `json:"height" validate:"gte=0,lte=20000"`
+	Language string `json:"language" validate:"language"`
+	Primary  bool   `json:"primary"`
+	Actor    string `json:"-"`
+	Version  *int64 `json:"-"`
+}
+
+type ReorderMovieImages struct {
+	ImageIDs []int64 `json:"image_ids"`
+}
+
+type DeleteMovieImage struct {
+	Actor   string
+	Version *int64
+}
+
+type UploadMovieImage struct {
+	Kind        string `json:"kind" validate:"required,oneof=poster backdrop still logo"`
+	Language    string `json:"language" validate:"language"`
+	Primary     bool   `json:"primary"`
+	Filename    string
+	ContentType string
+	Data        []byte
+	Actor       string
+	Version     *int64
 }
@@ -1,13 +1,23 @@
 package response
 
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
 type ListMovie struct {
 	ID          int64   `json:"id"`
 	Title       string  `json:"title"`
 	Description string  `json:"description"`
 	Rating      float32 `json:"rating"`
 	Image       string  `json:"image"`
-	CreatedAt   string  `json:"created_at"`
-	UpdatedAt   string  `json:"updated_at"`
+	MovieMetadata
+	Genres    []Genre   `json:"genres,omitempty"`
+	CreatedAt string    `json:"created_at"`
+	UpdatedAt string    `json:"updated_at"`
+	Version   int64     `json:"version"`
+	Modified  time.Time `json:"-"`
 }
 
 type GetMovie struct {
@@ -16,6 +26,178 @@ type GetMovie struct {
 	Description string  `json:"description"`
 	Rating      float32 `json:"rating"`
 	Image       string  `json:"image"`
+	MovieMetadata
+	Genres    []Genre       `json:"genres,omitempty"`
+	Credits   []MovieCredit `json:"credits,omitempty"`
+	CreatedAt string        `json:"created_at"`
+	UpdatedAt string        `json:"updated_at"`
+	Version   int64         `json:"version"`
+	Modified  time.Time     `json:"-"`
+}
+
+type MovieMetadata struct {
+	ReleaseDate         string            `json:"release_date,omitempty"`
+	RuntimeMinutes      int               `json:"runtime_minutes"`
+	OriginalLanguage    string            `json:"original_language"`
+	SpokenLanguages     []string          `json:"spoken_languages"`
+	ProductionCountries []string          `json:"production_countries"`
+	Certifications      map[string]string `json:"certifications"`
+}
+
+type TrashMovie struct {
+	ID          int64   `json:"id"`
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	Rating      float32 `json:"rating"`
+	Image       string  `json:"image"`
+	Version     int64   `json:"version"`
 	CreatedAt   string  `json:"created_at"`
 	UpdatedAt   string  `json:"updated_at"`
+	DeletedAt   string  `json:"deleted_at"`
+}
+
+type SearchMovie struct {
+	ID             int64   `json:"id"`
+	Title          string  `json:"title"`
+	Description    string  `json:"description"`
+	Rating         float32 `json:"rating"`
+	Image          string  `json:"image"`
+	CreatedAt      string  `json:"created_at"`
+	UpdatedAt      string  `json:"updated_at"`
+	Score          float32 `json:"score"`
+	TitleHighlight string  `json:"title_highlight"`
+	Snippet        string  `json:"snippet"`
+}
+
+type SuggestMovie struct {
+	ID    int64  `json:"id"`
+	Title string `json:"title"`
+}
+
+type Pagination struct {
+	Total      *int64 `json:"total,omitempty"`
+	Page       int    `json:"page,omitempty"`
+	PageSize   int    `json:"page_size,omitempty"`
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset"`
+	Next       string `json:"next,omitempty"`
+	Prev       string `json:"prev,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+type MovieRevision struct {
+	Revision  int             `json:"revision"`
+	Action    string          `json:"action"`
+	Actor     string          `json:"actor"`
+	Snapshot  json.RawMessage `json:"snapshot"`
+	Diff      json.RawMessage `json:"diff"`
+	CreatedAt string          `json:"created_at"`
+}
+
+type BulkMovie struct {
+	Mode      string            `json:"mode"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Results   []BulkMovieResult `json:"results"`
+}
+
+type BulkMovieResult struct {
+	Operation string `json:"operation"`
+	Index     int    `json:"index"`
+	ID        int64  `json:"id,omitempty"`
+	Status    int    `json:"status"`
+	Error     string `json:"error,omitempty"`
+	Errors    any    `json:"errors,omitempty"`
+	Err       error  `json:"-"`
+}
+
+type ImportJob struct {
+	ID          int64             `json:"id"`
+	Status      string            `json:"status"`
+	Format      string            `json:"format"`
+	Filename    string            `json:"filename"`
+	Mapping     map[string]string `json:"mapping"`
+	Processed   int64             `json:"processed"`
+	Inserted    int64             `json:"inserted"`
+	Skipped     int64             `json:"skipped"`
+	Failed      int64             `json:"failed"`
+	Error       string            `json:"error,omitempty"`
+	ErrorReport string            `json:"error_report,omitempty"`
+	CreatedAt   string            `json:"created_at"`
+	StartedAt   string            `json:"started_at,omitempty"`
+	FinishedAt  string            `json:"finished_at,omitempty"`
+}
+
+type ImportJobError struct {
+	Row     int64  `json:"row"`
+	Message string `json:"message"`
+	Raw     string `json:"raw"`
+}
+
+type DuplicateCandidate struct {
+	ID          int64   `json:"id"`
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	Score       float64 `json:"score"`
+}
+
+type DuplicateCluster struct {
+	Score  float64     `json:"score"`
+	Movies []ListMovie `json:"movies"`
+}
+
+type Genre struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type Person struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	Bio       string `json:"bio"`
+	BirthDate string `json:"birth_date,omitempty"`
+	Photo     string `json:"photo"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type MovieCredit struct {
+	ID           int64  `json:"id"`
+	PersonID     int64  `json:"person_id"`
+	Name         string `json:"name"`
+	Photo        string `json:"photo"`
+	Role         string `json:"role"`
+	Character    string `json:"character,omitempty"`
+	BillingOrder int    `json:"billing_order"`
+}
+
+type PersonCredit struct {
+	ID           int64   `json:"id"`
+	MovieID      int64   `json:"movie_id"`
+	Title        string  `json:"title"`
+	Image        string  `json:"image"`
+	Rating       float32 `json:"rating"`
+	Role         string  `json:"role"`
+	Character    string  `json:"character,omitempty"`
+	BillingOrder int     `json:"billing_order"`
+}
+
+type MovieImage struct {
+	ID         int64             `json:"id"`
+	Kind       string            `json:"kind"`
+	URL        string            `json:"url"`
+	Width      int               `json:"width"`
+	Height     int               `json:"height"`
+	Language   string            `json:"language,omitempty"`
+	Primary    bool              `json:"primary"`
+	Position   int               `json:"position"`
+	Thumbnails map[string]string `json:"thumbnails,omitempty"`
+}
+
+type ImageFile struct {
+	ContentType  string
+	Size         int64
+	ETag         string
+	LastModified time.Time
+	Body         io.ReadCloser
 }
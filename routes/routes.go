@@ -2,8 +2,13 @@ package routes
 
 import (
 	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
 	"gorm.io/gorm"
+	"log"
 	"net/http"
+	"os"
+	"strconv"
+	"time"
 	"xsis-code-test/app"
 	AppHandler "xsis-code-test/app/handlers"
 	AppRepo "xsis-code-test/app/repository"
@@ -14,18 +19,98 @@ func implementHandler(appHandler app.IAppHandlers) app.IAppHandlers {
 	return appHandler
 }
 
-func AppRoutes(db *gorm.DB) http.Handler {
+func NewAppUsecase(db *gorm.DB) *AppUsecase.AppUsecase {
 	appRepo := AppRepo.NewAppRepository(db)
-	appUsecase := AppUsecase.NewAppUsecase(appRepo)
+	suggestRepo := AppRepo.NewMemorySuggestRepository()
+	appUsecase := AppUsecase.NewAppUsecase(appRepo, suggestRepo)
+	appUsecase.AppBlobRepository = blobStore()
+	if err := appUsecase.LoadSuggestion(); err != nil {
+		log.Println("Cannot Load Movie Suggestion Index", err)
+	}
+	return appUsecase
+}
+
+func AppRoutes(db *gorm.DB, appUsecase *AppUsecase.AppUsecase) http.Handler {
 	appHandler := AppHandler.NewAppHandler(appUsecase)
+	appHandler.CacheControl = os.Getenv("MOVIE_CACHE_CONTROL")
 	implHandler := implementHandler(appHandler)
 	route := chi.NewMux()
+	route.Use(middleware.RequestID)
+	route.Use(AppHandler.Idempotency(idempotencyStore(db), idempotencyTTL()))
 
 	route.Post("/Movie", implHandler.CreateMovie)
 	route.Get("/Movie", implHandler.ListMovie)
+	route.Get("/Movie/search", implHandler.SearchMovie)
+	route.Get("/Movie/suggest", implHandler.SuggestMovie)
+	route.Get("/Movie/export", implHandler.ExportMovie)
+	route.Get("/Movie/duplicates", implHandler.ListDuplicates)
+	route.Post("/Movie/bulk", implHandler.BulkMovie)
+	route.Post("/Movie/import", implHandler.ImportMovie)
+	route.Get("/Movie/import/{id}", implHandler.GetImportJob)
+	route.Get("/Movie/import/{id}/errors", implHandler.GetImportJobErrors)
+	route.Get("/Movie/trash", implHandler.ListTrash)
 	route.Get("/Movie/{id}", implHandler.GetMovie)
 	route.Patch("/Movie/{id}", implHandler.UpdateMovie)
+	route.Put("/Movie/{id}", implHandler.ReplaceMovie)
 	route.Delete("/Movie/{id}", implHandler.DeleteMovie)
+	route.Post("/Movie/{id}/restore", implHandler.RestoreMovie)
+	route.Post("/Movie/{id}/merge", implHandler.MergeMovie)
+	route.Get("/Movie/{id}/revisions", implHandler.ListMovieRevision)
+	route.Get("/Movie/{id}/revisions/{rev}", implHandler.GetMovieRevision)
+	route.Post("/Movie/{id}/revisions/{rev}/revert", implHandler.RevertMovie)
+	route.Get("/Movie/{id}/credits", implHandler.ListMovieCredits)
+	route.Post("/Movie/{id}/credits", implHandler.CreateMovieCredit)
+	route.Delete("/Movie/{id}/credits/{creditId}", implHandler.DeleteMovieCredit)
+	route.Get("/Movie/{id}/images", implHandler.ListMovieImages)
+	route.Post("/Movie/{id}/images", implHandler.CreateMovieImage)
+	route.Post("/Movie/{id}/images/upload", implHandler.UploadMovieImage)
+	route.Put("/Movie/{id}/images/order", implHandler.ReorderMovieImages)
+	route.Delete("/Movie/{id}/images/{imageId}", implHandler.DeleteMovieImage)
+
+	route.Get("/images/*", implHandler.GetImage)
+
+	route.Post("/Genre", implHandler.CreateGenre)
+	route.Get("/Genre", implHandler.ListGenre)
+	route.Get("/Genre/{id}", implHandler.GetGenre)
+	route.Put("/Genre/{id}", implHandler.UpdateGenre)
+	route.Delete("/Genre/{id}", implHandler.DeleteGenre)
+	route.Get("/Genre/{id}/movies", implHandler.ListGenreMovie)
+
+	route.Post("/Person", implHandler.CreatePerson)
+	route.Get("/Person", implHandler.ListPerson)
+	route.Get("/Person/{id}", implHandler.GetPerson)
+	route.Put("/Person/{id}", implHandler.UpdatePerson)
+	route.Delete("/Person/{id}", implHandler.DeletePerson)
+	route.Get("/Person/{id}/filmography", implHandler.ListPersonFilmography)
 
 	return route
 }
+
+func idempotencyStore(db *gorm.DB) app.IAppIdempotencyRepository {
+	if os.Getenv("IDEMPOTENCY_STORE") == "memory" {
+		return AppRepo.NewMemoryIdempotencyRepository()
+	}
+	return AppRepo.NewAppRepository(db)
+}
+
+func blobStore() app.IAppBlobRepository {
+	if os.Getenv("BLOB_STORE") == "s3" {
+		region := os.Getenv("S3_REGION")
+		if region == "" {
+			region = "us-east-1"
+		}
+		return AppRepo.NewS3BlobRepository(os.Getenv("S3_ENDPOINT"), region, os.Getenv("S3_BUCKET"),
+			os.Getenv("S3_ACCESS_KEY_ID"), os.Getenv("S3_SECRET_ACCESS_KEY"))
+	}
+	if dir := os.Getenv("BLOB_LOCAL_DIR"); dir != "" {
+		return AppRepo.NewLocalBlobRepository(dir)
+	}
+	return AppRepo.NewLocalBlobRepository("uploads")
+}
+
+func idempotencyTTL() time.Duration {
+	if hours, _ := strconv.Atoi(os.Getenv("IDEMPOTENCY_TTL_HOURS")); hours > 0 {
+		return time.Duration(hours) * time.Hour
+	}
+	return 24 * time.Hour
+}
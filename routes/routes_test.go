@@ -0,0 +1,59 @@
+package routes
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"xsis-code-test/utils"
+)
+
+func TestNewAppUsecase_PurgesExpiredTrash(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("BLOB_STORE", "")
+	t.Setenv("BLOB_LOCAL_DIR", root)
+
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectQuery("SELECT \"id\",\"title\" FROM \"movies\" WHERE deleted_at is null").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(7, "Expired Title"))
+	appUsecase := NewAppUsecase(db)
+	assert.Nil(t, appUsecase.AppBlobRepository.PutBlob("movies/7/abc/original.png", []byte("png"), utils.ImagePNG))
+	blobPath := filepath.Join(root, "movies", "7", "abc", "original.png")
+	_, err = os.Stat(blobPath)
+	assert.Nil(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM \"movie_revisions\" WHERE movie_id IN .+").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM \"movie_genres\" WHERE movie_id IN .+").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM \"credits\" WHERE movie_id IN .+").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("DELETE FROM \"movie_images\" WHERE movie_id IN .+ RETURNING \\*").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "movie_id", "url", "thumbnails"}).
+			AddRow(3, 7, "/images/movies/7/abc/original.png", `{"original":"/images/movies/7/abc/original.png"}`))
+	mock.ExpectQuery("DELETE FROM \"movies\" WHERE deleted_at is not null AND deleted_at < .+ RETURNING \"id\"").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	mock.ExpectCommit()
+	mock.ExpectQuery("SELECT image FROM movies WHERE image IN .+ UNION SELECT url FROM movie_images WHERE url IN .+").
+		WithArgs("/images/movies/7/abc/original.png", "/images/movies/7/abc/original.png").
+		WillReturnRows(sqlmock.NewRows([]string{"image"}))
+
+	purged, err := appUsecase.PurgeExpiredTrash(30 * 24 * time.Hour)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), purged)
+	assert.Empty(t, appUsecase.AppSuggestRepository.SuggestTitle("expired", 5))
+	_, err = os.Stat(blobPath)
+	assert.True(t, os.IsNotExist(err))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultCacheControl = "no-cache"
+
+type Cache struct {
+	ETag         string
+	LastModified time.Time
+	CacheControl string
+}
+
+func WriteCachedJson(w http.ResponseWriter, r *http.Request, status int, data any, cache Cache) error {
+	out, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	if cache.ETag == "" {
+		sum := sha256.Sum256(out)
+		cache.ETag = `"` + base64.RawURLEncoding.EncodeToString(sum[:18]) + `"`
+	}
+	if cache.CacheControl == "" {
+		cache.CacheControl = defaultCacheControl
+	}
+
+	headers := http.Header{}
+	headers.Set("ETag", cache.ETag)
+	headers.Set("Cache-Control", cache.CacheControl)
+	if !cache.LastModified.IsZero() {
+		headers.Set("Last-Modified", cache.LastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if notModified(r, cache) {
+		for key, value := range headers {
+			w.Header()[key] = value
+		}
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+	return writeBody(w, status, out, headers)
+}
+
+func WriteCachedFile(w http.ResponseWriter, r *http.Request, contentType string, size int64, body io.Reader, cache Cache) error {
+	if cache.CacheControl == "" {
+		cache.CacheControl = defaultCacheControl
+	}
+	w.Header().Set("Cache-Control", cache.CacheControl)
+	if cache.ETag != "" {
+		w.Header().Set("ETag", cache.ETag)
+	}
+	if !cache.LastModified.IsZero() {
+		w.Header().Set("Last-Modified", cache.LastModified.UTC().Format(http.TimeFormat))
+	}
+	if notModified(r, cache) {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if size >= 0 {
+		w.Header().Set("Content-Length", strconv.FormatInt(size, 10))
+	}
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodHead {
+		return nil
+	}
+	_, err := io.Copy(w, body)
+	return err
+}
+
+func notModified(r *http.Request, cache Cache) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+
+	if ifNoneMatch := r.Header.Values("If-None-Match"); len(ifNoneMatch) > 0 {
+		etag := strings.TrimPrefix(cache.ETag, "W/")
+		for _, value := range ifNoneMatch {
+			for _, candidate := range strings.Split(value, ",") {
+				candidate = strings.TrimSpace(candidate)
+				if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	if ifModifiedSince := r.Header.Get("If-Modified-Since"); ifModifiedSince != "" && !cache.LastModified.IsZero() {
+		since, err := http.ParseTime(ifModifiedSince)
+		if err != nil {
+			return false
+		}
+		return !cache.LastModified.Truncate(time.Second).After(since)
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteCachedJson(t *testing.T) {
+	modified := time.Date(2024, 1, 13, 10, 30, 15, 500, time.UTC)
+	data := map[string]string{"title": "Dans 1"}
+
+	first := httptest.NewRecorder()
+	if err := WriteCachedJson(first, httptest.NewRequest("GET", "/Movie", nil), http.StatusOK, data, Cache{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	etag := first.Header().Get("ETag")
+	if len(etag) < 3 || etag[0] != '"' || etag[len(etag)-1] != '"' {
+		t.Fatalf("expected strong etag, got %s", etag)
+	}
+	if first.Header().Get("Cache-Control") != "no-cache" {
+		t.Errorf("expected default cache control, got %s", first.Header().Get("Cache-Control"))
+	}
+
+	testCases := []struct {
+		name           string
+		method         string
+		headers        map[string]string
+		cache          Cache
+		expectedStatus int
+	}{
+		{name: "No validators", method: "GET", cache: Cache{LastModified: modified}, expectedStatus: http.StatusOK},
+		{name: "Matching hash etag", method: "GET", headers: map[string]string{"If-None-Match": etag}, expectedStatus: http.StatusNotModified},
+		{name: "Weak match", method: "GET", headers: map[string]string{"If-None-Match": `"x", W/` + etag}, expectedStatus: http.StatusNotModified},
+		{name: "Stale etag", method: "GET", headers: map[string]string{"If-None-Match": `"stale"`}, expectedStatus: http.StatusOK},
+		{name: "Wildcard", method: "GET", headers: map[string]string{"If-None-Match": "*"}, expectedStatus: http.StatusNotModified},
+		{name: "Explicit etag", method: "GET", headers: map[string]string{"If-None-Match": `"4"`}, cache: Cache{ETag: `"4"`}, expectedStatus: http.StatusNotModified},
+		{name: "Not modified since", method: "GET", headers: map[string]string{"If-Modified-Since": modified.Format(http.TimeFormat)}, cache: Cache{LastModified: modified}, expectedStatus: http.StatusNotModified},
+		{name: "Modified since", method: "GET", headers: map[string]string{"If-Modified-Since": modified.Add(-time.Minute).Format(http.TimeFormat)}, cache: Cache{LastModified: modified}, expectedStatus: http.StatusOK},
+		{name: "Etag wins over date", method: "GET", headers: map[string]string{"If-None-Match": `"stale"`, "If-Modified-Since": modified.Format(http.TimeFormat)}, cache: Cache{LastModified: modified}, expectedStatus: http.StatusOK},
+		{name: "Not a read", method: "POST", headers: map[string]string{"If-None-Match": "*"}, expectedStatus: http.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(tc.method, "/Movie", nil)
+			for key, value := range tc.headers {
+				r.Header.Set(key, value)
+			}
+			tc.cache.CacheControl = "public, max-age=60"
+			if err := WriteCachedJson(w, r, http.StatusOK, data, tc.cache); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if w.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d", tc.expectedStatus, w.Code)
+			}
+			if w.Code == http.StatusNotModified && w.Body.Len() != 0 {
+				t.Errorf("expected empty body, got %s", w.Body.String())
+			}
+			if w.Header().Get("ETag") == "" || w.Header().Get("Cache-Control") != "public, max-age=60" {
+				t.Errorf("expected cache headers, got %v", w.Header())
+			}
+			if !tc.cache.LastModified.IsZero() && w.Header().Get("Last-Modified") != "Sat, 13 Jan 2024 10:30:15 GMT" {
+				t.Errorf("unexpected last modified %s", w.Header().Get("Last-Modified"))
+			}
+		})
+	}
+}
+
+func TestWriteCachedFile(t *testing.T) {
+	cache := Cache{ETag: `"abc"`, LastModified: time.Date(2024, 1, 13, 10, 30, 15, 0, time.UTC), CacheControl: "public, max-age=31536000, immutable"}
+
+	w := httptest.NewRecorder()
+	if err := WriteCachedFile(w, httptest.NewRequest("GET", "/images/a.png", nil), "image/png", 3, strings.NewReader("png"), cache); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if w.Code != http.StatusOK || w.Body.String() != "png" {
+		t.Fatalf("expected file body, got %d %s", w.Code, w.Body.String())
+	}
+	if w.Header().Get("Content-Type") != "image/png" || w.Header().Get("Content-Length") != "3" || w.Header().Get("ETag") != `"abc"` {
+		t.Errorf("unexpected headers %v", w.Header())
+	}
+	if w.Header().Get("Cache-Control") != "public, max-age=31536000, immutable" || w.Header().Get("X-Content-Type-Options") != "nosniff" {
+		t.Errorf("unexpected headers %v", w.Header())
+	}
+
+	w = httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/images/a.png", nil)
+	r.Header.Set("If-None-Match", `"abc"`)
+	if err := WriteCachedFile(w, r, "image/png", 3, strings.NewReader("png"), cache); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if w.Code != http.StatusNotModified || w.Body.Len() != 0 || w.Header().Get("ETag") != `"abc"` {
+		t.Errorf("expected not modified, got %d %v", w.Code, w.Header())
+	}
+
+	w = httptest.NewRecorder()
+	if err := WriteCachedFile(w, httptest.NewRequest("HEAD", "/images/a.png", nil), "image/png", 3, strings.NewReader("png"), cache); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if w.Code != http.StatusOK || w.Body.Len() != 0 || w.Header().Get("Content-Length") != "3" {
+		t.Errorf("expected headers only, got %d %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"xsis-code-test/apperror"
+)
+
+var (
+	cursorSecret     []byte
+	cursorSecretOnce sync.Once
+)
+
+func getCursorSecret() []byte {
+	cursorSecretOnce.Do(func() {
+		cursorSecret = []byte(os.Getenv("CURSOR_SECRET"))
+		if len(cursorSecret) == 0 {
+			log.Println("CURSOR_SECRET is not set, cursors will not survive a restart")
+			cursorSecret = make([]byte, 32)
+			rand.Read(cursorSecret)
+		}
+	})
+	return cursorSecret
+}
+
+func EncodeCursor(payload any) (string, error) {
+	out, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	body := base64.RawURLEncoding.EncodeToString(out)
+	return body + "." + signCursor(body), nil
+}
+
+func DecodeCursor(cursor string, payload any) error {
+	body, signature, found := strings.Cut(cursor, ".")
+	if !found || !hmac.Equal([]byte(signature), []byte(signCursor(body))) {
+		return apperror.BadRequest("Invalid Cursor")
+	}
+	out, err := base64.RawURLEncoding.DecodeString(body)
+	if err != nil {
+		return apperror.BadRequest("Invalid Cursor")
+	}
+	if err := json.Unmarshal(out, payload); err != nil {
+		return apperror.BadRequest("Invalid Cursor")
+	}
+	return nil
+}
+
+func signCursor(body string) string {
+	mac := hmac.New(sha256.New, getCursorSecret())
+	mac.Write([]byte(body))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
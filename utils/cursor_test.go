@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCursor(t *testing.T) {
+	type payload struct {
+		Sort   []string `json:"s"`
+		Values []any    `json:"v"`
+	}
+
+	cursor, err := EncodeCursor(payload{Sort: []string{"-rating", "id"}, Values: []any{7.5, 12}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded payload
+	if err := DecodeCursor(cursor, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decoded.Sort[0] != "-rating" || decoded.Values[1] != float64(12) {
+		t.Errorf("unexpected payload %v", decoded)
+	}
+
+	body, signature, _ := strings.Cut(cursor, ".")
+	testCases := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "Missing signature", cursor: body},
+		{name: "Tampered body", cursor: body + "A." + signature},
+		{name: "Tampered signature", cursor: body + "." + signature[1:]},
+		{name: "Garbage", cursor: "not-a-cursor"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := DecodeCursor(tc.cursor, &decoded); err == nil {
+				t.Errorf("expected error for %q", tc.cursor)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package utils
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5/middleware"
+	"log"
+	"mime"
+	"net/http"
+	"strings"
+	"xsis-code-test/apperror"
+)
+
+const problemContentType = "application/problem+json"
+
+var errorStatus = map[apperror.Kind]int{
+	apperror.KindBadRequest:           http.StatusBadRequest,
+	apperror.KindNotFound:             http.StatusNotFound,
+	apperror.KindConflict:             http.StatusConflict,
+	apperror.KindValidation:           http.StatusUnprocessableEntity,
+	apperror.KindPreconditionFailed:   http.StatusPreconditionFailed,
+	apperror.KindUnsupportedMediaType: http.StatusUnsupportedMediaType,
+	apperror.KindPayloadTooLarge:      http.StatusRequestEntityTooLarge,
+	apperror.KindUnavailable:          http.StatusServiceUnavailable,
+	apperror.KindInternal:             http.StatusInternalServerError,
+}
+
+var problemTypes = map[int]string{
+	http.StatusBadRequest:            "/problems/bad-request",
+	http.StatusNotFound:              "/problems/not-found",
+	http.StatusConflict:              "/problems/conflict",
+	http.StatusUnprocessableEntity:   "/problems/validation-error",
+	http.StatusPreconditionFailed:    "/problems/precondition-failed",
+	http.StatusRequestEntityTooLarge: "/problems/payload-too-large",
+	http.StatusUnsupportedMediaType:  "/problems/unsupported-media-type",
+	http.StatusServiceUnavailable:    "/problems/service-unavailable",
+	http.StatusInternalServerError:   "/problems/internal-error",
+}
+
+type Problem struct {
+	Type      string           `json:"type"`
+	Title     string           `json:"title"`
+	Status    int              `json:"status"`
+	Detail    string           `json:"detail,omitempty"`
+	Instance  string           `json:"instance,omitempty"`
+	RequestID string           `json:"request_id,omitempty"`
+	Errors    ValidationErrors `json:"errors,omitempty"`
+	Details   any              `json:"details,omitempty"`
+}
+
+func ErrorStatus(err error) int {
+	var validationErrors ValidationErrors
+	if errors.As(err, &validationErrors) {
+		return http.StatusUnprocessableEntity
+	}
+	return errorStatus[apperror.KindOf(err)]
+}
+
+func WriteError(w http.ResponseWriter, r *http.Request, err error) error {
+	status := ErrorStatus(err)
+	var appErr *apperror.Error
+	if status == http.StatusInternalServerError && !errors.As(err, &appErr) {
+		log.Println(err.Error())
+		err = errors.New("Internal Server Error")
+	}
+
+	var validationErrors ValidationErrors
+	errors.As(err, &validationErrors)
+
+	if prefersLegacyError(r) {
+		if validationErrors != nil {
+			return ValidationErrorJson(w, validationErrors)
+		}
+		if details := apperror.DetailsOf(err); details != nil {
+			return WriteJson(w, status, JSONResponse{Error: true, Message: err.Error(), Data: details})
+		}
+		return ErrorJson(w, err, status)
+	}
+
+	problem := Problem{
+		Type:      problemType(status),
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    err.Error(),
+		Instance:  r.URL.RequestURI(),
+		RequestID: middleware.GetReqID(r.Context()),
+		Errors:    validationErrors,
+		Details:   apperror.DetailsOf(err),
+	}
+	if validationErrors != nil {
+		problem.Detail = "Validation Failed"
+	}
+	return ProblemJson(w, problem)
+}
+
+func ProblemJson(w http.ResponseWriter, problem Problem) error {
+	headers := http.Header{"Content-Type": []string{problemContentType}}
+	if problem.RequestID != "" {
+		headers.Set(middleware.RequestIDHeader, problem.RequestID)
+	}
+	return WriteJson(w, problem.Status, problem, headers)
+}
+
+func problemType(status int) string {
+	if problemType, ok := problemTypes[status]; ok {
+		return problemType
+	}
+	return "about:blank"
+}
+
+func prefersLegacyError(r *http.Request) bool {
+	legacy := false
+	for _, accept := range r.Header.Values("Accept") {
+		for _, mediaRange := range strings.Split(accept, ",") {
+			mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+			if err != nil {
+				continue
+			}
+			switch mediaType {
+			case problemContentType:
+				return false
+			case "application/json":
+				legacy = true
+			}
+		}
+	}
+	return legacy
+}
@@ -0,0 +1,160 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5/middleware"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"xsis-code-test/apperror"
+)
+
+func TestWriteError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            error
+		expectedBody   string
+		expectedStatus int
+	}{
+		{
+			name:           "Not found",
+			err:            apperror.NotFound("Movie Not Found"),
+			expectedBody:   `{"type":"/problems/not-found","title":"Not Found","status":404,"detail":"Movie Not Found","instance":"/Movie/7?x=1","request_id":"req-1"}`,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Conflict",
+			err:            apperror.Conflict("Record Already Exists"),
+			expectedBody:   `{"type":"/problems/conflict","title":"Conflict","status":409,"detail":"Record Already Exists","instance":"/Movie/7?x=1","request_id":"req-1"}`,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "Conflict with details",
+			err:            apperror.WithDetails(apperror.Conflict("Movie May Be A Duplicate"), []map[string]int64{{"id": 3}}),
+			expectedBody:   `{"type":"/problems/conflict","title":"Conflict","status":409,"detail":"Movie May Be A Duplicate","instance":"/Movie/7?x=1","request_id":"req-1","details":[{"id":3}]}`,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "Validation",
+			err:            apperror.Validation("Title Cannot Be Empty"),
+			expectedBody:   `{"type":"/problems/validation-error","title":"Unprocessable Entity","status":422,"detail":"Title Cannot Be Empty","instance":"/Movie/7?x=1","request_id":"req-1"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Validation errors",
+			err:            ValidationErrors{{Field: "title", Code: "required", Message: "Title Cannot Be Empty", Value: ""}},
+			expectedBody:   `{"type":"/problems/validation-error","title":"Unprocessable Entity","status":422,"detail":"Validation Failed","instance":"/Movie/7?x=1","request_id":"req-1","errors":[{"field":"title","code":"required","message":"Title Cannot Be Empty","rejected_value":""}]}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Precondition failed",
+			err:            apperror.PreconditionFailed("Movie Has Been Modified"),
+			expectedBody:   `{"type":"/problems/precondition-failed","title":"Precondition Failed","status":412,"detail":"Movie Has Been Modified","instance":"/Movie/7?x=1","request_id":"req-1"}`,
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:           "Payload too large",
+			err:            apperror.PayloadTooLarge("file Cannot Be Larger Than 10 MB"),
+			expectedBody:   `{"type":"/problems/payload-too-large","title":"Request Entity Too Large","status":413,"detail":"file Cannot Be Larger Than 10 MB","instance":"/Movie/7?x=1","request_id":"req-1"}`,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:           "Unavailable",
+			err:            apperror.Unavailable("Database Unavailable", errors.New("dial tcp: connection refused")),
+			expectedBody:   `{"type":"/problems/service-unavailable","title":"Service Unavailable","status":503,"detail":"Database Unavailable","instance":"/Movie/7?x=1","request_id":"req-1"}`,
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:           "Unknown error is not leaked",
+			err:            errors.New("pq: password authentication failed"),
+			expectedBody:   `{"type":"/problems/internal-error","title":"Internal Server Error","status":500,"detail":"Internal Server Error","instance":"/Movie/7?x=1","request_id":"req-1"}`,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "/Movie/7?x=1", nil)
+			r = r.WithContext(context.WithValue(r.Context(), middleware.RequestIDKey, "req-1"))
+			if err := WriteError(w, r, tc.err); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if w.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d", tc.expectedStatus, w.Code)
+			}
+			if w.Header().Get("Content-Type") != "application/problem+json" {
+				t.Errorf("expected problem content type, got %s", w.Header().Get("Content-Type"))
+			}
+			if w.Header().Get("X-Request-Id") != "req-1" {
+				t.Errorf("expected request id header, got %s", w.Header().Get("X-Request-Id"))
+			}
+			if w.Body.String() != tc.expectedBody {
+				t.Errorf("expected body %s, got %s", tc.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestWriteError_ContentNegotiation(t *testing.T) {
+	testCases := []struct {
+		name                string
+		accept              []string
+		err                 error
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:                "No accept header",
+			err:                 apperror.NotFound("Movie Not Found"),
+			expectedContentType: "application/problem+json",
+			expectedBody:        `{"type":"/problems/not-found","title":"Not Found","status":404,"detail":"Movie Not Found","instance":"/Movie/7"}`,
+		},
+		{
+			name:                "Legacy json client",
+			accept:              []string{"application/json"},
+			err:                 apperror.NotFound("Movie Not Found"),
+			expectedContentType: "application/json",
+			expectedBody:        `{"error":true,"message":"Movie Not Found"}`,
+		},
+		{
+			name:                "Legacy validation envelope",
+			accept:              []string{"application/json; charset=utf-8"},
+			err:                 ValidationErrors{{Field: "title", Code: "required", Message: "Title Cannot Be Empty", Value: ""}},
+			expectedContentType: "application/json",
+			expectedBody:        `{"error":true,"message":"Validation Failed","data":[{"field":"title","code":"required","message":"Title Cannot Be Empty","rejected_value":""}]}`,
+		},
+		{
+			name:                "Problem preferred over json",
+			accept:              []string{"application/json, application/problem+json;q=0.9"},
+			err:                 apperror.NotFound("Movie Not Found"),
+			expectedContentType: "application/problem+json",
+			expectedBody:        `{"type":"/problems/not-found","title":"Not Found","status":404,"detail":"Movie Not Found","instance":"/Movie/7"}`,
+		},
+		{
+			name:                "Wildcard",
+			accept:              []string{"*/*"},
+			err:                 apperror.NotFound("Movie Not Found"),
+			expectedContentType: "application/problem+json",
+			expectedBody:        `{"type":"/problems/not-found","title":"Not Found","status":404,"detail":"Movie Not Found","instance":"/Movie/7"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "/Movie/7", nil)
+			for _, accept := range tc.accept {
+				r.Header.Add("Accept", accept)
+			}
+			WriteError(w, r, tc.err)
+			if w.Header().Get("Content-Type") != tc.expectedContentType {
+				t.Errorf("expected content type %s, got %s", tc.expectedContentType, w.Header().Get("Content-Type"))
+			}
+			if w.Body.String() != tc.expectedBody {
+				t.Errorf("expected body %s, got %s", tc.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+)
+
+const (
+	ImageJPEG = "image/jpeg"
+	ImagePNG  = "image/png"
+	ImageGIF  = "image/gif"
+)
+
+var imageMagic = []struct {
+	contentType string
+	magic       []byte
+}{
+	{ImageJPEG, []byte{0xFF, 0xD8, 0xFF}},
+	{ImagePNG, []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1A, '\n'}},
+	{ImageGIF, []byte("GIF87a")},
+	{ImageGIF, []byte("GIF89a")},
+}
+
+func SniffImage(data []byte) string {
+	for _, candidate := range imageMagic {
+		if bytes.HasPrefix(data, candidate.magic) {
+			return candidate.contentType
+		}
+	}
+	return ""
+}
+
+func DecodeImageConfig(data []byte, contentType string) (image.Config, error) {
+	reader := bytes.NewReader(data)
+	switch contentType {
+	case ImageJPEG:
+		return jpeg.DecodeConfig(reader)
+	case ImagePNG:
+		return png.DecodeConfig(reader)
+	case ImageGIF:
+		return gif.DecodeConfig(reader)
+	}
+	return image.Config{}, image.ErrFormat
+}
+
+func DecodeImage(data []byte, contentType string) (*image.RGBA, error) {
+	reader := bytes.NewReader(data)
+	var (
+		img image.Image
+		err error
+	)
+	switch contentType {
+	case ImageJPEG:
+		img, err = jpeg.Decode(reader)
+	case ImagePNG:
+		img, err = png.Decode(reader)
+	case ImageGIF:
+		img, err = gif.Decode(reader)
+	default:
+		err = image.ErrFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rgba := image.NewRGBA(image.Rect(0, 0, img.Bounds().Dx(), img.Bounds().Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, img.Bounds().Min, draw.Src)
+	if contentType == ImageJPEG {
+		return orientImage(rgba, jpegOrientation(data)), nil
+	}
+	return rgba, nil
+}
+
+func EncodeImage(w io.Writer, img image.Image, contentType string) error {
+	if contentType == ImageJPEG {
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: 90})
+	}
+	return png.Encode(w, img)
+}
+
+func ResizeImage(src *image.RGBA, width int) *image.RGBA {
+	srcWidth, srcHeight := src.Bounds().Dx(), src.Bounds().Dy()
+	if width <= 0 || width >= srcWidth {
+		return src
+	}
+	height := (srcHeight*width + srcWidth/2) / srcWidth
+	if height < 1 {
+		height = 1
+	}
+
+	columns := resampleWeights(srcWidth, width)
+	rows := resampleWeights(srcHeight, height)
+
+	horizontal := make([]float32, width*srcHeight*4)
+	for y := 0; y < srcHeight; y++ {
+		line := src.Pix[y*src.Stride:]
+		for x, weights := range columns {
+			var r, g, b, a float32
+			for _, weight := range weights {
+				pixel := line[weight.index*4:]
+				r += float32(pixel[0]) * weight.value
+				g += float32(pixel[1]) * weight.value
+				b += float32(pixel[2]) * weight.value
+				a += float32(pixel[3]) * weight.value
+			}
+			offset := (y*width + x) * 4
+			horizontal[offset], horizontal[offset+1], horizontal[offset+2], horizontal[offset+3] = r, g, b, a
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y, weights := range rows {
+		for x := 0; x < width; x++ {
+			var r, g, b, a float32
+			for _, weight := range weights {
+				offset := (weight.index*width + x) * 4
+				r += horizontal[offset] * weight.value
+				g += horizontal[offset+1] * weight.value
+				b += horizontal[offset+2] * weight.value
+				a += horizontal[offset+3] * weight.value
+			}
+			pixel := dst.Pix[y*dst.Stride+x*4:]
+			pixel[0], pixel[1], pixel[2], pixel[3] = clampChannel(r), clampChannel(g), clampChannel(b), clampChannel(a)
+		}
+	}
+	return dst
+}
+
+type resampleWeight struct {
+	index int
+	value float32
+}
+
+func resampleWeights(srcSize, dstSize int) [][]resampleWeight {
+	scale := float64(srcSize) / float64(dstSize)
+	weights := make([][]resampleWeight, dstSize)
+	for i := range weights {
+		start, end := float64(i)*scale, float64(i+1)*scale
+		for index := int(start); index < srcSize && float64(index) < end; index++ {
+			overlap := min(end, float64(index+1)) - max(start, float64(index))
+			if overlap > 0 {
+				weights[i] = append(weights[i], resampleWeight{index: index, value: float32(overlap / scale)})
+			}
+		}
+	}
+	return weights
+}
+
+func clampChannel(value float32) uint8 {
+	if value <= 0 {
+		return 0
+	}
+	if value >= 255 {
+		return 255
+	}
+	return uint8(value + 0.5)
+}
+
+func jpegOrientation(data []byte) int {
+	for offset := 2; offset+4 <= len(data); {
+		if data[offset] != 0xFF {
+			return 1
+		}
+		marker := data[offset+1]
+		if marker == 0xD8 || marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7) {
+			offset += 2
+			continue
+		}
+		if marker == 0xDA || marker == 0xD9 {
+			return 1
+		}
+		length := int(binary.BigEndian.Uint16(data[offset+2:]))
+		end := offset + 2 + length
+		if length < 2 || end > len(data) {
+			return 1
+		}
+		segment := data[offset+4 : end]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+		offset = end
+	}
+	return 1
+}
+
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 1
+	}
+	entries := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < entries; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			if orientation := int(order.Uint16(tiff[entry+8:])); orientation >= 1 && orientation <= 8 {
+				return orientation
+			}
+			return 1
+		}
+	}
+	return 1
+}
+
+func orientImage(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation <= 1 || orientation > 8 {
+		return src
+	}
+	width, height := src.Bounds().Dx(), src.Bounds().Dy()
+	dstWidth, dstHeight := width, height
+	if orientation >= 5 {
+		dstWidth, dstHeight = height, width
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2:
+				dx, dy = width-1-x, y
+			case 3:
+				dx, dy = width-1-x, height-1-y
+			case 4:
+				dx, dy = x, height-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = height-1-y, x
+			case 7:
+				dx, dy = height-1-y, width-1-x
+			case 8:
+				dx, dy = y, width-1-x
+			}
+			copy(dst.Pix[dy*dst.Stride+dx*4:dy*dst.Stride+dx*4+4], src.Pix[y*src.Stride+x*4:])
+		}
+	}
+	return dst
+}
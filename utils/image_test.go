@@ -0,0 +1,134 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func encodeTestImage(t *testing.T, width, height int, contentType string) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x * 255 / width), G: uint8(y * 255 / height), B: 128, A: 255})
+		}
+	}
+	var buffer bytes.Buffer
+	var err error
+	if contentType == ImagePNG {
+		err = png.Encode(&buffer, img)
+	} else {
+		err = jpeg.Encode(&buffer, img, nil)
+	}
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return buffer.Bytes()
+}
+
+func withExifOrientation(data []byte, orientation uint16) []byte {
+	tiff := []byte{'M', 'M', 0, 42, 0, 0, 0, 8, 0, 1}
+	entry := make([]byte, 12)
+	binary.BigEndian.PutUint16(entry[0:], 0x0112)
+	binary.BigEndian.PutUint16(entry[2:], 3)
+	binary.BigEndian.PutUint32(entry[4:], 1)
+	binary.BigEndian.PutUint16(entry[8:], orientation)
+	tiff = append(append(tiff, entry...), 0, 0, 0, 0)
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	result := append([]byte{}, data[:2]...)
+	result = append(result, segment...)
+	return append(result, data[2:]...)
+}
+
+func TestSniffImage(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     []byte
+		expected string
+	}{
+		{name: "JPEG", data: encodeTestImage(t, 4, 4, ImageJPEG), expected: ImageJPEG},
+		{name: "PNG", data: encodeTestImage(t, 4, 4, ImagePNG), expected: ImagePNG},
+		{name: "GIF", data: []byte("GIF89a..."), expected: ImageGIF},
+		{name: "SVG", data: []byte(`<svg xmlns="http://www.w3.org/2000/svg"/>`), expected: ""},
+		{name: "Empty", data: nil, expected: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if contentType := SniffImage(tc.data); contentType != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, contentType)
+			}
+		})
+	}
+}
+
+func TestDecodeImage_AppliesAndStripsExif(t *testing.T) {
+	data := withExifOrientation(encodeTestImage(t, 40, 20, ImageJPEG), 6)
+	config, err := DecodeImageConfig(data, ImageJPEG)
+	if err != nil || config.Width != 40 {
+		t.Fatalf("unexpected config %v %v", config, err)
+	}
+
+	img, err := DecodeImage(data, ImageJPEG)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if img.Bounds().Dx() != 20 || img.Bounds().Dy() != 40 {
+		t.Errorf("expected rotated 20x40 image, got %v", img.Bounds())
+	}
+
+	var buffer bytes.Buffer
+	if err := EncodeImage(&buffer, img, ImageJPEG); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bytes.Contains(buffer.Bytes(), []byte("Exif")) {
+		t.Errorf("expected EXIF to be stripped")
+	}
+
+	if _, err := DecodeImage([]byte("GIF89a broken"), ImageGIF); err == nil {
+		t.Errorf("expected broken image to fail")
+	}
+}
+
+func TestResizeImage(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 4; x++ {
+			if x%2 == 0 {
+				src.Set(x, y, color.RGBA{R: 255, A: 255})
+			} else {
+				src.Set(x, y, color.RGBA{B: 255, A: 255})
+			}
+		}
+	}
+
+	dst := ResizeImage(src, 2)
+	if dst.Bounds() != image.Rect(0, 0, 2, 1) {
+		t.Fatalf("unexpected bounds %v", dst.Bounds())
+	}
+	if pixel := dst.RGBAAt(0, 0); pixel != (color.RGBA{R: 128, B: 128, A: 255}) {
+		t.Errorf("expected averaged pixel, got %v", pixel)
+	}
+	if ResizeImage(src, 4) != src || ResizeImage(src, 10) != src {
+		t.Errorf("expected no upscaling")
+	}
+
+	img, err := DecodeImage(encodeTestImage(t, 1000, 1500, ImagePNG), ImagePNG)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for width, expected := range map[int]image.Rectangle{92: image.Rect(0, 0, 92, 138), 185: image.Rect(0, 0, 185, 278), 500: image.Rect(0, 0, 500, 750)} {
+		if bounds := ResizeImage(img, width).Bounds(); bounds != expected {
+			t.Errorf("expected %v, got %v", expected, bounds)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package utils
+
+import "strings"
+
+var languageCodes = codeSet(`
+aa ab ae af ak am an ar as av ay az ba be bg bh bi bm bn bo br bs ca ce ch co cr cs cu cv cy
+da de dv dz ee el en eo es et eu fa ff fi fj fo fr fy ga gd gl gn gu gv ha he hi ho hr ht hu
+hy hz ia id ie ig ii ik io is it iu ja jv ka kg ki kj kk kl km kn ko kr ks ku kv kw ky la lb
+lg li ln lo lt lu lv mg mh mi mk ml mn mr ms mt my na nb nd ne ng nl nn no nr nv ny oc oj om
+or os pa pi pl ps pt qu rm rn ro ru rw sa sc sd se sg si sk sl sm sn so sq sr ss st su sv sw
+ta te tg th ti tk tl tn to tr ts tt tw ty ug uk ur uz ve vi vo wa wo xh yi yo za zh zu
+`)
+
+var countryCodes = codeSet(`
+AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ BA BB BD BE BF BG BH BI BJ BL BM BN BO BQ BR
+BS BT BV BW BY BZ CA CC CD CF CG CH CI CK CL CM CN CO CR CU CV CW CX CY CZ DE DJ DK DM DO DZ
+EC EE EG EH ER ES ET FI FJ FK FM FO FR GA GB GD GE GF GG GH GI GL GM GN GP GQ GR GS GT GU GW
+GY HK HM HN HR HT HU ID IE IL IM IN IO IQ IR IS IT JE JM JO JP KE KG KH KI KM KN KP KR KW KY
+KZ LA LB LC LI LK LR LS LT LU LV LY MA MC MD ME MF MG MH MK ML MM MN MO MP MQ MR MS MT MU MV
+MW MX MY MZ NA NC NE NF NG NI NL NO NP NR NU NZ OM PA PE PF PG PH PK PL PM PN PR PS PT PW PY
+QA RE RO RS RU RW SA SB SC SD SE SG SH SI SJ SK SL SM SN SO SR SS ST SV SX SY SZ TC TD TF TG
+TH TJ TK TL TM TN TO TR TT TV TW TZ UA UG UM US UY UZ VA VC VE VG VI VN VU WF WS YE YT ZA ZM ZW
+`)
+
+var certificationCodes = map[string]map[string]bool{
+	"AU": codeSet("E G PG M MA15+ R18+ X18+ RC"),
+	"BR": codeSet("L 10 12 14 16 18"),
+	"CA": codeSet("G PG 14A 18A R A"),
+	"DE": codeSet("0 6 12 16 18"),
+	"ES": codeSet("APTA 7 12 16 18 X"),
+	"FR": codeSet("U 10 12 16 18"),
+	"GB": codeSet("U PG 12A 12 15 18 R18"),
+	"ID": codeSet("SU 13+ 17+ 21+"),
+	"IN": codeSet("U UA U/A A S"),
+	"IT": codeSet("T 6+ 14+ 18+"),
+	"JP": codeSet("G PG12 R15+ R18+"),
+	"KR": codeSet("ALL 12 15 18 Restricted"),
+	"MY": codeSet("U P13 18SG 18SX 18PA 18PL"),
+	"NL": codeSet("AL 6 9 12 14 16 18"),
+	"NZ": codeSet("G PG M R13 R15 R16 R18 R"),
+	"SG": codeSet("G PG PG13 NC16 M18 R21"),
+	"US": codeSet("G PG PG-13 R NC-17 NR"),
+}
+
+func codeSet(codes string) map[string]bool {
+	set := make(map[string]bool)
+	for _, code := range strings.Fields(codes) {
+		set[code] = true
+	}
+	return set
+}
+
+func IsLanguageCode(code string) bool {
+	return languageCodes[code]
+}
+
+func IsCountryCode(code string) bool {
+	return countryCodes[code]
+}
+
+func IsCertification(country, rating string) bool {
+	return certificationCodes[country][rating]
+}
+
+func NormalizeCodes(codes []string, upper bool) []string {
+	if codes == nil {
+		return nil
+	}
+	seen := make(map[string]bool, len(codes))
+	normalized := make([]string, 0, len(codes))
+	for _, code := range codes {
+		code = strings.TrimSpace(code)
+		if upper {
+			code = strings.ToUpper(code)
+		} else {
+			code = strings.ToLower(code)
+		}
+		if !seen[code] {
+			seen[code] = true
+			normalized = append(normalized, code)
+		}
+	}
+	return normalized
+}
@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeCodes(t *testing.T) {
+	if got := NormalizeCodes([]string{" EN", "fr", "en"}, false); !reflect.DeepEqual(got, []string{"en", "fr"}) {
+		t.Errorf("unexpected language codes %v", got)
+	}
+	if got := NormalizeCodes([]string{"us", "GB"}, true); !reflect.DeepEqual(got, []string{"US", "GB"}) {
+		t.Errorf("unexpected country codes %v", got)
+	}
+	if got := NormalizeCodes(nil, true); got != nil {
+		t.Errorf("expected nil codes, got %v", got)
+	}
+}
+
+func TestIsCertification(t *testing.T) {
+	if !IsCertification("US", "PG-13") || !IsCertification("GB", "12A") {
+		t.Error("expected known certifications to be valid")
+	}
+	if IsCertification("US", "12A") || IsCertification("XX", "PG") {
+		t.Error("expected foreign or unknown certifications to be invalid")
+	}
+}
@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"xsis-code-test/apperror"
+)
+
+func VersionETag(version int64) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+func IfMatchVersion(r *http.Request) (*int64, error) {
+	header := strings.TrimSpace(strings.Join(r.Header.Values("If-Match"), ","))
+	if header == "" || header == "*" {
+		return nil, nil
+	}
+	if strings.Contains(header, ",") {
+		return nil, apperror.BadRequest("If-Match must contain a single ETag")
+	}
+	if strings.HasPrefix(header, "W/") {
+		return nil, apperror.PreconditionFailed("If-Match requires a strong ETag")
+	}
+
+	unquoted, err := strconv.Unquote(header)
+	if err != nil {
+		return nil, apperror.BadRequest("If-Match is not a valid ETag")
+	}
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+	if err != nil {
+		return nil, apperror.PreconditionFailed("Movie Has Been Modified")
+	}
+	return &version, nil
+}
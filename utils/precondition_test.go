@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"net/http/httptest"
+	"testing"
+	"xsis-code-test/apperror"
+)
+
+func TestIfMatchVersion(t *testing.T) {
+	testCases := []struct {
+		name            string
+		header          string
+		expectedVersion int64
+		expectedKind    apperror.Kind
+		expectedError   bool
+	}{
+		{name: "Absent", header: ""},
+		{name: "Wildcard", header: "*"},
+		{name: "Strong ETag", header: VersionETag(3), expectedVersion: 3},
+		{name: "Weak ETag", header: `W/"3"`, expectedError: true, expectedKind: apperror.KindPreconditionFailed},
+		{name: "Unknown ETag", header: `"abc"`, expectedError: true, expectedKind: apperror.KindPreconditionFailed},
+		{name: "Unquoted", header: "3", expectedError: true, expectedKind: apperror.KindBadRequest},
+		{name: "Multiple ETags", header: `"3", "4"`, expectedError: true, expectedKind: apperror.KindBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest("PATCH", "/Movie/1", nil)
+			if tc.header != "" {
+				r.Header.Set("If-Match", tc.header)
+			}
+			version, err := IfMatchVersion(r)
+			if tc.expectedError {
+				if !apperror.Is(err, tc.expectedKind) {
+					t.Errorf("expected error kind %d, got %v", tc.expectedKind, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.expectedVersion == 0 && version != nil {
+				t.Errorf("expected no version, got %d", *version)
+			}
+			if tc.expectedVersion != 0 && (version == nil || *version != tc.expectedVersion) {
+				t.Errorf("expected version %d, got %v", tc.expectedVersion, version)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+func NormalizeText(text string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+func Trigrams(normalized string) map[string]struct{} {
+	trigrams := make(map[string]struct{})
+	for _, word := range strings.Fields(normalized) {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			trigrams[string(padded[i:i+3])] = struct{}{}
+		}
+	}
+	return trigrams
+}
+
+func TrigramSimilarity(a, b string) float64 {
+	left, right := Trigrams(NormalizeText(a)), Trigrams(NormalizeText(b))
+	if len(left) == 0 || len(right) == 0 {
+		return 0
+	}
+	shared := 0
+	for trigram := range left {
+		if _, ok := right[trigram]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(left)+len(right)-shared)
+}
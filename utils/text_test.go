@@ -0,0 +1,26 @@
+package utils
+
+import "testing"
+
+func TestNormalizeText(t *testing.T) {
+	if got := NormalizeText("  The Matrix: Reloaded!! "); got != "the matrix reloaded" {
+		t.Errorf("unexpected normalized text %q", got)
+	}
+}
+
+func TestTrigramSimilarity(t *testing.T) {
+	testcases := []struct {
+		a, b     string
+		min, max float64
+	}{
+		{a: "Inception", b: "inception!", min: 1, max: 1},
+		{a: "Inception", b: "Inceptoin", min: 0.3, max: 0.8},
+		{a: "Inception", b: "Frozen", min: 0, max: 0.1},
+		{a: "", b: "Frozen", min: 0, max: 0},
+	}
+	for _, tc := range testcases {
+		if got := TrigramSimilarity(tc.a, tc.b); got < tc.min || got > tc.max {
+			t.Errorf("similarity of %q and %q = %f, expected between %f and %f", tc.a, tc.b, got, tc.min, tc.max)
+		}
+	}
+}
@@ -11,6 +11,7 @@ type JSONResponse struct {
 	Error   bool   `json:"error"`
 	Message string `json:"message"`
 	Data    any    `json:"data,omitempty"`
+	Meta    any    `json:"meta,omitempty"`
 }
 
 func ReadJson(w http.ResponseWriter, r *http.Request, data any) error {
@@ -24,6 +25,10 @@ func ReadJson(w http.ResponseWriter, r *http.Request, data any) error {
 	}
 
 	err = dec.Decode(&struct{}{})
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return err
+	}
 	if err != io.EOF {
 		return errors.New("body must have any single json value")
 	}
@@ -36,16 +41,21 @@ func WriteJson(w http.ResponseWriter, status int, data any, headers ...http.Head
 	if err != nil {
 		return err
 	}
+	return writeBody(w, status, out, headers...)
+}
 
+func writeBody(w http.ResponseWriter, status int, out []byte, headers ...http.Header) error {
 	if len(headers) > 0 {
 		for key, value := range headers[0] {
 			w.Header()[key] = value
 		}
 	}
 
-	w.Header().Set("Content-Type", "application/json")
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/json")
+	}
 	w.WriteHeader(status)
-	_, err = w.Write(out)
+	_, err := w.Write(out)
 	if err != nil {
 		return err
 	}
@@ -49,7 +49,7 @@ func TestReadJson(t *testing.T) {
 				t.Errorf("expected error %v, got %v", tc.expectedError, err)
 			}
 
-			if data != tc.expectedData {
+			if !reflect.DeepEqual(data, tc.expectedData) {
 				t.Errorf("expected data %v, got %v", tc.expectedData, data)
 			}
 		})
@@ -0,0 +1,244 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const DateLayout = "2006-01-02"
+
+var imageExtensions = map[string]bool{
+	".jpg":  true,
+	".jpeg": true,
+	".png":  true,
+	".gif":  true,
+	".webp": true,
+	".avif": true,
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Value   any    `json:"rejected_value"`
+}
+
+type ValidationErrors []FieldError
+
+func (ve ValidationErrors) Error() string {
+	messages := make([]string, 0, len(ve))
+	for _, fieldError := range ve {
+		messages = append(messages, fieldError.Message)
+	}
+	return strings.Join(messages, ", ")
+}
+
+func Validate(data any) error {
+	value := reflect.Indirect(reflect.ValueOf(data))
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var validationErrors ValidationErrors
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		structField := valueType.Field(i)
+		rules := structField.Tag.Get("validate")
+		if rules == "" || !structField.IsExported() {
+			continue
+		}
+		name := fieldName(structField)
+		fieldValue := value.Field(i)
+
+		for _, rule := range strings.Split(rules, ",") {
+			code, param, _ := strings.Cut(rule, "=")
+			if fieldError := checkRule(name, code, param, fieldValue); fieldError != nil {
+				validationErrors = append(validationErrors, *fieldError)
+				break
+			}
+		}
+	}
+
+	if len(validationErrors) > 0 {
+		return validationErrors
+	}
+	return nil
+}
+
+func ParseDate(field, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse(DateLayout, value)
+	if err != nil {
+		message := fmt.Sprintf("%s Must Be A Date In YYYY-MM-DD Format", fieldLabel(field))
+		return nil, ValidationErrors{{Field: field, Code: "date", Message: message, Value: value}}
+	}
+	return &date, nil
+}
+
+func ValidationErrorJson(w http.ResponseWriter, validationErrors ValidationErrors) error {
+	payload := JSONResponse{
+		Error:   true,
+		Message: "Validation Failed",
+		Data:    validationErrors,
+	}
+	return WriteJson(w, http.StatusUnprocessableEntity, payload)
+}
+
+func checkRule(name, code, param string, value reflect.Value) *FieldError {
+	label := fieldLabel(name)
+	fail := func(message string) *FieldError {
+		return &FieldError{Field: name, Code: code, Message: message, Value: value.Interface()}
+	}
+
+	switch code {
+	case "required":
+		if value.IsZero() || (value.Kind() == reflect.String && strings.TrimSpace(value.String()) == "") {
+			return fail(fmt.Sprintf("%s Cannot Be Empty", label))
+		}
+	case "max":
+		limit, _ := strconv.Atoi(param)
+		if value.Kind() == reflect.String && utf8.RuneCountInString(value.String()) > limit {
+			return fail(fmt.Sprintf("%s Cannot Be Longer Than %d Characters", label, limit))
+		}
+	case "min":
+		limit, _ := strconv.Atoi(param)
+		if value.Kind() == reflect.String && utf8.RuneCountInString(value.String()) < limit {
+			return fail(fmt.Sprintf("%s Must Be At Least %d Characters", label, limit))
+		}
+	case "gte":
+		limit, _ := strconv.ParseFloat(param, 64)
+		if number, ok := numberOf(value); ok && number < limit {
+			return fail(fmt.Sprintf("%s Must Be Greater Than Or Equal To %s", label, param))
+		}
+	case "lte":
+		limit, _ := strconv.ParseFloat(param, 64)
+		if number, ok := numberOf(value); ok && number > limit {
+			return fail(fmt.Sprintf("%s Must Be Less Than Or Equal To %s", label, param))
+		}
+	case "decimals":
+		places, _ := strconv.Atoi(param)
+		scale := math.Pow(10, float64(places))
+		if number, ok := numberOf(value); ok && math.Abs(number*scale-math.Round(number*scale)) > 1e-4 {
+			return fail(fmt.Sprintf("%s Cannot Have More Than %d Decimal Places", label, places))
+		}
+	case "oneof":
+		options := strings.Fields(param)
+		if value.Kind() == reflect.String && value.String() != "" && !containsString(options, value.String()) {
+			return fail(fmt.Sprintf("%s Must Be One Of %s", label, strings.Join(options, ", ")))
+		}
+	case "date":
+		if value.Kind() == reflect.String && value.String() != "" {
+			if _, err := time.Parse(DateLayout, value.String()); err != nil {
+				return fail(fmt.Sprintf("%s Must Be A Date In YYYY-MM-DD Format", label))
+			}
+		}
+	case "language":
+		if value.Kind() == reflect.String && value.String() != "" && !IsLanguageCode(value.String()) {
+			return fail(fmt.Sprintf("%s Must Be An ISO 639-1 Language Code", label))
+		}
+	case "languages":
+		if !everyString(value, IsLanguageCode) {
+			return fail(fmt.Sprintf("%s Must Only Contain ISO 639-1 Language Codes", label))
+		}
+	case "countries":
+		if !everyString(value, IsCountryCode) {
+			return fail(fmt.Sprintf("%s Must Only Contain ISO 3166-1 Country Codes", label))
+		}
+	case "certifications":
+		if value.Kind() != reflect.Map {
+			break
+		}
+		iter := value.MapRange()
+		for iter.Next() {
+			country, rating := iter.Key().String(), iter.Value().String()
+			if !IsCountryCode(country) {
+				return fail(fmt.Sprintf("%s Must Be Keyed By ISO 3166-1 Country Codes", label))
+			}
+			if !IsCertification(country, rating) {
+				return fail(fmt.Sprintf("%s Has Unknown Rating %s For %s", label, rating, country))
+			}
+		}
+	case "imageurl":
+		if value.Kind() == reflect.String && value.String() != "" && !isImageURL(value.String()) {
+			return fail(fmt.Sprintf("%s Must Be A Valid Image URL", label))
+		}
+	}
+	return nil
+}
+
+func containsString(options []string, value string) bool {
+	for _, option := range options {
+		if option == value {
+			return true
+		}
+	}
+	return false
+}
+
+func everyString(value reflect.Value, valid func(string) bool) bool {
+	if value.Kind() != reflect.Slice {
+		return true
+	}
+	for i := 0; i < value.Len(); i++ {
+		if item := value.Index(i); item.Kind() != reflect.String || !valid(item.String()) {
+			return false
+		}
+	}
+	return true
+}
+
+func numberOf(value reflect.Value) (float64, bool) {
+	switch value.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), true
+	}
+	return 0, false
+}
+
+func isImageURL(raw string) bool {
+	if strings.ContainsAny(raw, " \t\r\n") {
+		return false
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	if parsed.Scheme != "" || parsed.Host != "" {
+		if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return false
+		}
+	}
+	return imageExtensions[strings.ToLower(path.Ext(parsed.Path))]
+}
+
+func fieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
+
+func fieldLabel(name string) string {
+	words := strings.Split(name, "_")
+	for i, word := range words {
+		if word != "" {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
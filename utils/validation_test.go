@@ -0,0 +1,174 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"xsis-code-test/models/request"
+)
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		name           string
+		data           request.CreateMovie
+		expectedFields []string
+		expectedCodes  []string
+	}{
+		{
+			name:           "Valid",
+			data:           request.CreateMovie{Title: "a", Description: "a", Rating: 7.5, Image: "https://cdn.example.com/a.jpg"},
+			expectedFields: []string{},
+			expectedCodes:  []string{},
+		},
+		{
+			name:           "Relative image path",
+			data:           request.CreateMovie{Title: "a", Description: "a", Rating: 10, Image: "posters/a.webp"},
+			expectedFields: []string{},
+			expectedCodes:  []string{},
+		},
+		{
+			name:           "All fields invalid",
+			data:           request.CreateMovie{Title: " ", Rating: 11, Image: "javascript:alert(1).png"},
+			expectedFields: []string{"title", "description", "rating", "image"},
+			expectedCodes:  []string{"required", "required", "lte", "imageurl"},
+		},
+		{
+			name:           "Title too long and rating precision",
+			data:           request.CreateMovie{Title: string(make([]byte, 256)), Description: "a", Rating: 7.25, Image: "a.txt"},
+			expectedFields: []string{"title", "rating", "image"},
+			expectedCodes:  []string{"max", "decimals", "imageurl"},
+		},
+		{
+			name:           "Negative rating",
+			data:           request.CreateMovie{Title: "a", Description: "a", Rating: -1, Image: "ftp://example.com/a.jpg"},
+			expectedFields: []string{"rating", "image"},
+			expectedCodes:  []string{"gte", "imageurl"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(tc.data)
+			var validationErrors ValidationErrors
+			if len(tc.expectedFields) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.As(err, &validationErrors) {
+				t.Fatalf("expected ValidationErrors, got %v", err)
+			}
+			if len(validationErrors) != len(tc.expectedFields) {
+				t.Fatalf("expected %d errors, got %v", len(tc.expectedFields), validationErrors)
+			}
+			for i, fieldError := range validationErrors {
+				if fieldError.Field != tc.expectedFields[i] || fieldError.Code != tc.expectedCodes[i] {
+					t.Errorf("expected %s/%s, got %s/%s", tc.expectedFields[i], tc.expectedCodes[i], fieldError.Field, fieldError.Code)
+				}
+			}
+		})
+	}
+}
+
+func TestValidate_OneOfAndDate(t *testing.T) {
+	testCases := []struct {
+		name         string
+		data         any
+		expectedCode string
+	}{
+		{name: "Valid credit", data: request.CreateCredit{PersonID: 1, Role: "actor"}},
+		{name: "Unknown role", data: request.CreateCredit{PersonID: 1, Role: "producer"}, expectedCode: "oneof"},
+		{name: "Valid birth date", data: request.CreatePerson{Name: "a", BirthDate: "1970-01-31"}},
+		{name: "Empty birth date", data: request.CreatePerson{Name: "a"}},
+		{name: "Invalid birth date", data: request.CreatePerson{Name: "a", BirthDate: "31/01/1970"}, expectedCode: "date"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(tc.data)
+			if tc.expectedCode == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var validationErrors ValidationErrors
+			if !errors.As(err, &validationErrors) || len(validationErrors) != 1 || validationErrors[0].Code != tc.expectedCode {
+				t.Fatalf("expected %s error, got %v", tc.expectedCode, err)
+			}
+		})
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	date, err := ParseDate("release_date", "2010-07-16")
+	if err != nil || date == nil || date.Format(DateLayout) != "2010-07-16" {
+		t.Fatalf("unexpected result %v %v", date, err)
+	}
+	if date, err := ParseDate("release_date", ""); err != nil || date != nil {
+		t.Fatalf("expected empty date to parse to nil, got %v %v", date, err)
+	}
+
+	_, err = ParseDate("release_date", "2010-13-45")
+	var validationErrors ValidationErrors
+	if !errors.As(err, &validationErrors) || len(validationErrors) != 1 || validationErrors[0].Field != "release_date" || validationErrors[0].Code != "date" {
+		t.Fatalf("expected date error, got %v", err)
+	}
+	if validationErrors[0].Message != "Release Date Must Be A Date In YYYY-MM-DD Format" {
+		t.Errorf("unexpected message %q", validationErrors[0].Message)
+	}
+}
+
+func TestValidate_CodeLists(t *testing.T) {
+	testCases := []struct {
+		name         string
+		data         any
+		expectedCode string
+	}{
+		{name: "Valid metadata", data: request.ReplaceMovie{Title: "a", Description: "a", Image: "a.jpg", OriginalLanguage: "ja", SpokenLanguages: []string{"ja", "en"}, ProductionCountries: []string{"JP"}, Certifications: map[string]string{"JP": "PG12"}}},
+		{name: "Empty metadata", data: request.ReplaceMovie{Title: "a", Description: "a", Image: "a.jpg"}},
+		{name: "Unknown language", data: request.ReplaceMovie{Title: "a", Description: "a", Image: "a.jpg", OriginalLanguage: "jp"}, expectedCode: "language"},
+		{name: "Three letter language", data: request.ReplaceMovie{Title: "a", Description: "a", Image: "a.jpg", SpokenLanguages: []string{"eng"}}, expectedCode: "languages"},
+		{name: "Lowercase country", data: request.ReplaceMovie{Title: "a", Description: "a", Image: "a.jpg", ProductionCountries: []string{"us"}}, expectedCode: "countries"},
+		{name: "Unknown certification country", data: request.ReplaceMovie{Title: "a", Description: "a", Image: "a.jpg", Certifications: map[string]string{"XX": "PG"}}, expectedCode: "certifications"},
+		{name: "Rating from another system", data: request.ReplaceMovie{Title: "a", Description: "a", Image: "a.jpg", Certifications: map[string]string{"US": "12A"}}, expectedCode: "certifications"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(tc.data)
+			if tc.expectedCode == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var validationErrors ValidationErrors
+			if !errors.As(err, &validationErrors) || len(validationErrors) != 1 || validationErrors[0].Code != tc.expectedCode {
+				t.Fatalf("expected %s error, got %v", tc.expectedCode, err)
+			}
+		})
+	}
+}
+
+func TestValidationErrorJson(t *testing.T) {
+	w := httptest.NewRecorder()
+	ValidationErrorJson(w, ValidationErrors{{Field: "rating", Code: "lte", Message: "Rating Must Be Less Than Or Equal To 10", Value: 11}})
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected status %d, got %d", http.StatusUnprocessableEntity, w.Code)
+	}
+	var body struct {
+		Error bool         `json:"error"`
+		Data  []FieldError `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !body.Error || len(body.Data) != 1 || body.Data[0].Field != "rating" || body.Data[0].Value != float64(11) {
+		t.Errorf("unexpected body %s", w.Body.String())
+	}
+}
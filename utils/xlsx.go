@@ -0,0 +1,106 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+const xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`
+
+const xlsxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
+
+const xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+const xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`
+
+const xlsxSheetHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+const xlsxSheetFooter = `</sheetData></worksheet>`
+
+type XLSXWriter struct {
+	archive *zip.Writer
+	sheet   io.Writer
+	rows    int
+	buffer  bytes.Buffer
+}
+
+func NewXLSXWriter(w io.Writer, sheetName string) (*XLSXWriter, error) {
+	archive := zip.NewWriter(w)
+	var name bytes.Buffer
+	if err := xml.EscapeText(&name, []byte(sheetName)); err != nil {
+		return nil, err
+	}
+
+	parts := []struct{ path, content string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbook, name.String())},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+	}
+	for _, part := range parts {
+		file, err := archive.Create(part.path)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(file, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	sheet, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(sheet, xlsxSheetHeader); err != nil {
+		return nil, err
+	}
+	return &XLSXWriter{archive: archive, sheet: sheet}, nil
+}
+
+func (xw *XLSXWriter) WriteRow(values ...any) error {
+	xw.rows++
+	xw.buffer.Reset()
+	fmt.Fprintf(&xw.buffer, `<row r="%d">`, xw.rows)
+	for _, value := range values {
+		switch value := value.(type) {
+		case int:
+			fmt.Fprintf(&xw.buffer, `<c><v>%d</v></c>`, value)
+		case int64:
+			fmt.Fprintf(&xw.buffer, `<c><v>%d</v></c>`, value)
+		case float32:
+			fmt.Fprintf(&xw.buffer, `<c><v>%s</v></c>`, strconv.FormatFloat(float64(value), 'f', -1, 32))
+		case float64:
+			fmt.Fprintf(&xw.buffer, `<c><v>%s</v></c>`, strconv.FormatFloat(value, 'f', -1, 64))
+		case time.Time:
+			xw.writeString(value.Format("2006-01-02 15:04:05"))
+		default:
+			xw.writeString(fmt.Sprint(value))
+		}
+	}
+	xw.buffer.WriteString(`</row>`)
+	_, err := xw.sheet.Write(xw.buffer.Bytes())
+	return err
+}
+
+func (xw *XLSXWriter) writeString(value string) {
+	xw.buffer.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+	xml.EscapeText(&xw.buffer, []byte(value))
+	xw.buffer.WriteString(`</t></is></c>`)
+}
+
+func (xw *XLSXWriter) Close() error {
+	if _, err := io.WriteString(xw.sheet, xlsxSheetFooter); err != nil {
+		return err
+	}
+	return xw.archive.Close()
+}
@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestXLSXWriter(t *testing.T) {
+	var out bytes.Buffer
+	writer, err := NewXLSXWriter(&out, "Movies & Shows")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.WriteRow("id", "title"); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.WriteRow(int64(1), "Tom & Jerry <3", float32(7.5)); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := make(map[string]string)
+	for _, file := range archive.File {
+		reader, _ := file.Open()
+		content, _ := io.ReadAll(reader)
+		reader.Close()
+		parts[file.Name] = string(content)
+	}
+
+	if _, ok := parts["[Content_Types].xml"]; !ok {
+		t.Errorf("expected [Content_Types].xml in archive")
+	}
+	if !strings.Contains(parts["xl/workbook.xml"], `name="Movies &amp; Shows"`) {
+		t.Errorf("expected escaped sheet name, got %s", parts["xl/workbook.xml"])
+	}
+	row := `<row r="2"><c><v>1</v></c><c t="inlineStr"><is><t xml:space="preserve">Tom &amp; Jerry &lt;3</t></is></c><c><v>7.5</v></c></row>`
+	if sheet := parts["xl/worksheets/sheet1.xml"]; !strings.Contains(sheet, row) || !strings.HasSuffix(sheet, "</sheetData></worksheet>") {
+		t.Errorf("unexpected sheet %s", sheet)
+	}
+}